@@ -0,0 +1,362 @@
+package blobber
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+)
+
+// DefaultChunkSize is how much of a file a single upload request carries
+const DefaultChunkSize = 4 * BlockSize
+
+// Endpoints of the blobber REST protocol, each is followed by the allocation ID
+const (
+	UploadEndpoint        = "/v1/file/upload/"
+	CommitEndpoint        = "/v1/connection/commit/"
+	ListEndpoint          = "/v1/file/list/"
+	ReferencePathEndpoint = "/v1/file/referencepath/"
+	DownloadEndpoint      = "/v1/file/download/"
+)
+
+// Blobber is a blobber an allocation is stored on
+type Blobber struct {
+	ID  string
+	URL string
+}
+
+// Client talks to blobbers on behalf of the owner of an allocation
+type Client struct {
+	Zerochain *endpoint.Zerochain
+	ClientID  string
+	ClientKey string
+	Keys      *model.KeyPair
+	// ChunkSize is the size of the parts a file is uploaded in, DefaultChunkSize when not positive
+	ChunkSize int
+
+	mu           sync.Mutex
+	readCounters map[string]int64
+}
+
+func NewClient(zerochain *endpoint.Zerochain, clientID string, keys *model.KeyPair) *Client {
+	return &Client{
+		Zerochain:    zerochain,
+		ClientID:     clientID,
+		ClientKey:    keys.PublicKey,
+		Keys:         keys,
+		readCounters: make(map[string]int64),
+	}
+}
+
+// headers authenticate the client as the owner of allocationID
+func (c *Client) headers(allocationID string) (map[string]string, error) {
+	signature, err := crypto.Sign(crypto.Sha3256([]byte(allocationID)), c.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"X-App-Client-Id":        c.ClientID,
+		"X-App-Client-Key":       c.ClientKey,
+		"X-App-Client-Signature": signature,
+	}, nil
+}
+
+// Connection groups uploads to one blobber which are committed together with a single write marker
+type Connection struct {
+	ID           string
+	Blobber      Blobber
+	AllocationID string
+
+	client *Client
+	files  []model.BlobberFileRef
+}
+
+func (c *Client) NewConnection(blobber Blobber, allocationID string) *Connection {
+	return &Connection{ID: crypto.NewConnectionID(), Blobber: blobber, AllocationID: allocationID, client: c}
+}
+
+// Upload sends content to remotePath in chunks of ChunkSize, the hashes are sent with the final chunk once the whole content was read
+func (conn *Connection) Upload(ctx context.Context, remotePath string, content io.Reader) (*model.BlobberUploadFileResponse, error) {
+	chunkSize := conn.client.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	hasher := NewFileHasher()
+	var response *model.BlobberUploadFileResponse
+
+	chunk, err := readChunk(content, chunkSize)
+	for {
+		if err != nil {
+			return nil, fmt.Errorf("failed to read content of [%s]: %w", remotePath, err)
+		}
+		next, nextErr := readChunk(content, chunkSize)
+		final := len(next) == 0 && nextErr == nil
+
+		if _, err := hasher.Write(chunk); err != nil {
+			return nil, fmt.Errorf("failed to hash content of [%s]: %w", remotePath, err)
+		}
+
+		meta := model.BlobberUploadFileMeta{
+			ConnectionID: conn.ID,
+			FileName:     path.Base(remotePath),
+			FilePath:     remotePath,
+			MimeType:     mime.TypeByExtension(path.Ext(remotePath)),
+			IsFinal:      final,
+		}
+		var ref model.BlobberFileRef
+		if final {
+			hashes, err := hasher.Hashes()
+			if err != nil {
+				return nil, fmt.Errorf("failed to hash content of [%s]: %w", remotePath, err)
+			}
+			ref = ShardRef(remotePath, hashes, 0)
+			ref.MimeType = meta.MimeType
+			meta.ContentHash = ref.ContentHash
+			meta.MerkleRoot = ref.MerkleRoot
+			meta.ActualHash = ref.ActualFileHash
+			meta.ActualSize = ref.ActualFileSize
+			meta.ChunkSize = ref.ChunkSize
+		}
+
+		response, err = conn.uploadChunk(ctx, chunk, meta)
+		if err != nil {
+			return nil, err
+		}
+		if final {
+			conn.files = append(conn.files, ref)
+			return response, nil
+		}
+
+		chunk, err = next, nextErr
+	}
+}
+
+func (conn *Connection) uploadChunk(ctx context.Context, chunk []byte, meta model.BlobberUploadFileMeta) (*model.BlobberUploadFileResponse, error) {
+	payload := new(bytes.Buffer)
+	writer := multipart.NewWriter(payload)
+
+	uploadFile, err := writer.CreateFormFile("uploadFile", meta.FileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = uploadFile.Write(chunk); err != nil {
+		return nil, err
+	}
+	if err = writer.WriteField("connection_id", meta.ConnectionID); err != nil {
+		return nil, err
+	}
+	metaData, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	if err = writer.WriteField("uploadMeta", string(metaData)); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	headers, err := conn.client.headers(conn.AllocationID)
+	if err != nil {
+		return nil, err
+	}
+	headers["Content-Type"] = writer.FormDataContentType()
+
+	var response *model.BlobberUploadFileResponse
+	_, err = conn.client.Zerochain.PostToBlobber(ctx, conn.Blobber.URL, UploadEndpoint+conn.AllocationID, headers, nil, payload.Bytes(), &response)
+
+	return response, err
+}
+
+// readChunk reads up to size bytes, an empty chunk without error means content is exhausted
+func readChunk(content io.Reader, size int) ([]byte, error) {
+	chunk := make([]byte, size)
+	n, err := io.ReadFull(content, chunk)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		err = nil
+	}
+
+	return chunk[:n], err
+}
+
+// Commit builds a write marker on top of the latest one of the blobber, covering every file uploaded in the connection, and commits it
+func (conn *Connection) Commit(ctx context.Context) (*model.BlobberCommitConnectionResponse, error) {
+	if len(conn.files) == 0 {
+		return nil, errors.New("nothing was uploaded in the connection")
+	}
+
+	referencePath, err := conn.client.ReferencePath(ctx, conn.Blobber, conn.AllocationID, "/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the reference path to build the write marker on: %w", err)
+	}
+
+	tree := TreeFromReferencePath(conn.AllocationID, &referencePath.BlobberReferencePath)
+	writeMarker := model.BlobberCommitConnectionWriteMarker{
+		AllocationID: conn.AllocationID,
+		BlobberID:    conn.Blobber.ID,
+		ClientID:     conn.client.ClientID,
+		Timestamp:    time.Now().Unix(),
+	}
+	if referencePath.LatestWM != nil {
+		writeMarker.PreviousAllocationRoot = referencePath.LatestWM.AllocationRoot
+	}
+	for _, file := range conn.files {
+		tree.Put(file)
+		writeMarker.Size += file.Size
+		writeMarker.Name = path.Base(file.Path)
+		writeMarker.ContentHash = file.ContentHash
+		writeMarker.LookupHash = LookupHash(conn.AllocationID, file.Path)
+	}
+	writeMarker.AllocationRoot = AllocationRoot(tree.CalculateHash(), writeMarker.Timestamp)
+	if err := crypto.SignWriteMarker(&writeMarker, conn.client.Keys); err != nil {
+		return nil, err
+	}
+
+	marker, err := json.Marshal(writeMarker)
+	if err != nil {
+		return nil, err
+	}
+	formData := map[string]string{
+		"connection_id": conn.ID,
+		"write_marker":  string(marker),
+	}
+	headers := map[string]string{
+		"X-App-Client-Id":  conn.client.ClientID,
+		"X-App-Client-Key": conn.client.ClientKey,
+	}
+
+	var response *model.BlobberCommitConnectionResponse
+	if _, err = conn.client.Zerochain.PostToBlobber(ctx, conn.Blobber.URL, CommitEndpoint+conn.AllocationID, headers, formData, nil, &response); err != nil {
+		return nil, err
+	}
+	conn.files = nil
+
+	return response, nil
+}
+
+// List returns the ref of remotePath with its direct children
+func (c *Client) List(ctx context.Context, blobber Blobber, allocationID, remotePath string) (*model.BlobberListFilesResponse, error) {
+	params := map[string]string{
+		"path_hash":  LookupHash(allocationID, remotePath),
+		"path":       remotePath,
+		"auth_token": "",
+	}
+
+	headers, err := c.headers(allocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *model.BlobberListFilesResponse
+	_, err = c.Zerochain.GetFromBlobber(ctx, blobber.URL, ListEndpoint+allocationID, headers, params, &response)
+
+	return response, err
+}
+
+// ReferencePath returns the reference tree of paths together with the latest write marker of the blobber
+func (c *Client) ReferencePath(ctx context.Context, blobber Blobber, allocationID string, paths ...string) (*model.BlobberGetFileReferencePathResponse, error) {
+	encodedPaths, err := json.Marshal(paths)
+	if err != nil {
+		return nil, err
+	}
+	headers, err := c.headers(allocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *model.BlobberGetFileReferencePathResponse
+	_, err = c.Zerochain.GetFromBlobber(ctx, blobber.URL, ReferencePathEndpoint+allocationID, headers, map[string]string{"paths": string(encodedPaths)}, &response)
+
+	return response, err
+}
+
+// Download returns numBlocks blocks of remotePath starting at the 1 based blockNum, paying for them with a read marker
+func (c *Client) Download(ctx context.Context, blobber Blobber, allocationID, ownerID, remotePath string, blockNum, numBlocks int64) (*model.BlobberDownloadFileResponse, error) {
+	counterKey := blobber.ID + ":" + allocationID
+
+	c.mu.Lock()
+	if c.readCounters == nil {
+		c.readCounters = make(map[string]int64)
+	}
+	readMarker := model.BlobberDownloadFileReadMarker{
+		ClientID:     c.ClientID,
+		ClientKey:    c.ClientKey,
+		BlobberID:    blobber.ID,
+		AllocationID: allocationID,
+		OwnerID:      ownerID,
+		Timestamp:    time.Now().Unix(),
+		Counter:      c.readCounters[counterKey] + numBlocks,
+	}
+	c.readCounters[counterKey] = readMarker.Counter
+	c.mu.Unlock()
+
+	if err := crypto.SignReadMarker(&readMarker, c.Keys); err != nil {
+		return nil, err
+	}
+
+	marker, err := json.Marshal(readMarker)
+	if err != nil {
+		return nil, err
+	}
+
+	formData := map[string]string{
+		"path_hash":   LookupHash(allocationID, remotePath),
+		"block_num":   strconv.FormatInt(blockNum, 10),
+		"num_blocks":  strconv.FormatInt(numBlocks, 10),
+		"read_marker": string(marker),
+	}
+	headers := map[string]string{
+		"X-App-Client-Id":  c.ClientID,
+		"X-App-Client-Key": c.ClientKey,
+	}
+
+	var response *model.BlobberDownloadFileResponse
+	_, err = c.Zerochain.PostToBlobber(ctx, blobber.URL, DownloadEndpoint+allocationID, headers, formData, nil, &response)
+
+	return response, err
+}
+
+// DownloadFile downloads the whole of remotePath and checks it against the content hash the blobber has for it
+func (c *Client) DownloadFile(ctx context.Context, blobber Blobber, allocationID, ownerID, remotePath string) ([]byte, error) {
+	listed, err := c.List(ctx, blobber, allocationID, remotePath)
+	if err != nil {
+		return nil, err
+	}
+	if listed.Meta.Type != FileType {
+		return nil, fmt.Errorf("[%s] is not a file", remotePath)
+	}
+
+	var content []byte
+	for blockNum := int64(1); blockNum <= listed.Meta.NumBlocks; blockNum += DefaultChunkSize / BlockSize {
+		numBlocks := int64(DefaultChunkSize / BlockSize)
+		if remaining := listed.Meta.NumBlocks - blockNum + 1; remaining < numBlocks {
+			numBlocks = remaining
+		}
+
+		downloaded, err := c.Download(ctx, blobber, allocationID, ownerID, remotePath, blockNum, numBlocks)
+		if err != nil {
+			return nil, err
+		}
+		content = append(content, downloaded.Data...)
+	}
+
+	if hash := ContentHash(content); hash != listed.Meta.ContentHash {
+		return nil, fmt.Errorf("content hash [%s] of [%s] does not match the one of the blobber [%s]", hash, remotePath, listed.Meta.ContentHash)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,96 @@
+package blobber
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+)
+
+// Types of the refs in the reference tree of an allocation
+const (
+	FileType      = "f"
+	DirectoryType = "d"
+)
+
+// A file uploaded to a single blobber is its only data shard, hashed in chunks of BlockSize
+const (
+	DataShards   = 1
+	ParityShards = 0
+)
+
+// NewFileHasher hashes a file uploaded to a single blobber while it is uploaded
+func NewFileHasher() *crypto.FileHasher {
+	hasher, err := crypto.NewFileHasher(DataShards, ParityShards, BlockSize)
+	if err != nil {
+		panic(err) // a valid number of shards and chunk size can not fail
+	}
+
+	return hasher
+}
+
+// FileHashes are the hashes gosdk uploads content to a single blobber with
+func FileHashes(content []byte) *crypto.FileHashes {
+	hasher := NewFileHasher()
+	_, _ = hasher.Write(content)
+	hashes, err := hasher.Hashes()
+	if err != nil {
+		panic(err) // a single data shard without parity is never encoded
+	}
+
+	return hashes
+}
+
+// ContentHash is the content_hash of content uploaded to a single blobber, the compact merkle root over its chunks
+func ContentHash(content []byte) string {
+	return FileHashes(content).Shards[0].ContentHash
+}
+
+// LookupHash identifies a path of an allocation, it is what path_hash parameters expect
+func LookupHash(allocationID, path string) string {
+	return crypto.Sha3256([]byte(allocationID + ":" + path))
+}
+
+// FileRefHash hashes the metadata of a file the way gosdk and blobbers do
+func FileRefHash(allocationID string, ref *model.BlobberFileRef) string {
+	return crypto.Sha3256([]byte(strings.Join([]string{
+		allocationID,
+		ref.Type,
+		ref.Name,
+		ref.Path,
+		strconv.FormatInt(ref.Size, 10),
+		ref.ContentHash,
+		ref.MerkleRoot,
+		strconv.FormatInt(ref.ActualFileSize, 10),
+		ref.ActualFileHash,
+		strconv.FormatInt(ref.ChunkSize, 10),
+	}, ":")))
+}
+
+// DirectoryRefHash hashes a directory over the hashes of its children, ordered by name
+func DirectoryRefHash(childHashes []string) string {
+	return crypto.Sha3256([]byte(strings.Join(childHashes, ":")))
+}
+
+// AllocationRoot is what a write marker commits to, the hash of the root directory at the time of the marker
+func AllocationRoot(rootHash string, timestamp int64) string {
+	return crypto.Sha3256([]byte(rootHash + ":" + strconv.FormatInt(timestamp, 10)))
+}
+
+// ShardRef is the ref the blobber of shard stores for a file uploaded to remotePath with hashes,
+// put it into the tree of that blobber to calculate the allocation root the upload commits to
+func ShardRef(remotePath string, hashes *crypto.FileHashes, shard int) model.BlobberFileRef {
+	return model.BlobberFileRef{
+		Type:           FileType,
+		Name:           path.Base(remotePath),
+		Path:           remotePath,
+		Size:           hashes.Shards[shard].Size,
+		ContentHash:    hashes.Shards[shard].ContentHash,
+		MerkleRoot:     hashes.Shards[shard].ValidationRoot,
+		ActualFileHash: hashes.ActualHash,
+		ActualFileSize: hashes.ActualSize,
+		ChunkSize:      hashes.ChunkSize,
+	}
+}
@@ -0,0 +1,49 @@
+package blobber
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileHashes(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.Repeat([]byte("0chain"), 3*BlockSize/6+100)
+
+	t.Run("Single blobber uploads should hash content as the one data shard of gosdk", func(t *testing.T) {
+		t.Parallel()
+
+		expected, err := crypto.HashShards(bytes.NewReader(content), 1, 0, BlockSize)
+		require.Nil(t, err)
+		require.Equal(t, expected, FileHashes(content))
+		require.Equal(t, expected.Shards[0].ContentHash, ContentHash(content))
+	})
+
+	t.Run("Content hashes should be sha256 based and not the hash of the whole file", func(t *testing.T) {
+		t.Parallel()
+
+		leaf := sha256.Sum256([]byte("small"))
+		hexLeaf := hex.EncodeToString(leaf[:])
+		require.Equal(t, crypto.MerkleHash(hexLeaf, hexLeaf), ContentHash([]byte("small")))
+		require.NotEqual(t, FileHashes(content).ActualHash, ContentHash(content))
+	})
+
+	t.Run("Shard refs should carry the hashes of their shard and of the whole file", func(t *testing.T) {
+		t.Parallel()
+
+		hashes := FileHashes(content)
+		ref := ShardRef("/dir/file.bin", hashes, 0)
+		require.Equal(t, FileType, ref.Type)
+		require.Equal(t, "file.bin", ref.Name)
+		require.Equal(t, int64(len(content)), ref.Size)
+		require.Equal(t, hashes.Shards[0].ContentHash, ref.ContentHash)
+		require.Equal(t, hashes.Shards[0].ValidationRoot, ref.MerkleRoot)
+		require.Equal(t, hashes.ActualHash, ref.ActualFileHash)
+		require.Equal(t, int64(BlockSize), ref.ChunkSize)
+	})
+}
@@ -0,0 +1,175 @@
+package blobber
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/0chain/system_test/internal/api/model"
+)
+
+// BlockSize is the size of the blocks files are hashed, downloaded and paid for in
+const BlockSize = 64 * 1024
+
+// Ref is a file or directory of a reference tree
+type Ref struct {
+	model.BlobberFileRef
+	Children []*Ref
+}
+
+// Tree is the reference tree of an allocation on one blobber, Put and CalculateHash mirror what a blobber does on commit
+type Tree struct {
+	AllocationID string
+	Root         *Ref
+}
+
+func NewTree(allocationID string) *Tree {
+	tree := &Tree{
+		AllocationID: allocationID,
+		Root:         &Ref{BlobberFileRef: model.BlobberFileRef{Type: DirectoryType, Name: "/", Path: "/"}},
+	}
+	tree.CalculateHash()
+
+	return tree
+}
+
+// TreeFromReferencePath rebuilds the tree a blobber returned from its reference path endpoint
+func TreeFromReferencePath(allocationID string, referencePath *model.BlobberReferencePath) *Tree {
+	var build func(*model.BlobberReferencePath) *Ref
+	build = func(node *model.BlobberReferencePath) *Ref {
+		ref := &Ref{BlobberFileRef: node.Meta}
+		for _, child := range node.List {
+			ref.Children = append(ref.Children, build(child))
+		}
+
+		return ref
+	}
+
+	return &Tree{AllocationID: allocationID, Root: build(referencePath)}
+}
+
+// Put adds file to the tree, creating missing directories and replacing a file of the same path
+func (t *Tree) Put(file model.BlobberFileRef) {
+	parent := t.Root
+	if dir := path.Dir(file.Path); dir != "/" {
+		for _, name := range strings.Split(strings.TrimPrefix(dir, "/"), "/") {
+			parent = parent.child(name)
+		}
+	}
+
+	file.Type = FileType
+	file.Name = path.Base(file.Path)
+	for i, existing := range parent.Children {
+		if existing.Name == file.Name {
+			parent.Children[i] = &Ref{BlobberFileRef: file}
+			return
+		}
+	}
+	parent.Children = append(parent.Children, &Ref{BlobberFileRef: file})
+	parent.sortChildren()
+}
+
+// Delete removes the file or directory at remotePath from the tree, it reports whether there was one
+func (t *Tree) Delete(remotePath string) bool {
+	parent := t.Find(LookupHash(t.AllocationID, path.Dir(remotePath)))
+	if parent == nil || remotePath == "/" {
+		return false
+	}
+
+	for i, child := range parent.Children {
+		if child.Path == remotePath {
+			parent.Children = append(parent.Children[:i], parent.Children[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Find returns the ref of lookupHash, nil when there is none
+func (t *Tree) Find(lookupHash string) *Ref {
+	var find func(*Ref) *Ref
+	find = func(ref *Ref) *Ref {
+		if LookupHash(t.AllocationID, ref.Path) == lookupHash {
+			return ref
+		}
+		for _, child := range ref.Children {
+			if found := find(child); found != nil {
+				return found
+			}
+		}
+
+		return nil
+	}
+
+	return find(t.Root)
+}
+
+// CalculateHash updates the hash, size, block count and lookup hash of every ref and returns the hash of the root
+func (t *Tree) CalculateHash() string {
+	var calculate func(*Ref)
+	calculate = func(ref *Ref) {
+		ref.LookupHash = LookupHash(t.AllocationID, ref.Path)
+
+		if ref.Type == FileType {
+			ref.NumBlocks = (ref.Size + BlockSize - 1) / BlockSize
+			ref.Hash = FileRefHash(t.AllocationID, &ref.BlobberFileRef)
+			return
+		}
+
+		ref.Size, ref.NumBlocks = 0, 0
+		hashes := make([]string, len(ref.Children))
+		for i, child := range ref.Children {
+			calculate(child)
+			hashes[i] = child.Hash
+			ref.Size += child.Size
+			ref.NumBlocks += child.NumBlocks
+		}
+		ref.Hash = DirectoryRefHash(hashes)
+	}
+	calculate(t.Root)
+
+	return t.Root.Hash
+}
+
+func (t *Tree) Clone() *Tree {
+	var clone func(*Ref) *Ref
+	clone = func(ref *Ref) *Ref {
+		cloned := &Ref{BlobberFileRef: ref.BlobberFileRef}
+		for _, child := range ref.Children {
+			cloned.Children = append(cloned.Children, clone(child))
+		}
+
+		return cloned
+	}
+
+	return &Tree{AllocationID: t.AllocationID, Root: clone(t.Root)}
+}
+
+// ReferencePath returns ref and everything below it the way the reference path endpoint does
+func (r *Ref) ReferencePath() *model.BlobberReferencePath {
+	referencePath := &model.BlobberReferencePath{Meta: r.BlobberFileRef}
+	for _, child := range r.Children {
+		referencePath.List = append(referencePath.List, child.ReferencePath())
+	}
+
+	return referencePath
+}
+
+func (r *Ref) child(name string) *Ref {
+	for _, existing := range r.Children {
+		if existing.Name == name {
+			return existing
+		}
+	}
+
+	created := &Ref{BlobberFileRef: model.BlobberFileRef{Type: DirectoryType, Name: name, Path: path.Join(r.Path, name)}}
+	r.Children = append(r.Children, created)
+	r.sortChildren()
+
+	return created
+}
+
+func (r *Ref) sortChildren() {
+	sort.Slice(r.Children, func(i, j int) bool { return r.Children[i].Name < r.Children[j].Name })
+}
@@ -12,13 +12,14 @@ import (
 	"time"
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
-	"github.com/herumi/bls-go-binary/bls"
+	"github.com/0chain/system_test/internal/currency"
 )
 
 type Balance struct {
-	Txn     string `json:"txn"`
-	Round   int64  `json:"round"`
-	Balance int64  `json:"balance"`
+	Txn     string        `json:"txn"`
+	Round   int64         `json:"round"`
+	Balance currency.Coin `json:"balance"`
+	Nonce   int           `json:"nonce"`
 }
 
 type TransactionResponse struct {
@@ -27,40 +28,40 @@ type TransactionResponse struct {
 }
 
 type Transaction struct {
-	Hash              string `json:"hash"`
-	Version           string `json:"version"`
-	ClientId          string `json:"client_id"`
-	ToClientId        string `json:"to_client_id"`
-	ChainId           string `json:"chain_id"`
-	PublicKey         string `json:"public_key,omitempty"`
-	TransactionData   string `json:"transaction_data"`
-	TransactionValue  int64  `json:"transaction_value"`
-	Signature         string `json:"signature"`
-	CreationDate      int64  `json:"creation_date"`
-	TransactionFee    int64  `json:"transaction_fee"`
-	TransactionType   int    `json:"transaction_type"`
-	TransactionOutput string `json:"transaction_output,omitempty"`
-	TxnOutputHash     string `json:"txn_output_hash"`
-	TransactionStatus int    `json:"transaction_status"`
-	TransactionNonce  int    `json:"transaction_nonce"`
+	Hash              string        `json:"hash"`
+	Version           string        `json:"version"`
+	ClientId          string        `json:"client_id"`
+	ToClientId        string        `json:"to_client_id"`
+	ChainId           string        `json:"chain_id"`
+	PublicKey         string        `json:"public_key,omitempty"`
+	TransactionData   string        `json:"transaction_data"`
+	TransactionValue  currency.Coin `json:"transaction_value"`
+	Signature         string        `json:"signature"`
+	CreationDate      int64         `json:"creation_date"`
+	TransactionFee    currency.Coin `json:"transaction_fee"`
+	TransactionType   int           `json:"transaction_type"`
+	TransactionOutput string        `json:"transaction_output,omitempty"`
+	TxnOutputHash     string        `json:"txn_output_hash"`
+	TransactionStatus int           `json:"transaction_status"`
+	TransactionNonce  int           `json:"transaction_nonce"`
 }
 
 type EventDbTransaction struct {
-	Hash              string `json:"hash" `
-	BlockHash         string `json:"block_hash"`
-	Round             int64  `json:"round"`
-	Version           string `json:"version"`
-	ClientId          string `json:"client_id" `
-	ToClientId        string `json:"to_client_id" `
-	TransactionData   string `json:"transaction_data"`
-	Value             int64  `json:"value"`
-	Signature         string `json:"signature"`
-	CreationDate      int64  `json:"creation_date"  `
-	Fee               int64  `json:"fee"`
-	TransactionType   int    `json:"transaction_type"`
-	TransactionOutput string `json:"transaction_output"`
-	OutputHash        string `json:"output_hash"`
-	Status            int    `json:"status"`
+	Hash              string        `json:"hash" `
+	BlockHash         string        `json:"block_hash"`
+	Round             int64         `json:"round"`
+	Version           string        `json:"version"`
+	ClientId          string        `json:"client_id" `
+	ToClientId        string        `json:"to_client_id" `
+	TransactionData   string        `json:"transaction_data"`
+	Value             currency.Coin `json:"value"`
+	Signature         string        `json:"signature"`
+	CreationDate      int64         `json:"creation_date"  `
+	Fee               currency.Coin `json:"fee"`
+	TransactionType   int           `json:"transaction_type"`
+	TransactionOutput string        `json:"transaction_output"`
+	OutputHash        string        `json:"output_hash"`
+	Status            int           `json:"status"`
 }
 
 type SmartContractTxnData struct {
@@ -70,33 +71,52 @@ type SmartContractTxnData struct {
 
 type Block struct {
 	Block struct {
-		Version                        string `json:"version"`
-		CreationDate                   int64  `json:"creation_date"`
-		LatestFinalizedMagicBlockHash  string `json:"latest_finalized_magic_block_hash"`
-		LatestFinalizedMagicBlockRound int64  `json:"latest_finalized_magic_block_round"`
-		PrevHash                       string `json:"prev_hash"`
-		PrevVerificationTickets        []struct {
-			VerifierId string `json:"verifier_id"`
-			Signature  string `json:"signature"`
-		} `json:"prev_verification_tickets"`
-		MinerId             string         `json:"miner_id"`
-		Round               int64          `json:"round"`
-		RoundRandomSeed     int64          `json:"round_random_seed"`
-		RoundTimeoutCount   int64          `json:"round_timeout_count"`
-		StateHash           string         `json:"state_hash"`
-		Transactions        []*Transaction `json:"transactions"`
-		VerificationTickets []struct {
-			VerifierId string `json:"verifier_id"`
-			Signature  string `json:"signature"`
-		} `json:"verification_tickets"`
-		Hash            string  `json:"hash"`
-		Signature       string  `json:"signature"`
-		ChainId         string  `json:"chain_id"`
-		ChainWeight     float64 `json:"chain_weight"`
-		RunningTxnCount int     `json:"running_txn_count"`
+		Version                        string                `json:"version"`
+		CreationDate                   int64                 `json:"creation_date"`
+		LatestFinalizedMagicBlockHash  string                `json:"latest_finalized_magic_block_hash"`
+		LatestFinalizedMagicBlockRound int64                 `json:"latest_finalized_magic_block_round"`
+		PrevHash                       string                `json:"prev_hash"`
+		PrevVerificationTickets        []*VerificationTicket `json:"prev_verification_tickets"`
+		MinerId                        string                `json:"miner_id"`
+		Round                          int64                 `json:"round"`
+		RoundRandomSeed                int64                 `json:"round_random_seed"`
+		RoundTimeoutCount              int64                 `json:"round_timeout_count"`
+		StateHash                      string                `json:"state_hash"`
+		Transactions                   []*Transaction        `json:"transactions"`
+		VerificationTickets            []*VerificationTicket `json:"verification_tickets"`
+		Hash                           string                `json:"hash"`
+		Signature                      string                `json:"signature"`
+		ChainId                        string                `json:"chain_id"`
+		ChainWeight                    float64               `json:"chain_weight"`
+		RunningTxnCount                int                   `json:"running_txn_count"`
+		MagicBlock                     *MagicBlock           `json:"magic_block,omitempty"`
 	} `json:"block"`
 }
 
+// MagicBlock is the set of nodes running the chain from StartingRound on, only magic blocks carry one
+type MagicBlock struct {
+	Hash             string          `json:"hash"`
+	MagicBlockNumber int64           `json:"magic_block_number"`
+	StartingRound    int64           `json:"starting_round"`
+	Miners           *MagicBlockPool `json:"miners"`
+	Sharders         *MagicBlockPool `json:"sharders"`
+}
+
+type MagicBlockPool struct {
+	Nodes map[string]*MagicBlockNode `json:"nodes"`
+}
+
+type MagicBlockNode struct {
+	ID        string `json:"id"`
+	PublicKey string `json:"public_key"`
+}
+
+// VerificationTicket is the signature of a miner over the hash of a block it verified
+type VerificationTicket struct {
+	VerifierId string `json:"verifier_id"`
+	Signature  string `json:"signature"`
+}
+
 type EventDbBlock struct {
 	Hash                  string               `json:"hash"`
 	Version               string               `json:"version"`
@@ -128,11 +148,29 @@ type LatestFinalizedBlock struct {
 	NumTxns           int    `json:"num_txns"`
 }
 
+type BlockHeader struct {
+	Header *BlockSummary `json:"header"`
+}
+
+type BlockSummary struct {
+	Hash                  string `json:"hash"`
+	Version               string `json:"version"`
+	CreationDate          int64  `json:"creation_date"`
+	Round                 int64  `json:"round"`
+	RoundRandomSeed       int64  `json:"round_random_seed"`
+	MinerID               string `json:"miner_id"`
+	MerkleTreeRoot        string `json:"merkle_tree_root"`
+	StateHash             string `json:"state_hash"`
+	ReceiptMerkleTreeRoot string `json:"receipt_merkle_tree_root"`
+	NumTxns               int    `json:"num_txns"`
+	StateChangesCount     int    `json:"state_changes_count"`
+}
+
 type Transfer struct {
-	Minter string `json:"minter"`
-	From   string `json:"from"`
-	To     string `json:"to"`
-	Amount int64  `json:"amount"`
+	Minter string        `json:"minter"`
+	From   string        `json:"from"`
+	To     string        `json:"to"`
+	Amount currency.Coin `json:"amount"`
 }
 
 type StorageNodeGeolocation struct {
@@ -203,9 +241,25 @@ type Challenges struct {
 	Responded      bool              `json:"responded"`
 }
 
+// KeyPair holds the hex keys of a wallet and the signature scheme they are of, see crypto.Signer
 type KeyPair struct {
-	PublicKey  bls.PublicKey
-	PrivateKey bls.SecretKey
+	Scheme     string
+	PublicKey  string
+	PrivateKey string
+}
+
+// KeyShare is the threshold share of a group key held by one signer of a multisig wallet,
+// ID is the hex BLS ID the share was evaluated at and which its partial signatures are recovered with
+type KeyShare struct {
+	ID string
+	KeyPair
+}
+
+// EncryptionKeys are the base64 proxy re-encryption keys zbox derives from the mnemonic of a wallet,
+// the public key is what zbox getwallet shows as encryption_public_key
+type EncryptionKeys struct {
+	PublicKey  string
+	PrivateKey string
 }
 
 type Confirmation struct {
@@ -271,27 +325,27 @@ type GetBlobberResponse struct {
 	LastHealthCheck   int64             `json:"last_health_check"`
 	PublicKey         string            `json:"-"`
 	StakePoolSettings StakePoolSettings `json:"stake_pool_settings"`
-	TotalStake        int64             `json:"total_stake"`
+	TotalStake        currency.Coin     `json:"total_stake"`
 }
 
 type StakePoolSettings struct {
-	DelegateWallet string  `json:"delegate_wallet"`
-	MinStake       int     `json:"min_stake"`
-	MaxStake       int64   `json:"max_stake"`
-	NumDelegates   int     `json:"num_delegates"`
-	ServiceCharge  float64 `json:"service_charge"`
+	DelegateWallet string        `json:"delegate_wallet"`
+	MinStake       currency.Coin `json:"min_stake"`
+	MaxStake       currency.Coin `json:"max_stake"`
+	NumDelegates   int           `json:"num_delegates"`
+	ServiceCharge  float64       `json:"service_charge"`
 }
 
 type Terms struct {
-	ReadPrice        int64         `json:"read_price"`
-	WritePrice       int64         `json:"write_price"`
+	ReadPrice        currency.Coin `json:"read_price"`
+	WritePrice       currency.Coin `json:"write_price"`
 	MinLockDemand    float64       `json:"min_lock_demand"`
 	MaxOfferDuration time.Duration `json:"max_offer_duration"`
 }
 
 type PriceRange struct {
-	Min int64 `json:"min"`
-	Max int64 `json:"max"`
+	Min currency.Coin `json:"min"`
+	Max currency.Coin `json:"max"`
 }
 
 type BlobberRequirements struct {
@@ -373,9 +427,9 @@ type SharderStats struct {
 }
 
 type SharderSCStateResponse struct {
-	ID        string    `json:"ID"`
-	StartTime time.Time `json:"StartTime"`
-	Used      float64   `json:"Used"`
+	ID        string        `json:"ID"`
+	StartTime time.Time     `json:"StartTime"`
+	Used      currency.Coin `json:"Used"`
 }
 
 type BlobberUploadFileMeta struct {
@@ -384,8 +438,10 @@ type BlobberUploadFileMeta struct {
 	FilePath     string `json:"filepath" validation:"required"`
 	ActualHash   string `json:"actual_hash,omitempty" validation:"required"`
 	ContentHash  string `json:"content_hash" validation:"required"`
+	MerkleRoot   string `json:"merkle_root,omitempty"`
 	MimeType     string `json:"mimetype" validation:"required"`
 	ActualSize   int64  `json:"actual_size,omitempty" validation:"required"`
+	ChunkSize    int64  `json:"chunk_size,omitempty"`
 	IsFinal      bool   `json:"is_final" validation:"required"`
 }
 
@@ -396,6 +452,11 @@ type BlobberUploadFileRequest struct {
 }
 
 type BlobberUploadFileResponse struct {
+	Filename     string `json:"filename"`
+	Size         int64  `json:"size"`
+	Hash         string `json:"hash"`
+	UploadLength int64  `json:"upload_length"`
+	UploadOffset int64  `json:"upload_offset"`
 }
 
 type BlobberListFilesRequest struct {
@@ -404,19 +465,45 @@ type BlobberListFilesRequest struct {
 }
 
 type BlobberListFilesResponse struct {
+	AllocationRoot string           `json:"allocation_root"`
+	Meta           BlobberFileRef   `json:"meta_data"`
+	List           []BlobberFileRef `json:"list"`
 }
 
-type BlobberCommitConnectionWriteMarker struct {
-	AllocationRoot string `json:"allocation_root"`
-	AllocationID   string `json:"allocation_id"`
-	BlobberID      string `json:"blobber_id"`
-	ClientID       string `json:"client_id"`
-	Signature      string `json:"signature"`
+// BlobberFileRef is the metadata of a file or directory ("f" or "d") in the reference tree of an allocation
+type BlobberFileRef struct {
+	Type           string `json:"type"`
 	Name           string `json:"name"`
-	ContentHash    string `json:"content_hash"`
+	Path           string `json:"path"`
+	Hash           string `json:"hash"`
 	LookupHash     string `json:"lookup_hash"`
-	Timestamp      int64  `json:"timestamp"`
 	Size           int64  `json:"size"`
+	NumBlocks      int64  `json:"num_blocks"`
+	ContentHash    string `json:"content_hash,omitempty"`
+	MerkleRoot     string `json:"merkle_root,omitempty"`
+	ActualFileHash string `json:"actual_file_hash,omitempty"`
+	ActualFileSize int64  `json:"actual_file_size,omitempty"`
+	MimeType       string `json:"mimetype,omitempty"`
+	ChunkSize      int64  `json:"chunk_size,omitempty"`
+}
+
+type BlobberReferencePath struct {
+	Meta BlobberFileRef          `json:"meta_data"`
+	List []*BlobberReferencePath `json:"list,omitempty"`
+}
+
+type BlobberCommitConnectionWriteMarker struct {
+	AllocationRoot         string `json:"allocation_root"`
+	PreviousAllocationRoot string `json:"prev_allocation_root"`
+	AllocationID           string `json:"allocation_id"`
+	BlobberID              string `json:"blobber_id"`
+	ClientID               string `json:"client_id"`
+	Signature              string `json:"signature"`
+	Name                   string `json:"name"`
+	ContentHash            string `json:"content_hash"`
+	LookupHash             string `json:"lookup_hash"`
+	Timestamp              int64  `json:"timestamp"`
+	Size                   int64  `json:"size"`
 }
 
 type BlobberCommitConnectionRequest struct {
@@ -424,14 +511,20 @@ type BlobberCommitConnectionRequest struct {
 	WriteMarker                  BlobberCommitConnectionWriteMarker
 }
 
-type BlobberCommitConnectionResponse struct{}
+type BlobberCommitConnectionResponse struct {
+	AllocationRoot string                             `json:"allocation_root"`
+	WriteMarker    BlobberCommitConnectionWriteMarker `json:"write_marker"`
+	Success        bool                               `json:"success"`
+	ErrorMessage   string                             `json:"error_msg,omitempty"`
+}
 
 type BlobberGetFileReferencePathRequest struct {
 	URL, ClientID, ClientKey, ClientSignature, AllocationID string
 }
 
 type BlobberGetFileReferencePathResponse struct {
-	sdk.ReferencePathResult
+	BlobberReferencePath
+	LatestWM *BlobberCommitConnectionWriteMarker `json:"latest_write_marker"`
 }
 
 func (w *Wallet) MustConvertDateCreatedToInt() int {
@@ -497,4 +590,26 @@ type BlobberDownloadFileRequest struct {
 }
 
 type BlobberDownloadFileResponse struct {
+	Success      bool                           `json:"success"`
+	Data         []byte                         `json:"data"`
+	Path         string                         `json:"path"`
+	AllocationID string                         `json:"allocation_id"`
+	LatestRM     *BlobberDownloadFileReadMarker `json:"latest_rm"`
+}
+
+// AuthTicket grants ClientID access to a file shared by OwnerID, for encrypted files it carries the key
+// blobbers re-encrypt the file for the encryption public key of ClientID with
+type AuthTicket struct {
+	ClientID        string `json:"client_id"`
+	OwnerID         string `json:"owner_id"`
+	AllocationID    string `json:"allocation_id"`
+	FilePathHash    string `json:"file_path_hash"`
+	ActualFileHash  string `json:"actual_file_hash"`
+	FileName        string `json:"file_name"`
+	RefType         string `json:"reference_type"`
+	Expiration      int64  `json:"expiration"`
+	Timestamp       int64  `json:"timestamp"`
+	ReEncryptionKey string `json:"re_encryption_key,omitempty"`
+	Encrypted       bool   `json:"encrypted"`
+	Signature       string `json:"signature"`
 }
@@ -0,0 +1,30 @@
+package faucetsc
+
+import (
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+)
+
+// Functions of the faucet smart contract
+const (
+	PourFunction           = "pour"
+	RefillFunction         = "refill"
+	UpdateSettingsFunction = "update-settings"
+)
+
+// Pour asks the faucet for value tokens, within the limits of its settings
+func Pour(value currency.Coin) *model.Transaction {
+	return smartcontract.Call(endpoint.FaucetSmartContractAddress, PourFunction, struct{}{}, value)
+}
+
+// Refill transfers value tokens into the faucet
+func Refill(value currency.Coin) *model.Transaction {
+	return smartcontract.Call(endpoint.FaucetSmartContractAddress, RefillFunction, struct{}{}, value)
+}
+
+// UpdateSettings changes the limits of the faucet, only its owner may call it
+func UpdateSettings(settings map[string]string) *model.Transaction {
+	return smartcontract.Call(endpoint.FaucetSmartContractAddress, UpdateSettingsFunction, &smartcontract.InputMap{Fields: settings}, 0)
+}
@@ -0,0 +1,70 @@
+package minersc
+
+import (
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+)
+
+// Functions of the miner smart contract
+const (
+	LockFunction                  = "addToDelegatePool"
+	UnlockFunction                = "deleteFromDelegatePool"
+	UpdateMinerSettingsFunction   = "update_miner_settings"
+	UpdateSharderSettingsFunction = "update_sharder_settings"
+	UpdateSettingsFunction        = "update_settings"
+	UpdateGlobalsFunction         = "update_globals"
+)
+
+// DelegatePoolRequest names a miner or sharder to stake on, PoolID is only needed to unlock
+type DelegatePoolRequest struct {
+	ID     string `json:"id"`
+	PoolID string `json:"pool_id,omitempty"`
+}
+
+// NodeSettings is the input of UpdateMinerSettings and UpdateSharderSettings
+type NodeSettings struct {
+	SimpleNode SimpleNode        `json:"simple_miner"`
+	StakePool  StakePoolSettings `json:"stake_pool"`
+}
+
+type SimpleNode struct {
+	ID string `json:"id"`
+}
+
+type StakePoolSettings struct {
+	Settings model.StakePoolSettings `json:"settings"`
+}
+
+// Lock stakes lock on the miner or sharder nodeID
+func Lock(nodeID string, lock currency.Coin) *model.Transaction {
+	return smartcontract.Call(endpoint.MinerSmartContractAddress, LockFunction, &DelegatePoolRequest{ID: nodeID}, lock)
+}
+
+// Unlock returns the stake of the pool poolID from the miner or sharder nodeID
+func Unlock(nodeID, poolID string) *model.Transaction {
+	return smartcontract.Call(endpoint.MinerSmartContractAddress, UnlockFunction, &DelegatePoolRequest{ID: nodeID, PoolID: poolID}, 0)
+}
+
+// UpdateMinerSettings changes the stake pool settings of a miner, only its delegate wallet may call it
+func UpdateMinerSettings(minerID string, settings model.StakePoolSettings) *model.Transaction {
+	input := &NodeSettings{SimpleNode: SimpleNode{ID: minerID}, StakePool: StakePoolSettings{Settings: settings}}
+	return smartcontract.Call(endpoint.MinerSmartContractAddress, UpdateMinerSettingsFunction, input, 0)
+}
+
+// UpdateSharderSettings changes the stake pool settings of a sharder, only its delegate wallet may call it
+func UpdateSharderSettings(sharderID string, settings model.StakePoolSettings) *model.Transaction {
+	input := &NodeSettings{SimpleNode: SimpleNode{ID: sharderID}, StakePool: StakePoolSettings{Settings: settings}}
+	return smartcontract.Call(endpoint.MinerSmartContractAddress, UpdateSharderSettingsFunction, input, 0)
+}
+
+// UpdateSettings changes the configuration of the miner smart contract, only its owner may call it
+func UpdateSettings(settings map[string]string) *model.Transaction {
+	return smartcontract.Call(endpoint.MinerSmartContractAddress, UpdateSettingsFunction, &smartcontract.InputMap{Fields: settings}, 0)
+}
+
+// UpdateGlobals changes the global configuration of the chain, only the owner of the miner smart contract may call it
+func UpdateGlobals(globals map[string]string) *model.Transaction {
+	return smartcontract.Call(endpoint.MinerSmartContractAddress, UpdateGlobalsFunction, &smartcontract.InputMap{Fields: globals}, 0)
+}
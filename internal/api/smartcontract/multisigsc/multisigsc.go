@@ -0,0 +1,85 @@
+package multisigsc
+
+import (
+	"encoding/json"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+)
+
+// Functions of the multisig smart contract
+const (
+	RegisterFunction = "register"
+	VoteFunction     = "vote"
+)
+
+// Wallet registers a group wallet whose transfers need NumRequired of its signers to vote for them
+type Wallet struct {
+	ClientID        string `json:"client_id"`
+	SignatureScheme string `json:"signature_scheme"`
+	PublicKey       string `json:"public_key"`
+
+	SignerThresholdIDs []string `json:"signer_threshold_ids"`
+	SignerPublicKeys   []string `json:"signer_public_keys"`
+
+	NumRequired int `json:"num_required"`
+}
+
+// Transfer is what signers vote for, From is the group wallet and not the voting signer
+type Transfer struct {
+	From   string        `json:"from"`
+	To     string        `json:"to"`
+	Amount currency.Coin `json:"amount"`
+}
+
+// Vote is the partial signature of one signer over a transfer, votes of the same proposal are counted together
+type Vote struct {
+	ProposalID string   `json:"proposal_id"`
+	Transfer   Transfer `json:"transfer"`
+	Signature  string   `json:"signature"`
+}
+
+// NewWallet describes the group wallet of group keys dealt into shares by crypto.GenerateThresholdKeys
+func NewWallet(group *model.KeyPair, shares []*model.KeyShare, threshold int) *Wallet {
+	wallet := &Wallet{
+		ClientID:        crypto.ClientID(group.PublicKey),
+		SignatureScheme: crypto.BLS0Chain,
+		PublicKey:       group.PublicKey,
+		NumRequired:     threshold,
+	}
+	for _, share := range shares {
+		wallet.SignerThresholdIDs = append(wallet.SignerThresholdIDs, share.ID)
+		wallet.SignerPublicKeys = append(wallet.SignerPublicKeys, share.PublicKey)
+	}
+
+	return wallet
+}
+
+// Hash returns the hash of transfer which signers vote with their partial signatures over
+func (t Transfer) Hash() string {
+	transfer, _ := json.Marshal(t) //nolint
+	return crypto.Sha3256(transfer)
+}
+
+// NewVote signs transfer with the share of one signer for the proposal
+func NewVote(proposalID string, transfer Transfer, share *model.KeyShare) (*Vote, error) {
+	signature, err := crypto.SignPartial(transfer.Hash(), share)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Vote{ProposalID: proposalID, Transfer: transfer, Signature: signature}, nil
+}
+
+// Register registers the group wallet, it has to be sent by the group wallet itself
+func Register(wallet *Wallet) *model.Transaction {
+	return smartcontract.Call(endpoint.MultiSigSmartContractAddress, RegisterFunction, wallet, 0)
+}
+
+// CastVote sends the vote of a signer, the transfer executes once the votes of the proposal reach the threshold
+func CastVote(vote *Vote) *model.Transaction {
+	return smartcontract.Call(endpoint.MultiSigSmartContractAddress, VoteFunction, vote, 0)
+}
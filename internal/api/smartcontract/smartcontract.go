@@ -0,0 +1,32 @@
+package smartcontract
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/transaction"
+	"github.com/0chain/system_test/internal/currency"
+)
+
+// InputMap is the input of the settings functions of every smart contract, values are strings parsed by the smart contract
+type InputMap struct {
+	Fields map[string]string `json:"Fields"`
+}
+
+// Call returns a transaction which calls function of the smart contract at address with input and transfers value to it.
+// The transaction is ready to be filled, hashed and signed by a transaction.Builder.
+func Call(address, function string, input interface{}, value currency.Coin) *model.Transaction {
+	data, err := json.Marshal(model.SmartContractTxnData{Name: function, InputArgs: input})
+	if err != nil {
+		// inputs are plain structs, failing to marshal one is a programming error
+		panic(fmt.Errorf("failed to marshal input of smart contract function [%s]: %w", function, err))
+	}
+
+	return &model.Transaction{
+		ToClientId:       address,
+		TransactionType:  transaction.SmartContractType,
+		TransactionValue: value,
+		TransactionData:  string(data),
+	}
+}
@@ -0,0 +1,246 @@
+package smartcontract_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract"
+	"github.com/0chain/system_test/internal/api/smartcontract/faucetsc"
+	"github.com/0chain/system_test/internal/api/smartcontract/minersc"
+	"github.com/0chain/system_test/internal/api/smartcontract/multisigsc"
+	"github.com/0chain/system_test/internal/api/smartcontract/storagesc"
+	"github.com/0chain/system_test/internal/api/smartcontract/vestingsc"
+	"github.com/0chain/system_test/internal/api/smartcontract/zcnsc"
+	"github.com/0chain/system_test/internal/api/transaction"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCall(t *testing.T) {
+	t.Parallel()
+
+	const settings = `{"Fields":{"max_pour_amount":"10"}}`
+	requirements := &model.BlobberRequirements{DataShards: 2, ParityShards: 1, Size: 1024, OwnerId: "owner"}
+	update := &model.AllocationUpdate{ID: "allocation", Size: 1024}
+	blobber := &model.GetBlobberResponse{ID: "blobber"}
+	stakePoolSettings := model.StakePoolSettings{DelegateWallet: "delegate", NumDelegates: 5, ServiceCharge: 0.1}
+	vote := &multisigsc.Vote{ProposalID: "proposal", Transfer: multisigsc.Transfer{From: "group", To: "receiver", Amount: 5}, Signature: "signature"}
+
+	for _, test := range []struct {
+		name     string
+		txn      *model.Transaction
+		address  string
+		function string
+		// input is the JSON the smart contract decodes, literal where the wire format is defined by the smart contract packages
+		input func(t *testing.T) string
+		value currency.Coin
+	}{
+		{
+			name: "faucetsc.Pour", txn: faucetsc.Pour(10),
+			address: endpoint.FaucetSmartContractAddress, function: "pour", input: literal(`{}`), value: 10,
+		},
+		{
+			name: "faucetsc.Refill", txn: faucetsc.Refill(10),
+			address: endpoint.FaucetSmartContractAddress, function: "refill", input: literal(`{}`), value: 10,
+		},
+		{
+			name: "faucetsc.UpdateSettings", txn: faucetsc.UpdateSettings(map[string]string{"max_pour_amount": "10"}),
+			address: endpoint.FaucetSmartContractAddress, function: "update-settings", input: literal(settings),
+		},
+		{
+			name: "storagesc.NewAllocation", txn: storagesc.NewAllocation(requirements, 10),
+			address: endpoint.StorageSmartContractAddress, function: "new_allocation_request", input: encoded(requirements), value: 10,
+		},
+		{
+			name: "storagesc.UpdateAllocation", txn: storagesc.UpdateAllocation(update, 10),
+			address: endpoint.StorageSmartContractAddress, function: "update_allocation_request", input: encoded(update), value: 10,
+		},
+		{
+			name:    "storagesc.FreeAllocation",
+			txn:     storagesc.FreeAllocation(&storagesc.FreeAllocationRequest{RecipientPublicKey: "key", Marker: "marker", Blobbers: []string{"blobber"}}, 10),
+			address: endpoint.StorageSmartContractAddress, function: "free_allocation_request", value: 10,
+			input: literal(`{"recipient_public_key":"key","marker":"marker","blobbers":["blobber"]}`),
+		},
+		{
+			name:    "storagesc.AddFreeStorageAssigner",
+			txn:     storagesc.AddFreeStorageAssigner(&storagesc.FreeStorageAssignerRequest{Name: "assigner", PublicKey: "key", IndividualLimit: 1, TotalLimit: 2}),
+			address: endpoint.StorageSmartContractAddress, function: "add_free_storage_assigner",
+			input: literal(`{"name":"assigner","public_key":"key","individual_limit":1,"total_limit":2}`),
+		},
+		{
+			name: "storagesc.StakePoolLock", txn: storagesc.StakePoolLock("blobber", 10),
+			address: endpoint.StorageSmartContractAddress, function: "stake_pool_lock", input: literal(`{"blobber_id":"blobber"}`), value: 10,
+		},
+		{
+			name: "storagesc.StakePoolUnlock", txn: storagesc.StakePoolUnlock("blobber", "pool"),
+			address: endpoint.StorageSmartContractAddress, function: "stake_pool_unlock", input: literal(`{"blobber_id":"blobber","pool_id":"pool"}`),
+		},
+		{
+			name: "storagesc.ReadPoolLock", txn: storagesc.ReadPoolLock(10),
+			address: endpoint.StorageSmartContractAddress, function: "read_pool_lock", input: literal(`null`), value: 10,
+		},
+		{
+			name: "storagesc.ReadPoolUnlock", txn: storagesc.ReadPoolUnlock(),
+			address: endpoint.StorageSmartContractAddress, function: "read_pool_unlock", input: literal(`null`),
+		},
+		{
+			name: "storagesc.WritePoolLock", txn: storagesc.WritePoolLock("allocation", 10),
+			address: endpoint.StorageSmartContractAddress, function: "write_pool_lock", input: literal(`{"allocation_id":"allocation"}`), value: 10,
+		},
+		{
+			name: "storagesc.WritePoolUnlock", txn: storagesc.WritePoolUnlock("allocation"),
+			address: endpoint.StorageSmartContractAddress, function: "write_pool_unlock", input: literal(`{"allocation_id":"allocation"}`),
+		},
+		{
+			name: "storagesc.UpdateBlobberSettings", txn: storagesc.UpdateBlobberSettings(blobber),
+			address: endpoint.StorageSmartContractAddress, function: "update_blobber_settings", input: encoded(blobber),
+		},
+		{
+			name: "storagesc.UpdateSettings", txn: storagesc.UpdateSettings(map[string]string{"max_pour_amount": "10"}),
+			address: endpoint.StorageSmartContractAddress, function: "update_settings", input: literal(settings),
+		},
+		{
+			name: "minersc.Lock", txn: minersc.Lock("miner", 10),
+			address: endpoint.MinerSmartContractAddress, function: "addToDelegatePool", input: literal(`{"id":"miner"}`), value: 10,
+		},
+		{
+			name: "minersc.Unlock", txn: minersc.Unlock("miner", "pool"),
+			address: endpoint.MinerSmartContractAddress, function: "deleteFromDelegatePool", input: literal(`{"id":"miner","pool_id":"pool"}`),
+		},
+		{
+			name: "minersc.UpdateMinerSettings", txn: minersc.UpdateMinerSettings("miner", stakePoolSettings),
+			address: endpoint.MinerSmartContractAddress, function: "update_miner_settings",
+			input: literal(`{"simple_miner":{"id":"miner"},"stake_pool":{"settings":` +
+				`{"delegate_wallet":"delegate","min_stake":0,"max_stake":0,"num_delegates":5,"service_charge":0.1}}}`),
+		},
+		{
+			name: "minersc.UpdateSharderSettings", txn: minersc.UpdateSharderSettings("sharder", stakePoolSettings),
+			address: endpoint.MinerSmartContractAddress, function: "update_sharder_settings",
+			input: literal(`{"simple_miner":{"id":"sharder"},"stake_pool":{"settings":` +
+				`{"delegate_wallet":"delegate","min_stake":0,"max_stake":0,"num_delegates":5,"service_charge":0.1}}}`),
+		},
+		{
+			name: "minersc.UpdateSettings", txn: minersc.UpdateSettings(map[string]string{"max_pour_amount": "10"}),
+			address: endpoint.MinerSmartContractAddress, function: "update_settings", input: literal(settings),
+		},
+		{
+			name: "minersc.UpdateGlobals", txn: minersc.UpdateGlobals(map[string]string{"max_pour_amount": "10"}),
+			address: endpoint.MinerSmartContractAddress, function: "update_globals", input: literal(settings),
+		},
+		{
+			name: "vestingsc.Add",
+			txn: vestingsc.Add(&vestingsc.AddRequest{Description: "pool", StartTime: 1, Duration: 2,
+				Destinations: []*vestingsc.Destination{{ID: "destination", Amount: 10}}}, 10),
+			address: endpoint.VestingSmartContractAddress, function: "add", value: 10,
+			input: literal(`{"description":"pool","start_time":1,"duration":2,"destinations":[{"id":"destination","amount":10}]}`),
+		},
+		{
+			name: "vestingsc.Trigger", txn: vestingsc.Trigger("pool"),
+			address: endpoint.VestingSmartContractAddress, function: "trigger", input: literal(`{"pool_id":"pool"}`),
+		},
+		{
+			name: "vestingsc.Stop", txn: vestingsc.Stop(&vestingsc.StopRequest{PoolID: "pool", Destination: "destination"}),
+			address: endpoint.VestingSmartContractAddress, function: "stop", input: literal(`{"pool_id":"pool","destination":"destination"}`),
+		},
+		{
+			name: "vestingsc.Unlock", txn: vestingsc.Unlock("pool"),
+			address: endpoint.VestingSmartContractAddress, function: "unlock", input: literal(`{"pool_id":"pool"}`),
+		},
+		{
+			name: "vestingsc.Delete", txn: vestingsc.Delete("pool"),
+			address: endpoint.VestingSmartContractAddress, function: "delete", input: literal(`{"pool_id":"pool"}`),
+		},
+		{
+			name: "vestingsc.UpdateSettings", txn: vestingsc.UpdateSettings(map[string]string{"max_pour_amount": "10"}),
+			address: endpoint.VestingSmartContractAddress, function: "vestingsc-update-settings", input: literal(settings),
+		},
+		{
+			name: "zcnsc.Mint",
+			txn: zcnsc.Mint(&zcnsc.MintPayload{EthereumTxnID: "txn", Amount: 10, Nonce: 1, ReceivingClientID: "receiver",
+				Signatures: []*zcnsc.AuthorizerSignature{{ID: "authorizer", Signature: "signature"}}}),
+			address: endpoint.ZCNSmartContractAddress, function: "mint",
+			input: literal(`{"ethereum_txn_id":"txn","amount":10,"nonce":1,` +
+				`"signatures":[{"authorizer_id":"authorizer","signature":"signature"}],"receiving_client_id":"receiver"}`),
+		},
+		{
+			name: "zcnsc.Burn", txn: zcnsc.Burn(&zcnsc.BurnPayload{EthereumAddress: "0xaddress"}, 10),
+			address: endpoint.ZCNSmartContractAddress, function: "burn", input: literal(`{"ethereum_address":"0xaddress"}`), value: 10,
+		},
+		{
+			name:    "zcnsc.AddAuthorizer",
+			txn:     zcnsc.AddAuthorizer(&zcnsc.AddAuthorizerPayload{PublicKey: "key", URL: "http://authorizer", StakePoolSettings: stakePoolSettings}),
+			address: endpoint.ZCNSmartContractAddress, function: "add-authorizer",
+			input: literal(`{"public_key":"key","url":"http://authorizer","stake_pool_settings":` +
+				`{"delegate_wallet":"delegate","min_stake":0,"max_stake":0,"num_delegates":5,"service_charge":0.1}}`),
+		},
+		{
+			name: "zcnsc.DeleteAuthorizer", txn: zcnsc.DeleteAuthorizer("authorizer"),
+			address: endpoint.ZCNSmartContractAddress, function: "delete-authorizer", input: literal(`{"id":"authorizer"}`),
+		},
+		{
+			name:    "zcnsc.UpdateAuthorizerConfig",
+			txn:     zcnsc.UpdateAuthorizerConfig(&zcnsc.AuthorizerNode{ID: "authorizer", Config: &zcnsc.AuthorizerConfig{Fee: 10}}),
+			address: endpoint.ZCNSmartContractAddress, function: "update-authorizer-config", input: literal(`{"id":"authorizer","config":{"fee":10}}`),
+		},
+		{
+			name: "zcnsc.UpdateGlobalConfig", txn: zcnsc.UpdateGlobalConfig(map[string]string{"max_pour_amount": "10"}),
+			address: endpoint.ZCNSmartContractAddress, function: "update-global-config", input: literal(settings),
+		},
+		{
+			name: "multisigsc.Register",
+			txn: multisigsc.Register(&multisigsc.Wallet{ClientID: "group", SignatureScheme: "bls0chain", PublicKey: "key",
+				SignerThresholdIDs: []string{"1"}, SignerPublicKeys: []string{"signer"}, NumRequired: 1}),
+			address: endpoint.MultiSigSmartContractAddress, function: "register",
+			input: literal(`{"client_id":"group","signature_scheme":"bls0chain","public_key":"key",` +
+				`"signer_threshold_ids":["1"],"signer_public_keys":["signer"],"num_required":1}`),
+		},
+		{
+			name: "multisigsc.CastVote", txn: multisigsc.CastVote(vote),
+			address: endpoint.MultiSigSmartContractAddress, function: "vote",
+			input: literal(`{"proposal_id":"proposal","transfer":{"from":"group","to":"receiver","amount":5},"signature":"signature"}`),
+		},
+	} {
+		test := test
+		t.Run(test.name+" should call "+test.function+" of its smart contract", func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.address, test.txn.ToClientId)
+			require.Equal(t, transaction.SmartContractType, test.txn.TransactionType)
+			require.Equal(t, test.value, test.txn.TransactionValue)
+
+			var data struct {
+				Name  string          `json:"name"`
+				Input json.RawMessage `json:"input"`
+			}
+			require.Nil(t, json.Unmarshal([]byte(test.txn.TransactionData), &data))
+			require.Equal(t, test.function, data.Name)
+			require.JSONEq(t, test.input(t), string(data.Input))
+		})
+	}
+
+	t.Run("Call should panic on an input which cannot be encoded", func(t *testing.T) {
+		t.Parallel()
+
+		require.Panics(t, func() {
+			smartcontract.Call(endpoint.StorageSmartContractAddress, "function", make(chan int), 0)
+		})
+	})
+}
+
+func literal(input string) func(t *testing.T) string {
+	return func(t *testing.T) string {
+		return input
+	}
+}
+
+// encoded is the input of helpers which pass a model type through unchanged
+func encoded(v interface{}) func(t *testing.T) string {
+	return func(t *testing.T) string {
+		input, err := json.Marshal(v)
+		require.Nil(t, err)
+
+		return string(input)
+	}
+}
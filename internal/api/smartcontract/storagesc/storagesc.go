@@ -0,0 +1,119 @@
+package storagesc
+
+import (
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+)
+
+// Functions of the storage smart contract
+const (
+	NewAllocationFunction          = "new_allocation_request"
+	UpdateAllocationFunction       = "update_allocation_request"
+	FreeAllocationFunction         = "free_allocation_request"
+	AddFreeStorageAssignerFunction = "add_free_storage_assigner"
+	StakePoolLockFunction          = "stake_pool_lock"
+	StakePoolUnlockFunction        = "stake_pool_unlock"
+	ReadPoolLockFunction           = "read_pool_lock"
+	ReadPoolUnlockFunction         = "read_pool_unlock"
+	WritePoolLockFunction          = "write_pool_lock"
+	WritePoolUnlockFunction        = "write_pool_unlock"
+	UpdateBlobberSettingsFunction  = "update_blobber_settings"
+	UpdateSettingsFunction         = "update_settings"
+)
+
+// StakePoolRequest names the stake pool of a blobber, PoolID is only needed to unlock
+type StakePoolRequest struct {
+	BlobberID string `json:"blobber_id"`
+	PoolID    string `json:"pool_id,omitempty"`
+}
+
+// WritePoolRequest names the write pool of an allocation
+type WritePoolRequest struct {
+	AllocationID string `json:"allocation_id"`
+}
+
+// FreeAllocationRequest redeems a free storage marker signed by an assigner for an allocation on blobbers
+type FreeAllocationRequest struct {
+	RecipientPublicKey string   `json:"recipient_public_key"`
+	Marker             string   `json:"marker"`
+	Blobbers           []string `json:"blobbers"`
+}
+
+// FreeStorageMarker grants FreeTokens of storage to Recipient, its JSON is the marker of a FreeAllocationRequest
+type FreeStorageMarker struct {
+	Assigner   string  `json:"assigner"`
+	Recipient  string  `json:"recipient"`
+	FreeTokens float64 `json:"free_tokens"`
+	Nonce      int64   `json:"nonce"`
+	Signature  string  `json:"signature"`
+}
+
+// FreeStorageAssignerRequest registers a client allowed to sign free storage markers
+type FreeStorageAssignerRequest struct {
+	Name            string  `json:"name"`
+	PublicKey       string  `json:"public_key"`
+	IndividualLimit float64 `json:"individual_limit"`
+	TotalLimit      float64 `json:"total_limit"`
+}
+
+// NewAllocation creates an allocation on the blobbers of requirements, lock is moved into its write pool
+func NewAllocation(requirements *model.BlobberRequirements, lock currency.Coin) *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, NewAllocationFunction, requirements, lock)
+}
+
+// UpdateAllocation resizes, extends or changes the blobbers of an allocation, lock is moved into its write pool
+func UpdateAllocation(update *model.AllocationUpdate, lock currency.Coin) *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, UpdateAllocationFunction, update, lock)
+}
+
+// FreeAllocation creates an allocation paid for by the free storage marker of request, lock is moved into its write pool
+func FreeAllocation(request *FreeAllocationRequest, lock currency.Coin) *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, FreeAllocationFunction, request, lock)
+}
+
+// AddFreeStorageAssigner allows a client to sign free storage markers, only the owner of the storage smart contract may call it
+func AddFreeStorageAssigner(request *FreeStorageAssignerRequest) *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, AddFreeStorageAssignerFunction, request, 0)
+}
+
+// StakePoolLock stakes lock on a blobber
+func StakePoolLock(blobberID string, lock currency.Coin) *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, StakePoolLockFunction, &StakePoolRequest{BlobberID: blobberID}, lock)
+}
+
+// StakePoolUnlock returns the stake of the pool poolID from a blobber
+func StakePoolUnlock(blobberID, poolID string) *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, StakePoolUnlockFunction, &StakePoolRequest{BlobberID: blobberID, PoolID: poolID}, 0)
+}
+
+// ReadPoolLock moves lock into the read pool of the client, which pays for the reads of every allocation
+func ReadPoolLock(lock currency.Coin) *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, ReadPoolLockFunction, nil, lock)
+}
+
+// ReadPoolUnlock returns the tokens in the read pool of the client
+func ReadPoolUnlock() *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, ReadPoolUnlockFunction, nil, 0)
+}
+
+// WritePoolLock moves lock into the write pool of an allocation
+func WritePoolLock(allocationID string, lock currency.Coin) *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, WritePoolLockFunction, &WritePoolRequest{AllocationID: allocationID}, lock)
+}
+
+// WritePoolUnlock returns the tokens left in the write pool of an allocation which is finalized or canceled
+func WritePoolUnlock(allocationID string) *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, WritePoolUnlockFunction, &WritePoolRequest{AllocationID: allocationID}, 0)
+}
+
+// UpdateBlobberSettings changes the terms, capacity and stake pool settings of a blobber, only its delegate wallet may call it
+func UpdateBlobberSettings(blobber *model.GetBlobberResponse) *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, UpdateBlobberSettingsFunction, blobber, 0)
+}
+
+// UpdateSettings changes the configuration of the storage smart contract, only its owner may call it
+func UpdateSettings(settings map[string]string) *model.Transaction {
+	return smartcontract.Call(endpoint.StorageSmartContractAddress, UpdateSettingsFunction, &smartcontract.InputMap{Fields: settings}, 0)
+}
@@ -0,0 +1,74 @@
+package vestingsc
+
+import (
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+)
+
+// Functions of the vesting smart contract
+const (
+	AddFunction            = "add"
+	TriggerFunction        = "trigger"
+	StopFunction           = "stop"
+	UnlockFunction         = "unlock"
+	DeleteFunction         = "delete"
+	UpdateSettingsFunction = "vestingsc-update-settings"
+)
+
+// AddRequest creates a vesting pool which pays its destinations over Duration from StartTime
+type AddRequest struct {
+	Description  string         `json:"description"`
+	StartTime    int64          `json:"start_time"`
+	Duration     time.Duration  `json:"duration"`
+	Destinations []*Destination `json:"destinations"`
+}
+
+type Destination struct {
+	ID     string        `json:"id"`
+	Amount currency.Coin `json:"amount"`
+}
+
+// PoolRequest names a vesting pool
+type PoolRequest struct {
+	PoolID string `json:"pool_id"`
+}
+
+// StopRequest stops vesting of a pool to one of its destinations
+type StopRequest struct {
+	PoolID      string `json:"pool_id"`
+	Destination string `json:"destination"`
+}
+
+// Add creates a vesting pool funded with lock, which has to cover the amounts of every destination
+func Add(request *AddRequest, lock currency.Coin) *model.Transaction {
+	return smartcontract.Call(endpoint.VestingSmartContractAddress, AddFunction, request, lock)
+}
+
+// Trigger pays out the vested tokens of a pool to its destinations
+func Trigger(poolID string) *model.Transaction {
+	return smartcontract.Call(endpoint.VestingSmartContractAddress, TriggerFunction, &PoolRequest{PoolID: poolID}, 0)
+}
+
+// Stop stops vesting to one destination of a pool, the tokens not vested yet to it go back to the owner
+func Stop(request *StopRequest) *model.Transaction {
+	return smartcontract.Call(endpoint.VestingSmartContractAddress, StopFunction, request, 0)
+}
+
+// Unlock returns the tokens of a pool not vested yet to its owner, or the vested ones to the calling destination
+func Unlock(poolID string) *model.Transaction {
+	return smartcontract.Call(endpoint.VestingSmartContractAddress, UnlockFunction, &PoolRequest{PoolID: poolID}, 0)
+}
+
+// Delete stops vesting of a pool to all of its destinations and returns the tokens not vested yet to its owner
+func Delete(poolID string) *model.Transaction {
+	return smartcontract.Call(endpoint.VestingSmartContractAddress, DeleteFunction, &PoolRequest{PoolID: poolID}, 0)
+}
+
+// UpdateSettings changes the configuration of the vesting smart contract, only its owner may call it
+func UpdateSettings(settings map[string]string) *model.Transaction {
+	return smartcontract.Call(endpoint.VestingSmartContractAddress, UpdateSettingsFunction, &smartcontract.InputMap{Fields: settings}, 0)
+}
@@ -0,0 +1,89 @@
+package zcnsc
+
+import (
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+)
+
+// Functions of the zcn smart contract, which bridges tokens to and from Ethereum
+const (
+	MintFunction                   = "mint"
+	BurnFunction                   = "burn"
+	AddAuthorizerFunction          = "add-authorizer"
+	DeleteAuthorizerFunction       = "delete-authorizer"
+	UpdateAuthorizerConfigFunction = "update-authorizer-config"
+	UpdateGlobalConfigFunction     = "update-global-config"
+)
+
+// MintPayload mints tokens burned on Ethereum, it needs the signatures of enough authorizers
+type MintPayload struct {
+	EthereumTxnID     string                 `json:"ethereum_txn_id"`
+	Amount            currency.Coin          `json:"amount"`
+	Nonce             int64                  `json:"nonce"`
+	Signatures        []*AuthorizerSignature `json:"signatures"`
+	ReceivingClientID string                 `json:"receiving_client_id"`
+}
+
+type AuthorizerSignature struct {
+	ID        string `json:"authorizer_id"`
+	Signature string `json:"signature"`
+}
+
+// BurnPayload burns tokens to be minted to EthereumAddress
+type BurnPayload struct {
+	EthereumAddress string `json:"ethereum_address"`
+}
+
+// AddAuthorizerPayload registers an authorizer and creates its stake pool
+type AddAuthorizerPayload struct {
+	PublicKey         string                  `json:"public_key"`
+	URL               string                  `json:"url"`
+	StakePoolSettings model.StakePoolSettings `json:"stake_pool_settings"`
+}
+
+// AuthorizerNode is the input of UpdateAuthorizerConfig
+type AuthorizerNode struct {
+	ID     string            `json:"id"`
+	Config *AuthorizerConfig `json:"config"`
+}
+
+type AuthorizerConfig struct {
+	Fee currency.Coin `json:"fee"`
+}
+
+// AuthorizerRequest names an authorizer
+type AuthorizerRequest struct {
+	ID string `json:"id"`
+}
+
+// Mint mints the tokens burned on Ethereum to the receiving client of payload
+func Mint(payload *MintPayload) *model.Transaction {
+	return smartcontract.Call(endpoint.ZCNSmartContractAddress, MintFunction, payload, 0)
+}
+
+// Burn burns value tokens, authorizers then sign their mint on Ethereum
+func Burn(payload *BurnPayload, value currency.Coin) *model.Transaction {
+	return smartcontract.Call(endpoint.ZCNSmartContractAddress, BurnFunction, payload, value)
+}
+
+// AddAuthorizer registers an authorizer, only the owner of the zcn smart contract may call it
+func AddAuthorizer(payload *AddAuthorizerPayload) *model.Transaction {
+	return smartcontract.Call(endpoint.ZCNSmartContractAddress, AddAuthorizerFunction, payload, 0)
+}
+
+// DeleteAuthorizer removes an authorizer, only the owner of the zcn smart contract may call it
+func DeleteAuthorizer(authorizerID string) *model.Transaction {
+	return smartcontract.Call(endpoint.ZCNSmartContractAddress, DeleteAuthorizerFunction, &AuthorizerRequest{ID: authorizerID}, 0)
+}
+
+// UpdateAuthorizerConfig changes the fee of an authorizer, only the owner of the zcn smart contract may call it
+func UpdateAuthorizerConfig(node *AuthorizerNode) *model.Transaction {
+	return smartcontract.Call(endpoint.ZCNSmartContractAddress, UpdateAuthorizerConfigFunction, node, 0)
+}
+
+// UpdateGlobalConfig changes the configuration of the zcn smart contract, only its owner may call it
+func UpdateGlobalConfig(config map[string]string) *model.Transaction {
+	return smartcontract.Call(endpoint.ZCNSmartContractAddress, UpdateGlobalConfigFunction, &smartcontract.InputMap{Fields: config}, 0)
+}
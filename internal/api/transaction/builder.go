@@ -0,0 +1,251 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+	resty "github.com/go-resty/resty/v2" //nolint
+)
+
+// DefaultChainID is the chain ID of 0chain networks which do not configure their own
+const DefaultChainID = "0afc093ffb509f059c55478bc1a60351cef7b4e9c008a53a6cc8241ca8617dfe"
+
+// DefaultMaxResubmits is how often Submit retries a transaction rejected for its nonce
+const DefaultMaxResubmits = 3
+
+// DefaultPendingTimeout is how long a nonce stays in flight before SyncNonce takes its transaction as dropped by miners
+const DefaultPendingTimeout = 5 * time.Minute
+
+// Types of transactions
+const (
+	TransferType      = 0
+	SmartContractType = 1000
+)
+
+const version = "1.0"
+
+// Builder fills, signs and submits the transactions of one wallet.
+// It hands out nonces on top of the one sharders report and tracks the ones still in flight, so a wallet can send concurrently.
+type Builder struct {
+	Zerochain *endpoint.Zerochain
+	ClientID  string
+	Keys      *model.KeyPair
+	// ChainID is set on every transaction, DefaultChainID when empty
+	ChainID string
+	// Fee is set on transactions which do not carry a fee of their own
+	Fee currency.Coin
+	// MaxResubmits bounds how often Submit retries with a new nonce, DefaultMaxResubmits when zero and no retries when negative
+	MaxResubmits int
+	// PendingTimeout is how long a nonce stays in flight before its transaction is taken as dropped, DefaultPendingTimeout when zero
+	PendingTimeout time.Duration
+
+	mu     sync.Mutex
+	synced bool
+	nonce  int
+	// inFlight holds when each nonce in flight was handed out
+	inFlight map[int]time.Time
+	released []int
+}
+
+func NewBuilder(zerochain *endpoint.Zerochain, clientID string, keys *model.KeyPair) *Builder {
+	return &Builder{Zerochain: zerochain, ClientID: clientID, Keys: keys}
+}
+
+// SyncNonce fetches the nonce of the wallet from sharders and forgets every nonce at or below it, as those are executed.
+// When the nonce right after it is not in flight, or was handed out longer than PendingTimeout ago, nothing will fill the gap
+// and every later nonce is stuck, so the nonces are handed out again from the one after it, skipping the ones still in flight.
+func (b *Builder) SyncNonce(ctx context.Context) error {
+	var balance *model.Balance
+	resp, err := b.Zerochain.GetFromShardersWithQuorum(ctx, "/v1/client/get/balance?client_id="+b.ClientID, endpoint.QuorumIgnoring("round"), &balance)
+
+	nonce := 0
+	switch {
+	case resp != nil && resp.StatusCode() == http.StatusBadRequest:
+		// sharders know no balance of a wallet which never sent or received a transaction
+	case err != nil:
+		return fmt.Errorf("failed to fetch the nonce of [%s]: %w", b.ClientID, err)
+	case balance != nil:
+		nonce = balance.Nonce
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.synced = true
+	pendingTimeout := b.PendingTimeout
+	if pendingTimeout <= 0 {
+		pendingTimeout = DefaultPendingTimeout
+	}
+	for inFlight, handedOut := range b.inFlight {
+		if inFlight <= nonce || time.Since(handedOut) > pendingTimeout {
+			delete(b.inFlight, inFlight)
+		}
+	}
+	released := b.released[:0]
+	for _, free := range b.released {
+		if free > nonce {
+			released = append(released, free)
+		}
+	}
+	b.released = released
+
+	if _, next := b.inFlight[nonce+1]; nonce > b.nonce || !next {
+		b.nonce = nonce
+		b.released = nil
+	}
+
+	return nil
+}
+
+// InFlight returns the nonces handed out which sharders did not report as executed yet, in ascending order
+func (b *Builder) InFlight() []int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	nonces := make([]int, 0, len(b.inFlight))
+	for nonce := range b.inFlight {
+		nonces = append(nonces, nonce)
+	}
+	sort.Ints(nonces)
+
+	return nonces
+}
+
+// Build fills the client, chain, version, timestamp, fee and next nonce of txn, then hashes and signs it.
+// The nonce is in flight until a later SyncNonce sees it executed or it is handed back with Release.
+func (b *Builder) Build(ctx context.Context, txn *model.Transaction) error {
+	b.mu.Lock()
+	synced := b.synced
+	b.mu.Unlock()
+	if !synced {
+		if err := b.SyncNonce(ctx); err != nil {
+			return err
+		}
+	}
+
+	txn.ClientId = b.ClientID
+	txn.PublicKey = b.Keys.PublicKey
+	txn.ChainId = b.ChainID
+	if txn.ChainId == "" {
+		txn.ChainId = DefaultChainID
+	}
+	txn.Version = version
+	txn.CreationDate = time.Now().Unix()
+	if txn.TransactionFee == 0 {
+		txn.TransactionFee = b.Fee
+	}
+	txn.TransactionNonce = b.nextNonce()
+
+	crypto.HashTransaction(txn)
+	if err := crypto.SignTransaction(txn, b.Keys); err != nil {
+		b.Release(txn.TransactionNonce)
+		return err
+	}
+
+	return nil
+}
+
+// Release hands back a nonce of a transaction which was never accepted, the next Build reuses it so no gap blocks later ones
+func (b *Builder) Release(nonce int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.inFlight[nonce]; !ok {
+		return
+	}
+	delete(b.inFlight, nonce)
+	b.released = append(b.released, nonce)
+	sort.Ints(b.released)
+}
+
+func (b *Builder) nextNonce() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.inFlight == nil {
+		b.inFlight = make(map[int]time.Time)
+	}
+
+	var nonce int
+	if len(b.released) > 0 {
+		nonce, b.released = b.released[0], b.released[1:]
+	} else {
+		b.nonce++
+		for _, ok := b.inFlight[b.nonce]; ok; _, ok = b.inFlight[b.nonce] {
+			b.nonce++
+		}
+		nonce = b.nonce
+	}
+	b.inFlight[nonce] = time.Now()
+
+	return nonce
+}
+
+// Submit builds txn and sends it to the miners. When they reject it for its nonce, which happens when another
+// sender used it, the nonce is synced from sharders and txn is rebuilt and resubmitted with the next free one.
+func (b *Builder) Submit(ctx context.Context, txn *model.Transaction) (*model.TransactionResponse, *resty.Response, error) { //nolint
+	maxResubmits := b.MaxResubmits
+	if maxResubmits == 0 {
+		maxResubmits = DefaultMaxResubmits
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := b.Build(ctx, txn); err != nil {
+			return nil, nil, err
+		}
+
+		var response *model.TransactionResponse
+		resp, err := b.Zerochain.PostToMiners(ctx, "/v1/transaction/put", endpoint.ConsensusByHttpStatus(endpoint.HttpOkStatus), txn, &response)
+		if err == nil {
+			return response, resp, nil
+		}
+		if !IsNonceConflict(err) {
+			b.Release(txn.TransactionNonce)
+			return response, resp, err
+		}
+		if attempt >= maxResubmits {
+			return response, resp, fmt.Errorf("nonce [%d] was rejected after [%d] resubmits: %w", txn.TransactionNonce, attempt, err)
+		}
+
+		if syncErr := b.SyncNonce(ctx); syncErr != nil {
+			return response, resp, fmt.Errorf("%v, then %w", err, syncErr)
+		}
+	}
+}
+
+// IsNonceConflict reports whether err is a miner rejecting a transaction for a nonce which is executed or pending already.
+// Miners answer with a JSON error whose code or message names the nonce, e.g. {"code":"invalid_nonce","error":"..."},
+// other rejections may echo the transaction and with it its transaction_nonce field, so only the error itself is looked at.
+func IsNonceConflict(err error) bool {
+	var httpError *endpoint.NodeHTTPError
+	if !errors.As(err, &httpError) || httpError.StatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	var rejection struct {
+		Code  string `json:"code"`
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(httpError.Body, &rejection) != nil {
+		return false
+	}
+
+	return isNonceError(rejection.Code) || isNonceError(rejection.Error)
+}
+
+func isNonceError(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "invalid_nonce") || strings.Contains(message, "invalid nonce") ||
+		strings.Contains(message, "invalid transaction nonce")
+}
@@ -0,0 +1,218 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/transaction"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/api/util/fakenet"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestWallet registers a wallet on a fake network, which executes transactions in nonce order like miners do
+func newTestWallet(t *testing.T) (*endpoint.Zerochain, string, *model.KeyPair) {
+	signer, err := crypto.NewSigner(crypto.ED25519)
+	require.Nil(t, err)
+
+	network := fakenet.New(fakenet.Options{Signer: signer})
+	t.Cleanup(network.Close)
+	zerochain := &endpoint.Zerochain{HealthCheckInterval: -1, DiscoveryInterval: -1}
+	zerochain.Init(network.NetworkEntrypoint())
+	t.Cleanup(zerochain.StopHealthTracking)
+
+	keys, err := signer.GenerateKeys(crypto.GenerateMnemonic(t))
+	require.Nil(t, err)
+	clientID := crypto.ClientID(keys.PublicKey)
+	_, err = zerochain.PostToMiners(context.Background(), "/v1/client/put", endpoint.ConsensusByHttpStatus(endpoint.HttpOkStatus),
+		model.ClientPutWalletRequest{Id: clientID, PublicKey: keys.PublicKey}, nil)
+	require.Nil(t, err)
+
+	return zerochain, clientID, keys
+}
+
+// transfer returns a transaction whose hash differs from every other one, the same transaction sent twice is accepted twice
+func transfer() *model.Transaction {
+	return &model.Transaction{
+		ToClientId:      crypto.Sha3256([]byte("receiver")),
+		TransactionType: transaction.TransferType,
+		TransactionData: crypto.NewConnectionID(),
+	}
+}
+
+func submit(t *testing.T, builder *transaction.Builder) int {
+	response, _, err := builder.Submit(context.Background(), transfer())
+	require.Nil(t, err)
+	return response.Entity.TransactionNonce
+}
+
+func build(t *testing.T, builder *transaction.Builder) int {
+	txn := transfer()
+	require.Nil(t, builder.Build(context.Background(), txn))
+	return txn.TransactionNonce
+}
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Build should hand out consecutive nonces and reuse released ones first", func(t *testing.T) {
+		t.Parallel()
+
+		builder := transaction.NewBuilder(newTestWallet(t))
+		require.Equal(t, []int{1, 2, 3}, []int{build(t, builder), build(t, builder), build(t, builder)})
+
+		builder.Release(2)
+		builder.Release(7)
+		require.Equal(t, []int{1, 3}, builder.InFlight())
+		require.Equal(t, 2, build(t, builder))
+		require.Equal(t, 4, build(t, builder))
+		require.Equal(t, []int{1, 2, 3, 4}, builder.InFlight())
+	})
+
+	t.Run("SyncNonce should forget the nonces sharders report as executed", func(t *testing.T) {
+		t.Parallel()
+
+		builder := transaction.NewBuilder(newTestWallet(t))
+		require.Equal(t, 1, submit(t, builder))
+		require.Equal(t, 2, submit(t, builder))
+		require.Equal(t, 3, build(t, builder))
+		builder.Release(3)
+		require.Equal(t, 3, build(t, builder))
+		require.Equal(t, 4, build(t, builder))
+		builder.Release(4)
+
+		require.Nil(t, builder.SyncNonce(context.Background()))
+		require.Equal(t, []int{3}, builder.InFlight())
+		require.Equal(t, 4, build(t, builder), "a released nonce above the executed ones should still be reused")
+	})
+
+	t.Run("SyncNonce should rewind to the executed nonce when the next one was dropped", func(t *testing.T) {
+		t.Parallel()
+
+		builder := transaction.NewBuilder(newTestWallet(t))
+		builder.PendingTimeout = 50 * time.Millisecond
+
+		// nonce 1 is never sent, as if miners dropped its transaction, so nonce 2 stays pending
+		require.Equal(t, 1, build(t, builder))
+		time.Sleep(2 * builder.PendingTimeout)
+		require.Equal(t, 2, submit(t, builder))
+
+		require.Nil(t, builder.SyncNonce(context.Background()))
+		require.Equal(t, []int{2}, builder.InFlight())
+		require.Equal(t, 1, submit(t, builder))
+		require.Equal(t, 3, submit(t, builder), "the nonce still pending should be skipped")
+
+		require.Nil(t, builder.SyncNonce(context.Background()))
+		require.Empty(t, builder.InFlight(), "the pending nonce should execute once the gap is filled")
+	})
+
+	t.Run("SyncNonce should not rewind while the next nonce is pending", func(t *testing.T) {
+		t.Parallel()
+
+		builder := transaction.NewBuilder(newTestWallet(t))
+		require.Equal(t, 1, build(t, builder))
+		require.Equal(t, 2, submit(t, builder))
+
+		require.Nil(t, builder.SyncNonce(context.Background()))
+		require.Equal(t, []int{1, 2}, builder.InFlight())
+		require.Equal(t, 3, build(t, builder))
+	})
+
+	t.Run("Submit should resubmit with the next nonce when another sender of the wallet used it", func(t *testing.T) {
+		t.Parallel()
+
+		zerochain, clientID, keys := newTestWallet(t)
+		builder := transaction.NewBuilder(zerochain, clientID, keys)
+		other := transaction.NewBuilder(zerochain, clientID, keys)
+		require.Nil(t, builder.SyncNonce(context.Background()))
+
+		require.Equal(t, 1, submit(t, other))
+		require.Equal(t, 2, submit(t, builder))
+		require.Equal(t, []int{2}, builder.InFlight())
+	})
+
+	t.Run("Submit should give up once the resubmits are used up", func(t *testing.T) {
+		t.Parallel()
+
+		zerochain, clientID, keys := newTestWallet(t)
+		builder := transaction.NewBuilder(zerochain, clientID, keys)
+		builder.MaxResubmits = -1
+		other := transaction.NewBuilder(zerochain, clientID, keys)
+		require.Nil(t, builder.SyncNonce(context.Background()))
+
+		require.Equal(t, 1, submit(t, other))
+		_, _, err := builder.Submit(context.Background(), transfer())
+		require.True(t, transaction.IsNonceConflict(err), err)
+		require.Equal(t, []int{1}, builder.InFlight(), "a rejected nonce should stay in flight until a sync sees it executed")
+	})
+}
+
+func TestIsNonceConflict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("IsNonceConflict should match the rejection of a nonce by miners", func(t *testing.T) {
+		t.Parallel()
+
+		zerochain, clientID, keys := newTestWallet(t)
+		builder := transaction.NewBuilder(zerochain, clientID, keys)
+		require.Nil(t, builder.SyncNonce(context.Background()))
+		require.Equal(t, 1, submit(t, transaction.NewBuilder(zerochain, clientID, keys)))
+
+		// the builder synced before the other one sent nonce 1, so it sends nonce 1 again
+		txn := transfer()
+		require.Nil(t, builder.Build(context.Background(), txn))
+		_, err := zerochain.PostToMiners(context.Background(), "/v1/transaction/put", endpoint.ConsensusByHttpStatus(endpoint.HttpOkStatus), txn, nil)
+		require.NotNil(t, err)
+
+		var httpError *endpoint.NodeHTTPError
+		require.True(t, errors.As(err, &httpError), err)
+		require.Contains(t, string(httpError.Body), `"code":"invalid_nonce"`)
+		require.True(t, transaction.IsNonceConflict(err), err)
+	})
+
+	for _, test := range []struct {
+		name     string
+		err      error
+		conflict bool
+	}{
+		{
+			name:     "a nonce error without a code",
+			err:      &endpoint.NodeHTTPError{StatusCode: http.StatusBadRequest, Body: []byte(`{"error":"invalid nonce"}`)},
+			conflict: true,
+		},
+		{
+			name:     "a nonce which is pending already",
+			err:      &endpoint.NodeHTTPError{StatusCode: http.StatusBadRequest, Body: []byte(`{"code":"invalid_nonce","error":"invalid_nonce: invalid transaction nonce [3], it is already pending"}`)},
+			conflict: true,
+		},
+		{
+			name: "another rejection which echoes the nonce of the transaction",
+			err: &endpoint.NodeHTTPError{StatusCode: http.StatusBadRequest,
+				Body: []byte(`{"code":"invalid_request","error":"invalid_request: insufficient balance","transaction":{"transaction_nonce":3}}`)},
+		},
+		{
+			name: "a body which is not JSON",
+			err:  &endpoint.NodeHTTPError{StatusCode: http.StatusBadRequest, Body: []byte(`invalid nonce`)},
+		},
+		{
+			name: "a server error about the nonce",
+			err:  &endpoint.NodeHTTPError{StatusCode: http.StatusInternalServerError, Body: []byte(`{"code":"invalid_nonce","error":"invalid nonce"}`)},
+		},
+		{
+			name: "an error which is no HTTP error",
+			err:  errors.New("invalid nonce"),
+		},
+	} {
+		test := test
+		t.Run("IsNonceConflict should tell a nonce conflict given "+test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.conflict, transaction.IsNonceConflict(test.err))
+		})
+	}
+}
@@ -0,0 +1,426 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode decides whether a Recorder records, replays or just forwards requests
+type Mode string
+
+const (
+	ModePassthrough Mode = "passthrough"
+	ModeRecord      Mode = "record"
+	ModeReplay      Mode = "replay"
+)
+
+// DefaultName is the cassette of requests whose context carries no name, e.g. discovery and health checks
+const DefaultName = "default"
+
+// SignatureAndTimestampFields are the volatile fields which usually have to be ignored to replay signed requests
+var SignatureAndTimestampFields = []string{"signature", "creation_date", "timestamp", "hash"}
+
+// ErrNoInteraction is returned in replay mode when a request was never recorded
+var ErrNoInteraction = errors.New("no recorded interaction")
+
+func ParseMode(mode string) (Mode, error) {
+	switch Mode(strings.ToLower(strings.TrimSpace(mode))) {
+	case "", ModePassthrough:
+		return ModePassthrough, nil
+	case ModeRecord:
+		return ModeRecord, nil
+	case ModeReplay:
+		return ModeReplay, nil
+	default:
+		return "", fmt.Errorf("unknown http mode [%s], expected one of [%s, %s, %s]", mode, ModePassthrough, ModeRecord, ModeReplay)
+	}
+}
+
+// Request is the recorded part of an HTTP request, Key is what replay matches on
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+	Key    string `json:"key"`
+}
+
+// Response is the recorded part of an HTTP response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Status     string      `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette holds the interactions of one test in the order they happened
+type Cassette struct {
+	Name         string         `json:"name"`
+	Interactions []*Interaction `json:"interactions"`
+
+	used map[int]bool
+}
+
+// Recorder is an http.RoundTripper which records interactions into cassettes or replays them without any network
+type Recorder struct {
+	Mode Mode
+	// Dir holds one cassette file per test
+	Dir string
+	// IgnoredFields are dropped from query parameters, form values and JSON bodies before requests are matched
+	IgnoredFields []string
+	// Transport forwards requests in record and passthrough mode, http.DefaultTransport is used when nil
+	Transport http.RoundTripper
+
+	mu        sync.Mutex
+	cassettes map[string]*Cassette
+}
+
+func NewRecorder(mode Mode, dir string, ignoredFields ...string) *Recorder {
+	return &Recorder{Mode: mode, Dir: dir, IgnoredFields: ignoredFields, cassettes: make(map[string]*Cassette)}
+}
+
+type nameKey struct{}
+
+// WithName returns a context whose requests are recorded into and replayed from the cassette of the given name
+func WithName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, nameKey{}, name)
+}
+
+func nameFrom(ctx context.Context) string {
+	if name, ok := ctx.Value(nameKey{}).(string); ok && name != "" {
+		return name
+	}
+
+	return DefaultName
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch r.Mode {
+	case ModeRecord:
+		return r.record(req)
+	case ModeReplay:
+		return r.replay(req)
+	default:
+		return r.transport().RoundTrip(req)
+	}
+}
+
+// Save writes every cassette recorded so far into Dir, it does nothing unless recording
+func (r *Recorder) Save() error {
+	if r.Mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		return err
+	}
+
+	for name, cassette := range r.cassettes {
+		data, err := json.MarshalIndent(cassette, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(r.path(name), data, 0o600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   string(body),
+			Key:    r.key(req, body),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     resp.Header,
+		},
+	}
+	if utf8.Valid(respBody) {
+		interaction.Response.Body = string(respBody)
+	} else {
+		interaction.Response.BodyBase64 = base64.StdEncoding.EncodeToString(respBody)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cassette := r.cassette(nameFrom(req.Context()))
+	cassette.Interactions = append(cassette.Interactions, interaction)
+
+	return resp, nil
+}
+
+// replay returns the first unused interaction matching req, or the last matching one once all were used, as polling may ask more often than recorded
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := r.key(req, body)
+	name := nameFrom(req.Context())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cassette, err := r.load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	match := -1
+	for i, interaction := range cassette.Interactions {
+		if interaction.Request.Key != key {
+			continue
+		}
+		match = i
+		if !cassette.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("%w for [%s] in cassette [%s]", ErrNoInteraction, key, name)
+	}
+	cassette.used[match] = true
+
+	return cassette.Interactions[match].Response.toHTTP(req)
+}
+
+func (r *Recorder) transport() http.RoundTripper {
+	if r.Transport != nil {
+		return r.Transport
+	}
+
+	return http.DefaultTransport
+}
+
+// cassette returns the in-memory cassette of name, the caller must hold the lock
+func (r *Recorder) cassette(name string) *Cassette {
+	if r.cassettes == nil {
+		r.cassettes = make(map[string]*Cassette)
+	}
+
+	cassette, ok := r.cassettes[name]
+	if !ok {
+		cassette = &Cassette{Name: name, used: make(map[int]bool)}
+		r.cassettes[name] = cassette
+	}
+
+	return cassette
+}
+
+// load reads the cassette of name from Dir once, the caller must hold the lock
+func (r *Recorder) load(name string) (*Cassette, error) {
+	if cassette, ok := r.cassettes[name]; ok {
+		return cassette, nil
+	}
+
+	data, err := os.ReadFile(r.path(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette [%s]: %w", name, err)
+	}
+
+	cassette := r.cassette(name)
+	if err := json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("failed to deserialise cassette [%s]: %w", name, err)
+	}
+
+	return cassette, nil
+}
+
+var unsafeFileCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func (r *Recorder) path(name string) string {
+	return filepath.Join(r.Dir, unsafeFileCharacters.ReplaceAllString(name, "_")+".json")
+}
+
+func (res Response) toHTTP(req *http.Request) (*http.Response, error) {
+	body := []byte(res.Body)
+	if res.BodyBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(res.BodyBase64)
+		if err != nil {
+			return nil, err
+		}
+		body = decoded
+	}
+
+	return &http.Response{
+		StatusCode:    res.StatusCode,
+		Status:        res.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        res.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readBody reads the body of req and puts it back so the request can still be sent
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// key identifies a request by method, host, path, normalized query and normalized body
+func (r *Recorder) key(req *http.Request, body []byte) string {
+	ignored := ignoredSet(r.IgnoredFields)
+
+	return req.Method + " " + req.URL.Host + req.URL.Path + "?" + normalizeValues(req.URL.Query(), ignored) + " " + normalizeBody(req.Header.Get("Content-Type"), body, ignored)
+}
+
+func normalizeBody(contentType string, body []byte, ignored map[string]bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err == nil {
+			return normalizeValues(values, ignored)
+		}
+	case strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "":
+		return strings.ReplaceAll(string(body), params["boundary"], "boundary")
+	}
+
+	return normalizeJSON(string(body), ignored)
+}
+
+// normalizeValues sorts values by key, drops ignored keys and normalizes the values which hold JSON
+func normalizeValues(values url.Values, ignored map[string]bool) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if !ignored[strings.ToLower(key)] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	normalized := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range values[key] {
+			normalized = append(normalized, key+"="+normalizeJSON(value, ignored))
+		}
+	}
+
+	return strings.Join(normalized, "&")
+}
+
+// NormalizeJSON re-encodes JSON with sorted keys and without the ignored fields at any depth, JSON nested in strings included.
+// Fields are matched case insensitively and anything which is not JSON is returned as it is
+func NormalizeJSON(value string, ignoredFields []string) string {
+	return normalizeJSON(value, ignoredSet(ignoredFields))
+}
+
+func ignoredSet(ignoredFields []string) map[string]bool {
+	ignored := make(map[string]bool, len(ignoredFields))
+	for _, field := range ignoredFields {
+		ignored[strings.ToLower(field)] = true
+	}
+
+	return ignored
+}
+
+// normalizeJSON re-encodes JSON with sorted keys and without ignored fields, anything else is returned as it is
+func normalizeJSON(value string, ignored map[string]bool) string {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return value
+	}
+	if _, ok := decoded.(string); ok {
+		return value
+	}
+
+	normalized, err := json.Marshal(dropFields(decoded, ignored))
+	if err != nil {
+		return value
+	}
+
+	return string(normalized)
+}
+
+func dropFields(value interface{}, ignored map[string]bool) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			if ignored[strings.ToLower(key)] {
+				delete(typed, key)
+				continue
+			}
+			typed[key] = dropFields(nested, ignored)
+		}
+	case []interface{}:
+		for i, nested := range typed {
+			typed[i] = dropFields(nested, ignored)
+		}
+	case string:
+		// transaction data and markers are JSON documents nested in strings
+		if strings.HasPrefix(strings.TrimSpace(typed), "{") {
+			return normalizeJSON(typed, ignored)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,117 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(int(call)) + `,"echo":` + string(body) + `}`))
+	}))
+	dir := t.TempDir()
+
+	// a run registers the wallet of a fresh mnemonic with a fresh signature, as the API tests do
+	run := func(recorder *Recorder, signature string) ([]string, error) {
+		keys, err := mustSigner(t).GenerateKeys(crypto.GenerateMnemonic(t))
+		require.Nil(t, err)
+		clientID := crypto.ClientID(keys.PublicKey)
+
+		client := &http.Client{Transport: recorder}
+		ctx := WithName(context.Background(), t.Name())
+		var bodies []string
+		for _, path := range []string{"/v1/client/put", "/v1/client/get?id=" + clientID} {
+			body := `{"id":"` + clientID + `","public_key":"` + keys.PublicKey + `","signature":"` + signature + `"}`
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+path, strings.NewReader(body))
+			require.Nil(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			respBody, err := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			require.Nil(t, err)
+			bodies = append(bodies, string(respBody))
+		}
+
+		return bodies, nil
+	}
+	t.Cleanup(func() { crypto.UseDeterministicMnemonics(false) })
+
+	crypto.UseDeterministicMnemonics(true)
+	recorder := NewRecorder(ModeRecord, dir, SignatureAndTimestampFields...)
+	recorded, err := run(recorder, "recorded-signature")
+	require.Nil(t, err)
+	require.Nil(t, recorder.Save())
+	server.Close()
+
+	// replay answers the same wallet with the recorded responses, without the server
+	crypto.UseDeterministicMnemonics(true)
+	replayed, err := run(NewRecorder(ModeReplay, dir, SignatureAndTimestampFields...), "replayed-signature")
+	require.Nil(t, err)
+	require.Equal(t, recorded, replayed)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// a random mnemonic gives another wallet, whose requests were never recorded
+	crypto.UseDeterministicMnemonics(false)
+	_, err = run(NewRecorder(ModeReplay, dir, SignatureAndTimestampFields...), "replayed-signature")
+	require.True(t, errors.Is(err, ErrNoInteraction), err)
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewRecorder(ModeReplay, t.TempDir(), "signature", "timestamp")
+	key := func(contentType, rawURL, body string) string {
+		req := httptest.NewRequest(http.MethodPost, rawURL, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		return recorder.key(req, []byte(body))
+	}
+
+	t.Run("Keys should not depend on the order of JSON fields and query parameters", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t,
+			key("application/json", "http://node/v1/x?b=2&a=1", `{"a":1,"b":{"c":2,"d":3}}`),
+			key("application/json", "http://node/v1/x?a=1&b=2", `{"b":{"d":3,"c":2},"a":1}`))
+	})
+
+	t.Run("Keys should not depend on ignored fields, also within JSON nested in strings", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t,
+			key("application/x-www-form-urlencoded", "http://node/v1/x?timestamp=1", `data=%7B%22a%22%3A1%2C%22signature%22%3A%22x%22%7D&signature=x`),
+			key("application/x-www-form-urlencoded", "http://node/v1/x?timestamp=2", `data=%7B%22signature%22%3A%22y%22%2C%22a%22%3A1%7D&signature=y`))
+	})
+
+	t.Run("Keys should differ for different values of fields which are not ignored", func(t *testing.T) {
+		t.Parallel()
+
+		require.NotEqual(t,
+			key("application/json", "http://node/v1/x", `{"client_id":"a"}`),
+			key("application/json", "http://node/v1/x", `{"client_id":"b"}`))
+	})
+}
+
+func mustSigner(t *testing.T) crypto.Signer {
+	signer, err := crypto.NewSigner(crypto.ED25519)
+	require.Nil(t, err)
+
+	return signer
+}
@@ -4,10 +4,34 @@ import (
 	"gopkg.in/yaml.v3" //nolint
 	"log"
 	"os"
+	"time"
 )
 
 type Config struct {
-	NetworkEntrypoint string `yaml:"network_entrypoint"`
+	NetworkEntrypoint   string        `yaml:"network_entrypoint"`
+	NodeTimeout         time.Duration `yaml:"node_timeout"`
+	ConsensusTimeout    time.Duration `yaml:"consensus_timeout"`
+	ZboxConfigPath      string        `yaml:"zbox_config"`
+	HealthCheckInterval time.Duration `yaml:"health_check_interval"`
+	DiscoveryInterval   time.Duration `yaml:"discovery_interval"`
+	UnhealthyAfter      int           `yaml:"unhealthy_after"`
+	HealthyAfter        int           `yaml:"healthy_after"`
+	// HttpMode is passthrough, record or replay, see cassette.Mode
+	HttpMode             string   `yaml:"http_mode"`
+	CassetteDir          string   `yaml:"cassette_dir"`
+	CassetteIgnoreFields []string `yaml:"cassette_ignore_fields"`
+	// FakeNetwork runs the tests against an in-process network instead of NetworkEntrypoint, see fakenet.Network
+	FakeNetwork bool `yaml:"fake_network"`
+	// MetricsDir receives metrics.json and metrics.prom with the latency and outcome of every node at the end of a run, nothing is written when empty
+	MetricsDir string `yaml:"metrics_dir"`
+}
+
+// ZboxConfig holds the keys of zbox_config.yaml shared with the CLI tests
+type ZboxConfig struct {
+	BlockWorker     string `yaml:"block_worker"`
+	SignatureScheme string `yaml:"signature_scheme"`
+	MinSubmit       int    `yaml:"min_submit"`
+	MinConfirmation int    `yaml:"min_confirmation"`
 }
 
 func Parse(configPath string) *Config {
@@ -25,6 +49,21 @@ func Parse(configPath string) *Config {
 	return result
 }
 
+func ParseZbox(configPath string) *ZboxConfig {
+	var result *ZboxConfig
+
+	file, err := os.ReadFile(configPath)
+	if err != nil {
+		log.Fatalln("Failed to read zbox config file! due to error: " + err.Error())
+	}
+	err = yaml.Unmarshal(file, &result) //nolint
+	if err != nil {
+		log.Fatalln("failed to deserialise zbox config file due to error: " + err.Error())
+	}
+
+	return result
+}
+
 func MustGetHomeDir() string {
 	homeDir, err := os.UserHomeDir()
 
@@ -2,7 +2,9 @@ package config
 
 // Environment variables
 const (
-	ConfigPathEnv = "CONFIG_PATH"
+	ConfigPathEnv  = "CONFIG_PATH"
+	HttpModeEnv    = "HTTP_MODE"
+	FakeNetworkEnv = "FAKE_NETWORK"
 )
 
 // Default variables
@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/0chain/system_test/internal/api/model"
+)
+
+// DefaultNotarizationThreshold is the percentage of miners which has to verify a block for it to be notarized,
+// the threshold_by_count of the default 0chain.yaml. Networks configured otherwise pass their own to VerifyBlock.
+const DefaultNotarizationThreshold = 66
+
+var ErrInvalidBlock = errors.New("invalid block")
+
+// NotarizationThresholdCount returns how many distinct miners out of miners have to sign a block
+// for it to reach the notarization threshold percentage, rounding up like 0chain does
+func NotarizationThresholdCount(miners, threshold int) int {
+	return int(math.Ceil(float64(miners) * float64(threshold) / 100))
+}
+
+// VerifyBlock checks the generator signature over the hash of block and that enough distinct miners
+// signed verification tickets of it and of its previous block. minerKeys maps the IDs of the miners
+// to their hex public keys, tickets of unknown verifiers are not counted.
+func VerifyBlock(block *model.Block, minerKeys map[string]string, threshold int) error {
+	if threshold < 1 || threshold > 100 {
+		return fmt.Errorf("notarization threshold [%d] has to be a percentage between 1 and 100", threshold)
+	}
+
+	b := &block.Block
+	generatorKey, ok := minerKeys[b.MinerId]
+	if !ok {
+		return fmt.Errorf("%w: generator [%s] of block [%s] is not a miner", ErrInvalidBlock, b.MinerId, b.Hash)
+	}
+	valid, err := VerifySignature(generatorKey, b.Signature, b.Hash)
+	if err != nil {
+		return fmt.Errorf("%w: signature of generator [%s] over block [%s]: %v", ErrInvalidBlock, b.MinerId, b.Hash, err)
+	}
+	if !valid {
+		return fmt.Errorf("%w: signature of generator [%s] over block [%s] does not verify", ErrInvalidBlock, b.MinerId, b.Hash)
+	}
+
+	required := NotarizationThresholdCount(len(minerKeys), threshold)
+	if verifiers := countVerifiers(b.VerificationTickets, b.Hash, minerKeys); verifiers < required {
+		return fmt.Errorf("%w: block [%s] of round [%d] has [%d] valid verification tickets, [%d] are required",
+			ErrInvalidBlock, b.Hash, b.Round, verifiers, required)
+	}
+
+	// the first block after genesis has nothing notarized before it
+	if b.Round <= 1 {
+		return nil
+	}
+	if verifiers := countVerifiers(b.PrevVerificationTickets, b.PrevHash, minerKeys); verifiers < required {
+		return fmt.Errorf("%w: previous block [%s] of block [%s] has [%d] valid verification tickets, [%d] are required",
+			ErrInvalidBlock, b.PrevHash, b.Hash, verifiers, required)
+	}
+
+	return nil
+}
+
+// countVerifiers returns the number of distinct miners with a valid ticket over hash
+func countVerifiers(tickets []*model.VerificationTicket, hash string, minerKeys map[string]string) int {
+	verifiers := make(map[string]bool, len(tickets))
+	for _, ticket := range tickets {
+		publicKey, ok := minerKeys[ticket.VerifierId]
+		if !ok || verifiers[ticket.VerifierId] {
+			continue
+		}
+		if valid, err := VerifySignature(publicKey, ticket.Signature, hash); err == nil && valid {
+			verifiers[ticket.VerifierId] = true
+		}
+	}
+
+	return len(verifiers)
+}
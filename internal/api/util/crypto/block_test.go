@@ -0,0 +1,229 @@
+package crypto
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotarizationThresholdCount(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		miners, threshold, expected int
+	}{
+		{miners: 3, threshold: 66, expected: 2},
+		{miners: 4, threshold: 66, expected: 3},
+		{miners: 100, threshold: 66, expected: 66},
+		{miners: 3, threshold: 67, expected: 3},
+		{miners: 5, threshold: 100, expected: 5},
+		{miners: 1, threshold: 1, expected: 1},
+	} {
+		require.Equal(t, test.expected, NotarizationThresholdCount(test.miners, test.threshold), "%d%% of %d miners", test.threshold, test.miners)
+	}
+}
+
+func TestVerifyBlock(t *testing.T) {
+	t.Parallel()
+
+	miners := make([]*model.KeyPair, 4)
+	minerKeys := make(map[string]string, len(miners))
+	for i := range miners {
+		miners[i] = mustKeys(t, BLS0Chain)
+		minerKeys[minerID(miners[i])] = miners[i].PublicKey
+	}
+	outsider := mustKeys(t, BLS0Chain)
+
+	ticket := func(t *testing.T, verifier *model.KeyPair, hash string) *model.VerificationTicket {
+		signature, err := Sign(hash, verifier)
+		require.Nil(t, err)
+		return &model.VerificationTicket{VerifierId: minerID(verifier), Signature: signature}
+	}
+	tickets := func(t *testing.T, hash string, verifiers ...*model.KeyPair) []*model.VerificationTicket {
+		signed := make([]*model.VerificationTicket, 0, len(verifiers))
+		for _, verifier := range verifiers {
+			signed = append(signed, ticket(t, verifier, hash))
+		}
+		return signed
+	}
+
+	// newBlock returns a block of round generated by miners[0] and verified, as its previous block was, by miners[:3],
+	// which is exactly the 66% of 4 miners
+	newBlock := func(t *testing.T, round int64) *model.Block {
+		var block model.Block
+		b := &block.Block
+		b.Round = round
+		b.Hash = Sha3256([]byte{byte(round)})
+		b.PrevHash = Sha3256([]byte{byte(round - 1)})
+		b.MinerId = minerID(miners[0])
+
+		var err error
+		b.Signature, err = Sign(b.Hash, miners[0])
+		require.Nil(t, err)
+		b.VerificationTickets = tickets(t, b.Hash, miners[:3]...)
+		b.PrevVerificationTickets = tickets(t, b.PrevHash, miners[:3]...)
+
+		return &block
+	}
+
+	for _, test := range []struct {
+		name      string
+		threshold int
+		tamper    func(t *testing.T, b *model.Block)
+		valid     bool
+	}{
+		{
+			name:  "tickets of exactly the threshold of miners",
+			valid: true,
+		},
+		{
+			name:  "tickets of every miner",
+			valid: true,
+			tamper: func(t *testing.T, b *model.Block) {
+				b.Block.VerificationTickets = tickets(t, b.Block.Hash, miners...)
+			},
+		},
+		{
+			name: "tickets of one miner less than the threshold",
+			tamper: func(t *testing.T, b *model.Block) {
+				b.Block.VerificationTickets = b.Block.VerificationTickets[:2]
+			},
+		},
+		{
+			name:      "tickets below a threshold the chain configures higher",
+			threshold: 100,
+		},
+		{
+			name:      "tickets at a threshold the chain configures lower",
+			threshold: 50,
+			valid:     true,
+			tamper: func(t *testing.T, b *model.Block) {
+				b.Block.VerificationTickets = b.Block.VerificationTickets[:2]
+				b.Block.PrevVerificationTickets = b.Block.PrevVerificationTickets[:2]
+			},
+		},
+		{
+			name: "a generator signature over another hash",
+			tamper: func(t *testing.T, b *model.Block) {
+				var err error
+				b.Block.Signature, err = Sign(b.Block.PrevHash, miners[0])
+				require.Nil(t, err)
+			},
+		},
+		{
+			name: "a generator signature of another miner",
+			tamper: func(t *testing.T, b *model.Block) {
+				var err error
+				b.Block.Signature, err = Sign(b.Block.Hash, miners[1])
+				require.Nil(t, err)
+			},
+		},
+		{
+			name: "a generator signature which is not hex",
+			tamper: func(t *testing.T, b *model.Block) {
+				b.Block.Signature = "not a signature"
+			},
+		},
+		{
+			name: "a generator which is not a miner",
+			tamper: func(t *testing.T, b *model.Block) {
+				var err error
+				b.Block.MinerId = minerID(outsider)
+				b.Block.Signature, err = Sign(b.Block.Hash, outsider)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name: "duplicate tickets of the same miner",
+			tamper: func(t *testing.T, b *model.Block) {
+				b.Block.VerificationTickets = tickets(t, b.Block.Hash, miners[0], miners[1], miners[1])
+			},
+		},
+		{
+			name: "tickets of a verifier which is not a miner",
+			tamper: func(t *testing.T, b *model.Block) {
+				b.Block.VerificationTickets = tickets(t, b.Block.Hash, miners[0], miners[1], outsider)
+			},
+		},
+		{
+			name: "a ticket over another hash",
+			tamper: func(t *testing.T, b *model.Block) {
+				b.Block.VerificationTickets[2] = ticket(t, miners[2], b.Block.PrevHash)
+			},
+		},
+		{
+			name: "a ticket signed by another miner than its verifier",
+			tamper: func(t *testing.T, b *model.Block) {
+				forged := ticket(t, miners[3], b.Block.Hash)
+				forged.VerifierId = minerID(miners[2])
+				b.Block.VerificationTickets[2] = forged
+			},
+		},
+		{
+			name: "previous tickets below the threshold",
+			tamper: func(t *testing.T, b *model.Block) {
+				b.Block.PrevVerificationTickets = b.Block.PrevVerificationTickets[:2]
+			},
+		},
+		{
+			name: "previous tickets over the hash of the block",
+			tamper: func(t *testing.T, b *model.Block) {
+				b.Block.PrevVerificationTickets = tickets(t, b.Block.Hash, miners[:3]...)
+			},
+		},
+		{
+			name: "no previous tickets after round one",
+			tamper: func(t *testing.T, b *model.Block) {
+				b.Block.PrevVerificationTickets = nil
+			},
+		},
+		{
+			name:  "no previous tickets in round one",
+			valid: true,
+			tamper: func(t *testing.T, b *model.Block) {
+				first := newBlock(t, 1)
+				first.Block.PrevVerificationTickets = nil
+				*b = *first
+			},
+		},
+	} {
+		test := test
+		verdict := "reject"
+		if test.valid {
+			verdict = "accept"
+		}
+		t.Run("VerifyBlock should "+verdict+" a block given "+test.name, func(t *testing.T) {
+			t.Parallel()
+
+			block := newBlock(t, 5)
+			if test.tamper != nil {
+				test.tamper(t, block)
+			}
+			threshold := test.threshold
+			if threshold == 0 {
+				threshold = DefaultNotarizationThreshold
+			}
+
+			err := VerifyBlock(block, minerKeys, threshold)
+			if test.valid {
+				require.Nil(t, err)
+			} else {
+				require.True(t, errors.Is(err, ErrInvalidBlock), err)
+			}
+		})
+	}
+
+	t.Run("VerifyBlock should reject thresholds which are not percentages", func(t *testing.T) {
+		t.Parallel()
+
+		for _, threshold := range []int{-1, 0, 101} {
+			require.NotNil(t, VerifyBlock(newBlock(t, 5), minerKeys, threshold), "threshold %d", threshold)
+		}
+	})
+}
+
+func minerID(keys *model.KeyPair) string {
+	return ClientID(keys.PublicKey)
+}
@@ -0,0 +1,90 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/herumi/bls-go-binary/bls"
+	"github.com/tyler-smith/go-bip39" //nolint
+)
+
+var (
+	blsInit sync.Once
+	// blsLock guards the random source of bls, which key generation replaces with a seed.
+	// Everything drawing from it, as SetByCSPRNG and GetMasterSecretKey do, has to hold the lock.
+	blsLock sync.Mutex
+)
+
+type blsSigner struct{}
+
+func initBLS() {
+	blsInit.Do(func() {
+		if err := bls.Init(bls.CurveFp254BNb); err != nil {
+			panic(fmt.Errorf("failed to initialise bls: %w", err))
+		}
+	})
+}
+
+func (blsSigner) Scheme() string {
+	return BLS0Chain
+}
+
+func (blsSigner) GenerateKeys(mnemonic string) (*model.KeyPair, error) {
+	initBLS()
+	blsLock.Lock()
+	defer func() {
+		bls.SetRandFunc(nil)
+		blsLock.Unlock()
+	}()
+
+	seed := bip39.NewSeed(mnemonic, "0chain-client-split-key") //nolint
+	bls.SetRandFunc(bytes.NewReader(seed))
+
+	var secretKey bls.SecretKey
+	secretKey.SetByCSPRNG()
+
+	return &model.KeyPair{
+		Scheme:     BLS0Chain,
+		PublicKey:  secretKey.GetPublicKey().SerializeToHexStr(),
+		PrivateKey: secretKey.SerializeToHexStr(),
+	}, nil
+}
+
+func (blsSigner) Sign(hash, privateKey string) (string, error) {
+	initBLS()
+
+	var secretKey bls.SecretKey
+	if err := secretKey.DeserializeHexStr(privateKey); err != nil {
+		return "", fmt.Errorf("invalid private key: %w", err)
+	}
+	hashToSign, err := hex.DecodeString(hash)
+	if err != nil {
+		return "", fmt.Errorf("invalid hash: %w", err)
+	}
+
+	return secretKey.Sign(string(hashToSign)).SerializeToHexStr(), nil
+}
+
+func (blsSigner) Verify(publicKeyHex, signatureHex, hash string) (bool, error) {
+	initBLS()
+
+	var publicKey bls.PublicKey
+	if err := publicKey.DeserializeHexStr(publicKeyHex); err != nil {
+		return false, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	var signature bls.Sign
+	if err := signature.DeserializeHexStr(signatureHex); err != nil {
+		return false, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	hashToVerify, err := hex.DecodeString(hash)
+	if err != nil {
+		return false, fmt.Errorf("invalid hash: %w", err)
+	}
+
+	return signature.Verify(&publicKey, string(hashToVerify)), nil
+}
@@ -1,7 +1,6 @@
 package crypto
 
 import (
-	"bytes"
 	"crypto/sha1"
 	"crypto/sha256"
 	_ "crypto/sha256"
@@ -10,54 +9,54 @@ import (
 	"golang.org/x/crypto/sha3"
 	"io"
 	"os"
+	"strconv"
 	"sync"
 	"testing"
 
 	"github.com/0chain/system_test/internal/api/model"
-	"github.com/herumi/bls-go-binary/bls"
 	"github.com/lithammer/shortuuid/v3" //nolint
 	"github.com/tyler-smith/go-bip39"   //nolint
 )
 
-var blsLock sync.Mutex
+var (
+	deterministicMnemonics bool
+	mnemonicsLock          sync.Mutex
+	// mnemonicCounts numbers the mnemonics generated per test, so one test can still have several wallets
+	mnemonicCounts = make(map[string]int)
+)
+
+// UseDeterministicMnemonics makes GenerateMnemonic derive mnemonics from the test name instead of random entropy,
+// so the wallets of recorded and replayed runs, and with them the requests, are the same
+func UseDeterministicMnemonics(enabled bool) {
+	mnemonicsLock.Lock()
+	defer mnemonicsLock.Unlock()
 
-const BLS0Chain = "bls0chain"
+	deterministicMnemonics = enabled
+	mnemonicCounts = make(map[string]int)
+}
 
 func GenerateMnemonic(t *testing.T) string {
-	entropy, _ := bip39.NewEntropy(256)       //nolint
+	entropy := mnemonicEntropy(t.Name())
 	mnemonic, _ := bip39.NewMnemonic(entropy) //nolint
 	t.Logf("Generated mnemonic [%s]", mnemonic)
 
 	return mnemonic
 }
 
-func GenerateKeys(t *testing.T, mnemonic string) *model.KeyPair {
-	defer func() {
-		if err := recover(); err != nil {
-			t.Errorf("panic occurred: ", err)
-		}
-	}()
-	blsLock.Lock()
-	defer func() {
-		blsLock.Unlock()
-		bls.SetRandFunc(nil)
-	}()
-
-	_ = bls.Init(bls.CurveFp254BNb)
-	seed := bip39.NewSeed(mnemonic, "0chain-client-split-key") //nolint
-	random := bytes.NewReader(seed)
-	bls.SetRandFunc(random)
-
-	var secretKey bls.SecretKey
-	secretKey.SetByCSPRNG()
-
-	publicKey := secretKey.GetPublicKey()
-	secretKeyHex := secretKey.SerializeToHexStr()
-	publicKeyHex := publicKey.SerializeToHexStr()
-
-	t.Logf("Generated public key [%s] and secret key [%s]", publicKeyHex, secretKeyHex)
-
-	return &model.KeyPair{PublicKey: *publicKey, PrivateKey: secretKey}
+func mnemonicEntropy(name string) []byte {
+	mnemonicsLock.Lock()
+	defer mnemonicsLock.Unlock()
+
+	if !deterministicMnemonics {
+		entropy, _ := bip39.NewEntropy(256) //nolint
+		return entropy
+	}
+
+	count := mnemonicCounts[name]
+	mnemonicCounts[name] = count + 1
+	entropy := sha256.Sum256([]byte(name + ":" + strconv.Itoa(count)))
+
+	return entropy[:]
 }
 
 func NewConnectionID() string {
@@ -93,7 +92,7 @@ func HashTransaction(request *model.Transaction) {
 		blankIfNil(request.TransactionNonce) + ":" +
 		blankIfNil(request.ClientId) + ":" +
 		blankIfNil(request.ToClientId) + ":" +
-		blankIfNil(request.TransactionValue) + ":" +
+		strconv.FormatUint(uint64(request.TransactionValue), 10) + ":" +
 		Sha3256([]byte(request.TransactionData))
 
 	request.Hash = Sha3256([]byte(hashData))
@@ -107,9 +106,36 @@ func Sha3256(publicKeyBytes []byte) string {
 	return clientId
 }
 
-func SignTransaction(request *model.Transaction, pair *model.KeyPair) {
-	hashToSign, _ := hex.DecodeString(request.Hash)
-	request.Signature = pair.PrivateKey.Sign(string(hashToSign)).SerializeToHexStr()
+func SignTransaction(request *model.Transaction, pair *model.KeyPair) error {
+	signature, err := Sign(request.Hash, pair)
+	if err != nil {
+		return err
+	}
+	request.Signature = signature
+
+	return nil
+}
+
+// Sign signs a hex hash with the keys of pair, using the signer of their scheme
+func Sign(hash string, pair *model.KeyPair) (string, error) {
+	signer, err := NewSigner(pair.Scheme)
+	if err != nil {
+		return "", err
+	}
+
+	return signer.Sign(hash, pair.PrivateKey)
+}
+
+// ClientID returns the ID of the wallet with the hex public key, the hash of the raw key
+func ClientID(publicKey string) string {
+	publicKeyBytes, _ := hex.DecodeString(publicKey)
+	return Sha3256(publicKeyBytes)
+}
+
+// VerifySignature checks a hex bls0chain signature against the hex hash it was made over,
+// the scheme miners and sharders always sign with
+func VerifySignature(publicKeyHex, signatureHex, hash string) (bool, error) {
+	return blsSigner{}.Verify(publicKeyHex, signatureHex, hash)
 }
 
 func blankIfNil(obj interface{}) string {
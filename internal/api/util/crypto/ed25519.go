@@ -0,0 +1,61 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/tyler-smith/go-bip39" //nolint
+)
+
+type ed25519Signer struct{}
+
+func (ed25519Signer) Scheme() string {
+	return ED25519
+}
+
+func (ed25519Signer) GenerateKeys(mnemonic string) (*model.KeyPair, error) {
+	seed := bip39.NewSeed(mnemonic, "0chain-client-ed25519-key") //nolint
+	publicKey, privateKey, err := ed25519.GenerateKey(bytes.NewReader(seed))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.KeyPair{
+		Scheme:     ED25519,
+		PublicKey:  hex.EncodeToString(publicKey),
+		PrivateKey: hex.EncodeToString(privateKey),
+	}, nil
+}
+
+func (ed25519Signer) Sign(hash, privateKey string) (string, error) {
+	privateKeyBytes, err := hex.DecodeString(privateKey)
+	if err != nil || len(privateKeyBytes) != ed25519.PrivateKeySize {
+		return "", fmt.Errorf("invalid private key")
+	}
+	hashToSign, err := hex.DecodeString(hash)
+	if err != nil {
+		return "", fmt.Errorf("invalid hash: %w", err)
+	}
+
+	return hex.EncodeToString(ed25519.Sign(privateKeyBytes, hashToSign)), nil
+}
+
+func (ed25519Signer) Verify(publicKey, signature, hash string) (bool, error) {
+	publicKeyBytes, err := hex.DecodeString(publicKey)
+	if err != nil || len(publicKeyBytes) != ed25519.PublicKeySize {
+		return false, fmt.Errorf("invalid public key")
+	}
+	signatureBytes, err := hex.DecodeString(signature)
+	if err != nil {
+		return false, fmt.Errorf("invalid signature: %w", err)
+	}
+	hashToVerify, err := hex.DecodeString(hash)
+	if err != nil {
+		return false, fmt.Errorf("invalid hash: %w", err)
+	}
+
+	return ed25519.Verify(publicKeyBytes, hashToVerify, signatureBytes), nil
+}
@@ -0,0 +1,114 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/0chain/gosdk/zboxcore/encryption"
+	"github.com/0chain/system_test/internal/api/model"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+)
+
+// EncryptionTag is the tag zbox encrypts files and generates re-encryption keys with
+const EncryptionTag = "filetype:audio"
+
+// ReEncryptionHeaderSize is the size of the header blobbers prepend to every re-encrypted chunk they serve
+const ReEncryptionHeaderSize = 256
+
+var ErrDecryptionFailed = errors.New("decryption failed")
+
+// GenerateEncryptionKeys derives the proxy re-encryption keys of the wallet with mnemonic, the same keys zbox uses
+func GenerateEncryptionKeys(mnemonic string) (*model.EncryptionKeys, error) {
+	scheme := encryption.NewEncryptionScheme()
+	if _, err := scheme.Initialize(mnemonic); err != nil {
+		return nil, err
+	}
+
+	publicKey, err := scheme.GetPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := scheme.GetPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.EncryptionKeys{PublicKey: publicKey, PrivateKey: privateKey}, nil
+}
+
+// ReEncryptionKey computes the re_encryption_key of an auth ticket with which blobbers re-encrypt the files
+// the owner of keys encrypted for the client with encryptionPublicKey
+func ReEncryptionKey(keys *model.EncryptionKeys, encryptionPublicKey string) (string, error) {
+	scheme, err := encryptionScheme(keys)
+	if err != nil {
+		return "", err
+	}
+
+	return scheme.GetReGenKey(encryptionPublicKey, EncryptionTag)
+}
+
+// DecryptReEncryptedChunk decrypts a chunk a blobber re-encrypted for the client with keys, header included.
+// Chunks re-encrypted for another encryption public key fail with ErrDecryptionFailed.
+func DecryptReEncryptedChunk(keys *model.EncryptionKeys, chunk []byte) ([]byte, error) {
+	if len(chunk) < ReEncryptionHeaderSize {
+		return nil, fmt.Errorf("%w: chunk of [%d] bytes is shorter than its header", ErrDecryptionFailed, len(chunk))
+	}
+
+	scheme, err := encryptionScheme(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	message := &encryption.ReEncryptedMessage{D1: suite.Point(), D4: suite.Point(), D5: suite.Point()}
+	if err := message.Unmarshal(chunk); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+
+	data, err := scheme.ReDecrypt(message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+
+	return data, nil
+}
+
+// SignAuthTicket signs ticket with the keys of its owner, the signature blobbers check before sharing the file
+func SignAuthTicket(ticket *model.AuthTicket, pair *model.KeyPair) error {
+	hashData := fmt.Sprintf("%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v",
+		ticket.AllocationID,
+		ticket.ClientID,
+		ticket.OwnerID,
+		ticket.FilePathHash,
+		ticket.FileName,
+		ticket.RefType,
+		ticket.ReEncryptionKey,
+		ticket.Expiration,
+		ticket.Timestamp,
+		ticket.ActualFileHash,
+		ticket.Encrypted,
+	)
+
+	signature, err := Sign(Sha3256([]byte(hashData)), pair)
+	if err != nil {
+		return err
+	}
+	ticket.Signature = signature
+
+	return nil
+}
+
+func encryptionScheme(keys *model.EncryptionKeys) (encryption.EncryptionScheme, error) {
+	privateKey, err := base64.StdEncoding.DecodeString(keys.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption private key: %w", err)
+	}
+
+	scheme := encryption.NewEncryptionScheme()
+	if err := scheme.InitializeWithPrivateKey(privateKey); err != nil {
+		return nil, fmt.Errorf("invalid encryption private key: %w", err)
+	}
+
+	return scheme, nil
+}
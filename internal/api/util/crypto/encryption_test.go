@@ -0,0 +1,154 @@
+package crypto
+
+import (
+	"errors"
+	"testing"
+
+	coreencryption "github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/zboxcore/encryption"
+	"github.com/0chain/gosdk/zboxcore/marker"
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/stretchr/testify/require"
+)
+
+// reEncryptedChunk encrypts data as the owner of ownerKeys uploads it and re-encrypts it for recipient as a blobber serves it
+func reEncryptedChunk(t *testing.T, ownerKeys *model.EncryptionKeys, mnemonic string, recipient *model.EncryptionKeys, data []byte) []byte {
+	owner := encryption.NewEncryptionScheme()
+	_, err := owner.Initialize(mnemonic)
+	require.Nil(t, err)
+	owner.InitForEncryption(EncryptionTag)
+	encrypted, err := owner.Encrypt(data)
+	require.Nil(t, err)
+
+	reEncryptionKey, err := ReEncryptionKey(ownerKeys, recipient.PublicKey)
+	require.Nil(t, err)
+
+	// the blobber knows nothing but the encrypted key of the file and the re-encryption key of the auth ticket
+	blobber := encryption.NewEncryptionScheme()
+	_, err = blobber.Initialize(GenerateMnemonic(t))
+	require.Nil(t, err)
+	require.Nil(t, blobber.InitForDecryption(EncryptionTag, encrypted.EncryptedKey))
+	reEncrypted, err := blobber.ReEncrypt(encrypted, reEncryptionKey, recipient.PublicKey)
+	require.Nil(t, err)
+
+	chunk, err := reEncrypted.Marshal()
+	require.Nil(t, err)
+
+	return chunk
+}
+
+func TestReEncryption(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("a file the owner shares with one client only")
+
+	t.Run("A chunk re-encrypted for the recipient should decrypt with its keys only", func(t *testing.T) {
+		t.Parallel()
+
+		ownerMnemonic := GenerateMnemonic(t)
+		ownerKeys, err := GenerateEncryptionKeys(ownerMnemonic)
+		require.Nil(t, err)
+		recipientKeys, err := GenerateEncryptionKeys(GenerateMnemonic(t))
+		require.Nil(t, err)
+		otherKeys, err := GenerateEncryptionKeys(GenerateMnemonic(t))
+		require.Nil(t, err)
+
+		chunk := reEncryptedChunk(t, ownerKeys, ownerMnemonic, recipientKeys, data)
+		require.Greater(t, len(chunk), ReEncryptionHeaderSize)
+
+		decrypted, err := DecryptReEncryptedChunk(recipientKeys, chunk)
+		require.Nil(t, err)
+		require.Equal(t, data, decrypted)
+
+		_, err = DecryptReEncryptedChunk(otherKeys, chunk)
+		require.True(t, errors.Is(err, ErrDecryptionFailed), err)
+		_, err = DecryptReEncryptedChunk(ownerKeys, chunk)
+		require.True(t, errors.Is(err, ErrDecryptionFailed), err)
+	})
+
+	t.Run("GenerateEncryptionKeys should derive the same keys from the same mnemonic", func(t *testing.T) {
+		t.Parallel()
+
+		mnemonic := GenerateMnemonic(t)
+		keys, err := GenerateEncryptionKeys(mnemonic)
+		require.Nil(t, err)
+		again, err := GenerateEncryptionKeys(mnemonic)
+		require.Nil(t, err)
+		require.Equal(t, keys, again)
+	})
+
+	t.Run("DecryptReEncryptedChunk should fail on a chunk shorter than its header", func(t *testing.T) {
+		t.Parallel()
+
+		keys, err := GenerateEncryptionKeys(GenerateMnemonic(t))
+		require.Nil(t, err)
+
+		_, err = DecryptReEncryptedChunk(keys, make([]byte, ReEncryptionHeaderSize-1))
+		require.True(t, errors.Is(err, ErrDecryptionFailed), err)
+	})
+
+	t.Run("Keys which are not base64 should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		invalid := &model.EncryptionKeys{PublicKey: "not base64", PrivateKey: "not base64"}
+		_, err := ReEncryptionKey(invalid, invalid.PublicKey)
+		require.NotNil(t, err)
+		_, err = DecryptReEncryptedChunk(invalid, make([]byte, ReEncryptionHeaderSize))
+		require.NotNil(t, err)
+	})
+}
+
+func TestSignAuthTicket(t *testing.T) {
+	t.Parallel()
+
+	for _, scheme := range []string{BLS0Chain, ED25519} {
+		scheme := scheme
+		t.Run("SignAuthTicket should sign the hash gosdk signs auth tickets over with "+scheme, func(t *testing.T) {
+			t.Parallel()
+
+			keys := mustKeys(t, scheme)
+			ticket := &model.AuthTicket{
+				ClientID:        "client",
+				OwnerID:         "owner",
+				AllocationID:    "allocation",
+				FilePathHash:    "file path hash",
+				ActualFileHash:  "actual file hash",
+				FileName:        "file.txt",
+				RefType:         "f",
+				Expiration:      1661600000,
+				Timestamp:       1661500000,
+				ReEncryptionKey: "re-encryption key",
+				Encrypted:       true,
+			}
+			require.Nil(t, SignAuthTicket(ticket, keys))
+
+			expected := &marker.AuthTicket{
+				ClientID:        ticket.ClientID,
+				OwnerID:         ticket.OwnerID,
+				AllocationID:    ticket.AllocationID,
+				FilePathHash:    ticket.FilePathHash,
+				ActualFileHash:  ticket.ActualFileHash,
+				FileName:        ticket.FileName,
+				RefType:         ticket.RefType,
+				Expiration:      ticket.Expiration,
+				Timestamp:       ticket.Timestamp,
+				ReEncryptionKey: ticket.ReEncryptionKey,
+				Encrypted:       ticket.Encrypted,
+			}
+			require.Equal(t, "allocation:client:owner:file path hash:file.txt:f:re-encryption key:1661600000:1661500000:actual file hash:true",
+				expected.GetHashData())
+
+			signer, err := NewSigner(scheme)
+			require.Nil(t, err)
+			valid, err := signer.Verify(keys.PublicKey, ticket.Signature, coreencryption.Hash(expected.GetHashData()))
+			require.Nil(t, err)
+			require.True(t, valid)
+
+			ticket.Encrypted = false
+			require.Nil(t, SignAuthTicket(ticket, keys))
+			valid, err = signer.Verify(keys.PublicKey, ticket.Signature, coreencryption.Hash(expected.GetHashData()))
+			require.Nil(t, err)
+			require.False(t, valid, "a ticket which is not encrypted should be signed over another hash")
+		})
+	}
+}
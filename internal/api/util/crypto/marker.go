@@ -0,0 +1,184 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+)
+
+var ErrInvalidMarker = errors.New("invalid marker")
+
+// WriteMarkerHashData is the string a write marker is hashed over, the fields blobbers check the signature of
+func WriteMarkerHashData(wm *model.BlobberCommitConnectionWriteMarker) string {
+	return fmt.Sprintf("%v:%v:%v:%v:%v:%v:%v", wm.AllocationRoot, wm.PreviousAllocationRoot,
+		wm.AllocationID, wm.BlobberID, wm.ClientID, wm.Size, wm.Timestamp)
+}
+
+// WriteMarkerHash is the hash a write marker is signed over
+func WriteMarkerHash(wm *model.BlobberCommitConnectionWriteMarker) string {
+	return Sha3256([]byte(WriteMarkerHashData(wm)))
+}
+
+// ReadMarkerHashData is the string a read marker is hashed over, the fields blobbers check the signature of
+func ReadMarkerHashData(rm *model.BlobberDownloadFileReadMarker) string {
+	return fmt.Sprintf("%v:%v:%v:%v:%v:%v:%v", rm.AllocationID, rm.BlobberID,
+		rm.ClientID, rm.ClientKey, rm.OwnerID, rm.Counter, rm.Timestamp)
+}
+
+// ReadMarkerHash is the hash a read marker is signed over
+func ReadMarkerHash(rm *model.BlobberDownloadFileReadMarker) string {
+	return Sha3256([]byte(ReadMarkerHashData(rm)))
+}
+
+// SignWriteMarker signs wm with the keys of its client, Name, ContentHash and LookupHash are not signed
+func SignWriteMarker(wm *model.BlobberCommitConnectionWriteMarker, pair *model.KeyPair) error {
+	signature, err := Sign(WriteMarkerHash(wm), pair)
+	if err != nil {
+		return err
+	}
+	wm.Signature = signature
+
+	return nil
+}
+
+// SignReadMarker signs rm with the keys of its client
+func SignReadMarker(rm *model.BlobberDownloadFileReadMarker, pair *model.KeyPair) error {
+	signature, err := Sign(ReadMarkerHash(rm), pair)
+	if err != nil {
+		return err
+	}
+	rm.Signature = signature
+
+	return nil
+}
+
+// VerifyWriteMarker checks that wm was signed by its client, whose public key of scheme is clientKey
+func VerifyWriteMarker(wm *model.BlobberCommitConnectionWriteMarker, clientKey, scheme string) error {
+	if clientID := ClientID(clientKey); clientID != wm.ClientID {
+		return fmt.Errorf("%w: write marker of client [%s] is verified with the key of [%s]", ErrInvalidMarker, wm.ClientID, clientID)
+	}
+
+	return verifyMarker("write", WriteMarkerHash(wm), wm.Signature, clientKey, scheme)
+}
+
+// VerifyReadMarker checks that rm was signed by the client whose public key of scheme it carries
+func VerifyReadMarker(rm *model.BlobberDownloadFileReadMarker, scheme string) error {
+	if clientID := ClientID(rm.ClientKey); clientID != rm.ClientID {
+		return fmt.Errorf("%w: read marker of client [%s] carries the key of [%s]", ErrInvalidMarker, rm.ClientID, clientID)
+	}
+
+	return verifyMarker("read", ReadMarkerHash(rm), rm.Signature, rm.ClientKey, scheme)
+}
+
+// VerifyWriteMarkerOrder checks that next is a write marker blobbers accept after prev:
+// it chains onto the allocation root of prev, of the same allocation and blobber, and is not older than prev
+func VerifyWriteMarkerOrder(prev, next *model.BlobberCommitConnectionWriteMarker) error {
+	switch {
+	case next.AllocationID != prev.AllocationID || next.BlobberID != prev.BlobberID:
+		return fmt.Errorf("%w: write markers are of different allocations or blobbers", ErrInvalidMarker)
+	case next.PreviousAllocationRoot != prev.AllocationRoot:
+		return fmt.Errorf("%w: prev_allocation_root [%s] is not the allocation root [%s] of the previous write marker",
+			ErrInvalidMarker, next.PreviousAllocationRoot, prev.AllocationRoot)
+	case next.Timestamp < prev.Timestamp:
+		return fmt.Errorf("%w: write marker of [%d] is older than the previous one of [%d]", ErrInvalidMarker, next.Timestamp, prev.Timestamp)
+	}
+
+	return nil
+}
+
+// VerifyReadMarkerOrder checks that next is a read marker blobbers accept after prev for numBlocks more blocks,
+// read markers are redeemed by their counter, so a replayed or older marker pays for nothing
+func VerifyReadMarkerOrder(prev, next *model.BlobberDownloadFileReadMarker, numBlocks int64) error {
+	switch {
+	case next.AllocationID != prev.AllocationID || next.BlobberID != prev.BlobberID || next.ClientID != prev.ClientID:
+		return fmt.Errorf("%w: read markers are of different allocations, blobbers or clients", ErrInvalidMarker)
+	case next.Counter < prev.Counter+numBlocks:
+		return fmt.Errorf("%w: counter [%d] has to be at least [%d]", ErrInvalidMarker, next.Counter, prev.Counter+numBlocks)
+	}
+
+	return nil
+}
+
+func verifyMarker(kind, hash, signature, clientKey, scheme string) error {
+	signer, err := NewSigner(scheme)
+	if err != nil {
+		return err
+	}
+
+	valid, err := signer.Verify(clientKey, signature, hash)
+	if err != nil {
+		return fmt.Errorf("%w: %s marker signature: %v", ErrInvalidMarker, kind, err)
+	}
+	if !valid {
+		return fmt.Errorf("%w: %s marker signature does not verify", ErrInvalidMarker, kind)
+	}
+
+	return nil
+}
+
+// WriteMarkerBuilder builds the signed write markers of one client on one blobber of an allocation
+type WriteMarkerBuilder struct {
+	AllocationID string
+	BlobberID    string
+	ClientID     string
+	Keys         *model.KeyPair
+}
+
+func NewWriteMarkerBuilder(allocationID, blobberID string, keys *model.KeyPair) *WriteMarkerBuilder {
+	return &WriteMarkerBuilder{AllocationID: allocationID, BlobberID: blobberID, ClientID: ClientID(keys.PublicKey), Keys: keys}
+}
+
+// Build signs a write marker committing allocationRoot on top of prevAllocationRoot, size is the number of bytes the commit adds
+func (b *WriteMarkerBuilder) Build(allocationRoot, prevAllocationRoot string, size, timestamp int64) (*model.BlobberCommitConnectionWriteMarker, error) {
+	wm := &model.BlobberCommitConnectionWriteMarker{
+		AllocationRoot:         allocationRoot,
+		PreviousAllocationRoot: prevAllocationRoot,
+		AllocationID:           b.AllocationID,
+		BlobberID:              b.BlobberID,
+		ClientID:               b.ClientID,
+		Size:                   size,
+		Timestamp:              timestamp,
+	}
+	if err := SignWriteMarker(wm, b.Keys); err != nil {
+		return nil, err
+	}
+
+	return wm, nil
+}
+
+// ReadMarkerBuilder builds the signed read markers of one client on one blobber of an allocation,
+// each marker pays for its blocks on top of the ones of the markers built before it
+type ReadMarkerBuilder struct {
+	AllocationID string
+	BlobberID    string
+	OwnerID      string
+	ClientID     string
+	Keys         *model.KeyPair
+	// Counter is the counter of the latest marker built, start it from the latest read counter of the blobber
+	Counter int64
+}
+
+func NewReadMarkerBuilder(allocationID, blobberID, ownerID string, keys *model.KeyPair) *ReadMarkerBuilder {
+	return &ReadMarkerBuilder{AllocationID: allocationID, BlobberID: blobberID, OwnerID: ownerID, ClientID: ClientID(keys.PublicKey), Keys: keys}
+}
+
+// Build signs a read marker paying for numBlocks more blocks
+func (b *ReadMarkerBuilder) Build(numBlocks int64) (*model.BlobberDownloadFileReadMarker, error) {
+	rm := &model.BlobberDownloadFileReadMarker{
+		ClientID:     b.ClientID,
+		ClientKey:    b.Keys.PublicKey,
+		BlobberID:    b.BlobberID,
+		AllocationID: b.AllocationID,
+		OwnerID:      b.OwnerID,
+		Timestamp:    time.Now().Unix(),
+		Counter:      b.Counter + numBlocks,
+	}
+	if err := SignReadMarker(rm, b.Keys); err != nil {
+		return nil, err
+	}
+	b.Counter = rm.Counter
+
+	return rm, nil
+}
@@ -0,0 +1,156 @@
+package crypto
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/marker"
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/stretchr/testify/require"
+)
+
+func mustKeys(t *testing.T, scheme string) *model.KeyPair {
+	signer, err := NewSigner(scheme)
+	require.Nil(t, err)
+	keys, err := signer.GenerateKeys(GenerateMnemonic(t))
+	require.Nil(t, err)
+
+	return keys
+}
+
+func TestMarkerHash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WriteMarkerHash should match the hash gosdk signs write markers over", func(t *testing.T) {
+		t.Parallel()
+
+		wm := &model.BlobberCommitConnectionWriteMarker{
+			AllocationRoot:         "allocation root",
+			PreviousAllocationRoot: "previous allocation root",
+			AllocationID:           "allocation",
+			BlobberID:              "blobber",
+			ClientID:               "client",
+			Size:                   65536,
+			Timestamp:              1661500000,
+			Name:                   "not signed",
+			ContentHash:            "not signed",
+			LookupHash:             "not signed",
+		}
+		expected := &marker.WriteMarker{
+			AllocationRoot:         wm.AllocationRoot,
+			PreviousAllocationRoot: wm.PreviousAllocationRoot,
+			AllocationID:           wm.AllocationID,
+			BlobberID:              wm.BlobberID,
+			ClientID:               wm.ClientID,
+			Size:                   wm.Size,
+			Timestamp:              wm.Timestamp,
+		}
+
+		require.Equal(t, expected.GetHashData(), WriteMarkerHashData(wm))
+		require.Equal(t, expected.GetHash(), WriteMarkerHash(wm))
+	})
+
+	t.Run("ReadMarkerHash should match the hash gosdk signs read markers over", func(t *testing.T) {
+		t.Parallel()
+
+		rm := &model.BlobberDownloadFileReadMarker{
+			ClientID:     "client",
+			ClientKey:    "client key",
+			BlobberID:    "blobber",
+			AllocationID: "allocation",
+			OwnerID:      "owner",
+			Timestamp:    1661500000,
+			Counter:      42,
+		}
+		expected := &marker.ReadMarker{
+			ClientID:        rm.ClientID,
+			ClientPublicKey: rm.ClientKey,
+			BlobberID:       rm.BlobberID,
+			AllocationID:    rm.AllocationID,
+			OwnerID:         rm.OwnerID,
+			Timestamp:       common.Timestamp(rm.Timestamp),
+			ReadCounter:     rm.Counter,
+		}
+
+		require.Equal(t, expected.GetHash(), ReadMarkerHash(rm))
+	})
+}
+
+func TestMarkerSignature(t *testing.T) {
+	t.Parallel()
+
+	for _, scheme := range []string{BLS0Chain, ED25519} {
+		scheme := scheme
+		t.Run("Built markers should verify with the "+scheme+" key of their client only", func(t *testing.T) {
+			t.Parallel()
+
+			keys, other := mustKeys(t, scheme), mustKeys(t, scheme)
+
+			wm, err := NewWriteMarkerBuilder("allocation", "blobber", keys).Build("root", "", 1024, 1661500000)
+			require.Nil(t, err)
+			require.Nil(t, VerifyWriteMarker(wm, keys.PublicKey, scheme))
+			require.True(t, errors.Is(VerifyWriteMarker(wm, other.PublicKey, scheme), ErrInvalidMarker))
+
+			wm.Size++
+			require.True(t, errors.Is(VerifyWriteMarker(wm, keys.PublicKey, scheme), ErrInvalidMarker))
+
+			rm, err := NewReadMarkerBuilder("allocation", "blobber", "owner", keys).Build(3)
+			require.Nil(t, err)
+			require.Nil(t, VerifyReadMarker(rm, scheme))
+
+			rm.Counter++
+			require.True(t, errors.Is(VerifyReadMarker(rm, scheme), ErrInvalidMarker))
+
+			rm.Counter--
+			rm.ClientKey = other.PublicKey
+			require.True(t, errors.Is(VerifyReadMarker(rm, scheme), ErrInvalidMarker))
+		})
+	}
+}
+
+func TestMarkerOrder(t *testing.T) {
+	t.Parallel()
+
+	keys := mustKeys(t, ED25519)
+
+	t.Run("Write markers should chain onto the allocation root of the previous one", func(t *testing.T) {
+		t.Parallel()
+
+		builder := NewWriteMarkerBuilder("allocation", "blobber", keys)
+		first, err := builder.Build("first", "", 1024, 100)
+		require.Nil(t, err)
+		second, err := builder.Build("second", "first", 1024, 100)
+		require.Nil(t, err)
+		require.Nil(t, VerifyWriteMarkerOrder(first, second))
+
+		unchained, err := builder.Build("second", "other", 1024, 101)
+		require.Nil(t, err)
+		require.True(t, errors.Is(VerifyWriteMarkerOrder(first, unchained), ErrInvalidMarker))
+
+		older, err := builder.Build("second", "first", 1024, 99)
+		require.Nil(t, err)
+		require.True(t, errors.Is(VerifyWriteMarkerOrder(first, older), ErrInvalidMarker))
+
+		otherBlobber, err := NewWriteMarkerBuilder("allocation", "other", keys).Build("second", "first", 1024, 101)
+		require.Nil(t, err)
+		require.True(t, errors.Is(VerifyWriteMarkerOrder(first, otherBlobber), ErrInvalidMarker))
+	})
+
+	t.Run("Read markers should count up the blocks they pay for", func(t *testing.T) {
+		t.Parallel()
+
+		builder := NewReadMarkerBuilder("allocation", "blobber", "owner", keys)
+		builder.Counter = 10
+		first, err := builder.Build(2)
+		require.Nil(t, err)
+		require.EqualValues(t, 12, first.Counter)
+		second, err := builder.Build(3)
+		require.Nil(t, err)
+		require.EqualValues(t, 15, second.Counter)
+
+		require.Nil(t, VerifyReadMarkerOrder(first, second, 3))
+		require.True(t, errors.Is(VerifyReadMarkerOrder(first, second, 4), ErrInvalidMarker))
+		require.True(t, errors.Is(VerifyReadMarkerOrder(second, first, 1), ErrInvalidMarker))
+	})
+}
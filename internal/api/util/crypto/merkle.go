@@ -0,0 +1,113 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0chain/system_test/internal/api/model"
+)
+
+var ErrInvalidMerkleProof = errors.New("invalid merkle proof")
+
+// MerkleHash hashes a pair of hex child hashes into their parent, the way 0chain builds the block and receipt merkle trees
+func MerkleHash(left, right string) string {
+	return Sha3256([]byte(left + right))
+}
+
+// MerkleRoot returns the root of the merkle tree over the hex hashes of leaves, hashing the last node of
+// an odd level with itself, as gosdk and blobbers build the validation tree of files. A single leaf
+// is hashed with itself too and no leaves have the empty root.
+func MerkleRoot(leaves []string) string {
+	switch len(leaves) {
+	case 0:
+		return ""
+	case 1:
+		return MerkleHash(leaves[0], leaves[0])
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		parents := make([]string, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				parents = append(parents, MerkleHash(level[i], level[i+1]))
+			} else {
+				parents = append(parents, MerkleHash(level[i], level[i]))
+			}
+		}
+		level = parents
+	}
+
+	return level[0]
+}
+
+// CompactMerkleRoot returns the root of the compact merkle tree over leaves, which gosdk builds the content hash
+// of a shard with one chunk hash at a time. It only differs from MerkleRoot for a perfectly balanced tree,
+// whose root the compact tree hashes with itself once more.
+func CompactMerkleRoot(leaves []string) string {
+	root := MerkleRoot(leaves)
+	if n := len(leaves); n > 1 && n&(n-1) == 0 {
+		root = MerkleHash(root, root)
+	}
+
+	return root
+}
+
+// VerifyMerklePath walks path from leaf up the tree and reports whether it ends at root,
+// the leaf index tells on each level whether the sibling is on the left or on the right
+func VerifyMerklePath(leaf string, path *model.MerkleTreePath, root string) bool {
+	if path == nil {
+		return false
+	}
+
+	hash := leaf
+	index := path.LeafIndex
+	for _, sibling := range path.Nodes {
+		if index&1 == 1 {
+			hash = MerkleHash(sibling, hash)
+		} else {
+			hash = MerkleHash(hash, sibling)
+		}
+		index >>= 1
+	}
+
+	return hash == root
+}
+
+// VerifyConfirmation checks that a confirmation proves its transaction was included in block:
+// the transaction hashes to the confirmed hash, its merkle path leads to the merkle root of the block
+// and the path of its output hash leads to the receipt merkle root of the block
+func VerifyConfirmation(confirmation *model.Confirmation, block *model.BlockSummary) error {
+	if confirmation.Transaction == nil {
+		return fmt.Errorf("%w: confirmation of [%s] has no transaction", ErrInvalidMerkleProof, confirmation.Hash)
+	}
+
+	txn := *confirmation.Transaction
+	HashTransaction(&txn)
+	if txn.Hash != confirmation.Hash {
+		return fmt.Errorf("%w: transaction hashes to [%s] but [%s] was confirmed", ErrInvalidMerkleProof, txn.Hash, confirmation.Hash)
+	}
+	if outputHash := Sha3256([]byte(txn.TransactionOutput)); outputHash != txn.TxnOutputHash {
+		return fmt.Errorf("%w: transaction output hashes to [%s] but its output hash is [%s]", ErrInvalidMerkleProof, outputHash, txn.TxnOutputHash)
+	}
+
+	if !VerifyMerklePath(txn.Hash, confirmation.MerkleTreePath, confirmation.MerkleTreeRoot) {
+		return fmt.Errorf("%w: merkle path of [%s] does not lead to root [%s]", ErrInvalidMerkleProof, txn.Hash, confirmation.MerkleTreeRoot)
+	}
+	if !VerifyMerklePath(txn.TxnOutputHash, confirmation.ReceiptMerkleTreePath, confirmation.ReceiptMerkleTreeRoot) {
+		return fmt.Errorf("%w: receipt merkle path of [%s] does not lead to root [%s]", ErrInvalidMerkleProof, txn.Hash, confirmation.ReceiptMerkleTreeRoot)
+	}
+
+	switch {
+	case block.Hash != confirmation.BlockHash:
+		return fmt.Errorf("%w: block [%s] is not the confirmed block [%s]", ErrInvalidMerkleProof, block.Hash, confirmation.BlockHash)
+	case block.Round != confirmation.Round:
+		return fmt.Errorf("%w: block [%s] is of round [%d] but round [%d] was confirmed", ErrInvalidMerkleProof, block.Hash, block.Round, confirmation.Round)
+	case block.MerkleTreeRoot != confirmation.MerkleTreeRoot:
+		return fmt.Errorf("%w: block [%s] has merkle root [%s] but [%s] was confirmed", ErrInvalidMerkleProof, block.Hash, block.MerkleTreeRoot, confirmation.MerkleTreeRoot)
+	case block.ReceiptMerkleTreeRoot != confirmation.ReceiptMerkleTreeRoot:
+		return fmt.Errorf("%w: block [%s] has receipt merkle root [%s] but [%s] was confirmed", ErrInvalidMerkleProof, block.Hash, block.ReceiptMerkleTreeRoot, confirmation.ReceiptMerkleTreeRoot)
+	}
+
+	return nil
+}
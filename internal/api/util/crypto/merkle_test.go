@@ -0,0 +1,119 @@
+package crypto
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/0chain/gosdk/core/util"
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/stretchr/testify/require"
+)
+
+func testLeaves(n int) []string {
+	leaves := make([]string, n)
+	for i := range leaves {
+		leaves[i] = Sha3256([]byte("leaf " + strconv.Itoa(i)))
+	}
+	return leaves
+}
+
+func TestMerkleRoot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("MerkleRoot should match the root of the gosdk merkle tree", func(t *testing.T) {
+		t.Parallel()
+
+		for n := 1; n <= 17; n++ {
+			leaves := testLeaves(n)
+			hashables := make([]util.Hashable, n)
+			for i, leaf := range leaves {
+				hashables[i] = util.NewStringHashable(leaf)
+			}
+			var tree util.MerkleTree
+			tree.ComputeTree(hashables)
+
+			require.Equal(t, tree.GetRoot(), MerkleRoot(leaves), "%d leaves", n)
+		}
+	})
+
+	t.Run("CompactMerkleRoot should match the root of the gosdk compact merkle tree", func(t *testing.T) {
+		t.Parallel()
+
+		for n := 1; n <= 17; n++ {
+			leaves := testLeaves(n)
+			tree := util.NewCompactMerkleTree(nil)
+			for i, leaf := range leaves {
+				require.Nil(t, tree.AddLeaf(leaf, i))
+			}
+
+			require.Equal(t, tree.GetMerkleRoot(), CompactMerkleRoot(leaves), "%d leaves", n)
+		}
+	})
+
+	t.Run("MerkleRoot should hash a single leaf with itself and have no root without leaves", func(t *testing.T) {
+		t.Parallel()
+
+		leaf := testLeaves(1)[0]
+		require.Equal(t, MerkleHash(leaf, leaf), MerkleRoot([]string{leaf}))
+		require.Equal(t, MerkleHash(leaf, leaf), CompactMerkleRoot([]string{leaf}))
+		require.Empty(t, MerkleRoot(nil))
+	})
+}
+
+func TestVerifyMerklePath(t *testing.T) {
+	t.Parallel()
+
+	t.Run("VerifyMerklePath should verify the paths of the gosdk merkle tree", func(t *testing.T) {
+		t.Parallel()
+
+		for n := 1; n <= 9; n++ {
+			leaves := testLeaves(n)
+			hashables := make([]util.Hashable, n)
+			for i, leaf := range leaves {
+				hashables[i] = util.NewStringHashable(leaf)
+			}
+			var tree util.MerkleTree
+			tree.ComputeTree(hashables)
+			root := MerkleRoot(leaves)
+
+			for i, leaf := range leaves {
+				mtPath := tree.GetPathByIndex(i)
+				path := &model.MerkleTreePath{Nodes: mtPath.Nodes, LeafIndex: mtPath.LeafIndex}
+				require.True(t, VerifyMerklePath(leaf, path, root), "leaf %d of %d", i, n)
+				require.True(t, util.VerifyMerklePath(leaf, mtPath, root), "leaf %d of %d", i, n)
+			}
+		}
+	})
+
+	t.Run("VerifyMerklePath should reject paths which do not lead to the root", func(t *testing.T) {
+		t.Parallel()
+
+		leaves := testLeaves(5)
+		hashables := make([]util.Hashable, len(leaves))
+		for i, leaf := range leaves {
+			hashables[i] = util.NewStringHashable(leaf)
+		}
+		var tree util.MerkleTree
+		tree.ComputeTree(hashables)
+		root := MerkleRoot(leaves)
+		mtPath := tree.GetPathByIndex(2)
+
+		wrongIndex := &model.MerkleTreePath{Nodes: mtPath.Nodes, LeafIndex: 3}
+		require.False(t, VerifyMerklePath(leaves[2], wrongIndex, root))
+
+		tampered := &model.MerkleTreePath{Nodes: append([]string(nil), mtPath.Nodes...), LeafIndex: 2}
+		tampered.Nodes[0] = leaves[4]
+		require.False(t, VerifyMerklePath(leaves[2], tampered, root))
+
+		path := &model.MerkleTreePath{Nodes: mtPath.Nodes, LeafIndex: 2}
+		require.False(t, VerifyMerklePath(leaves[3], path, root))
+		require.False(t, VerifyMerklePath(leaves[2], nil, root))
+	})
+
+	t.Run("VerifyMerklePath should verify the single leaf path of a one transaction block", func(t *testing.T) {
+		t.Parallel()
+
+		leaf := testLeaves(1)[0]
+		require.True(t, VerifyMerklePath(leaf, &model.MerkleTreePath{Nodes: []string{leaf}}, MerkleRoot([]string{leaf})))
+	})
+}
@@ -0,0 +1,164 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+var ErrBelowThreshold = errors.New("not enough partial signatures to reach the threshold")
+
+// SplitKeys splits the bls0chain keys of pair into n keys whose private keys add up to its private key,
+// the way zwallet splits the keys of a split-key wallet. Signatures of all n keys over the same hash
+// aggregate with AggregateSignatures into the signature of pair.
+func SplitKeys(pair *model.KeyPair, n int) ([]*model.KeyPair, error) {
+	if err := requireBLS(pair.Scheme); err != nil {
+		return nil, err
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("cannot split keys into [%d] keys", n)
+	}
+	initBLS()
+
+	var primary bls.SecretKey
+	if err := primary.DeserializeHexStr(pair.PrivateKey); err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	keys := make([]*model.KeyPair, 0, n)
+	var sum bls.SecretKey
+	blsLock.Lock()
+	for i := 0; i < n-1; i++ {
+		var secretKey bls.SecretKey
+		secretKey.SetByCSPRNG()
+		sum.Add(&secretKey)
+		keys = append(keys, blsKeyPair(&secretKey))
+	}
+	blsLock.Unlock()
+
+	// the last key is whatever is left of the primary key
+	var primaryFr, sumFr, lastFr bls.Fr
+	if err := primaryFr.SetLittleEndian(primary.GetLittleEndian()); err != nil {
+		return nil, err
+	}
+	if err := sumFr.SetLittleEndian(sum.GetLittleEndian()); err != nil {
+		return nil, err
+	}
+	bls.FrSub(&lastFr, &primaryFr, &sumFr)
+
+	var last bls.SecretKey
+	if err := last.SetLittleEndian(lastFr.Serialize()); err != nil {
+		return nil, err
+	}
+
+	return append(keys, blsKeyPair(&last)), nil
+}
+
+// AggregateSignatures adds up hex bls0chain signatures over the same hash, the signatures of split keys
+// aggregate into the signature of the key they were split from
+func AggregateSignatures(signatures []string) (string, error) {
+	if len(signatures) == 0 {
+		return "", errors.New("no signatures to aggregate")
+	}
+	initBLS()
+
+	var aggregate bls.Sign
+	for _, signatureHex := range signatures {
+		var signature bls.Sign
+		if err := signature.DeserializeHexStr(signatureHex); err != nil {
+			return "", fmt.Errorf("invalid signature [%s]: %w", signatureHex, err)
+		}
+		aggregate.Add(&signature)
+	}
+
+	return aggregate.SerializeToHexStr(), nil
+}
+
+// GenerateThresholdKeys deals n shares of the bls0chain group keys, any threshold of which sign for the group
+// with RecoverSignature. The shares are evaluated at the IDs 1 to n, as zwallet createmswallet does.
+func GenerateThresholdKeys(group *model.KeyPair, threshold, n int) ([]*model.KeyShare, error) {
+	if err := requireBLS(group.Scheme); err != nil {
+		return nil, err
+	}
+	if threshold < 1 || threshold > n {
+		return nil, fmt.Errorf("threshold [%d] has to be between 1 and the number of shares [%d]", threshold, n)
+	}
+	initBLS()
+
+	var groupKey bls.SecretKey
+	if err := groupKey.DeserializeHexStr(group.PrivateKey); err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	blsLock.Lock()
+	polynomial := groupKey.GetMasterSecretKey(threshold)
+	blsLock.Unlock()
+
+	shares := make([]*model.KeyShare, 0, n)
+	for i := 1; i <= n; i++ {
+		var id bls.ID
+		if err := id.SetDecString(fmt.Sprint(i)); err != nil {
+			return nil, err
+		}
+
+		var secretKey bls.SecretKey
+		if err := secretKey.Set(polynomial, &id); err != nil {
+			return nil, err
+		}
+		shares = append(shares, &model.KeyShare{ID: id.GetHexString(), KeyPair: *blsKeyPair(&secretKey)})
+	}
+
+	return shares, nil
+}
+
+// SignPartial signs a hex hash with a threshold share, the partial signature RecoverSignature takes
+func SignPartial(hash string, share *model.KeyShare) (string, error) {
+	return Sign(hash, &share.KeyPair)
+}
+
+// RecoverSignature recovers the group signature from partial signatures keyed by the IDs of the shares
+// which signed, it fails with ErrBelowThreshold unless at least threshold shares signed
+func RecoverSignature(partials map[string]string, threshold int) (string, error) {
+	if len(partials) < threshold {
+		return "", fmt.Errorf("%w: [%d] of [%d] shares signed", ErrBelowThreshold, len(partials), threshold)
+	}
+	initBLS()
+
+	ids := make([]bls.ID, 0, len(partials))
+	signatures := make([]bls.Sign, 0, len(partials))
+	for idHex, signatureHex := range partials {
+		var id bls.ID
+		if err := id.SetHexString(idHex); err != nil {
+			return "", fmt.Errorf("invalid share id [%s]: %w", idHex, err)
+		}
+		var signature bls.Sign
+		if err := signature.DeserializeHexStr(signatureHex); err != nil {
+			return "", fmt.Errorf("invalid signature of share [%s]: %w", idHex, err)
+		}
+		ids = append(ids, id)
+		signatures = append(signatures, signature)
+	}
+
+	var recovered bls.Sign
+	if err := recovered.Recover(signatures, ids); err != nil {
+		return "", err
+	}
+
+	return recovered.SerializeToHexStr(), nil
+}
+
+func requireBLS(scheme string) error {
+	if scheme != BLS0Chain && scheme != "" {
+		return fmt.Errorf("signature scheme [%s] does not support split keys or threshold signatures", scheme)
+	}
+	return nil
+}
+
+func blsKeyPair(secretKey *bls.SecretKey) *model.KeyPair {
+	return &model.KeyPair{
+		Scheme:     BLS0Chain,
+		PublicKey:  secretKey.GetPublicKey().SerializeToHexStr(),
+		PrivateKey: secretKey.SerializeToHexStr(),
+	}
+}
@@ -0,0 +1,204 @@
+package crypto
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitKeys(t *testing.T) {
+	t.Parallel()
+
+	hash := Sha3256([]byte("split key transaction"))
+
+	t.Run("Signatures of the split keys should aggregate into the signature of the primary key", func(t *testing.T) {
+		t.Parallel()
+
+		primary := mustKeys(t, BLS0Chain)
+		for n := 1; n <= 4; n++ {
+			keys, err := SplitKeys(primary, n)
+			require.Nil(t, err)
+			require.Len(t, keys, n)
+
+			signatures := make([]string, 0, n)
+			for _, key := range keys {
+				signature, err := Sign(hash, key)
+				require.Nil(t, err)
+				signatures = append(signatures, signature)
+			}
+			aggregate, err := AggregateSignatures(signatures)
+			require.Nil(t, err)
+
+			expected, err := Sign(hash, primary)
+			require.Nil(t, err)
+			require.Equal(t, expected, aggregate, "%d split keys", n)
+
+			valid, err := blsSigner{}.Verify(primary.PublicKey, aggregate, hash)
+			require.Nil(t, err)
+			require.True(t, valid, "%d split keys", n)
+		}
+	})
+
+	t.Run("Signatures of only some split keys should not verify with the primary key", func(t *testing.T) {
+		t.Parallel()
+
+		primary := mustKeys(t, BLS0Chain)
+		keys, err := SplitKeys(primary, 3)
+		require.Nil(t, err)
+
+		var signatures []string
+		for _, key := range keys[:2] {
+			signature, err := Sign(hash, key)
+			require.Nil(t, err)
+			signatures = append(signatures, signature)
+		}
+		aggregate, err := AggregateSignatures(signatures)
+		require.Nil(t, err)
+
+		valid, err := blsSigner{}.Verify(primary.PublicKey, aggregate, hash)
+		require.Nil(t, err)
+		require.False(t, valid)
+	})
+
+	t.Run("SplitKeys should reject other schemes and fewer than one key", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := SplitKeys(mustKeys(t, ED25519), 2)
+		require.NotNil(t, err)
+		_, err = SplitKeys(mustKeys(t, BLS0Chain), 0)
+		require.NotNil(t, err)
+		_, err = AggregateSignatures(nil)
+		require.NotNil(t, err)
+	})
+
+	t.Run("Splitting keys should not disturb generating keys from a mnemonic at the same time", func(t *testing.T) {
+		t.Parallel()
+
+		mnemonic := GenerateMnemonic(t)
+		expected, err := blsSigner{}.GenerateKeys(mnemonic)
+		require.Nil(t, err)
+		primary := mustKeys(t, BLS0Chain)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 100)
+		for i := 0; i < 50; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				if _, err := SplitKeys(primary, 20); err != nil {
+					errs <- err
+				}
+				if _, err := GenerateThresholdKeys(primary, 10, 20); err != nil {
+					errs <- err
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				keys, err := blsSigner{}.GenerateKeys(mnemonic)
+				if err == nil && keys.PrivateKey != expected.PrivateKey {
+					err = errors.New("keys of the same mnemonic differ")
+				}
+				if err != nil {
+					errs <- err
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.Nil(t, err)
+		}
+	})
+}
+
+func TestThresholdKeys(t *testing.T) {
+	t.Parallel()
+
+	hash := Sha3256([]byte("multisig transfer"))
+
+	t.Run("Any threshold of the shares should recover the signature of the group", func(t *testing.T) {
+		t.Parallel()
+
+		group := mustKeys(t, BLS0Chain)
+		shares, err := GenerateThresholdKeys(group, 2, 3)
+		require.Nil(t, err)
+		require.Len(t, shares, 3)
+
+		expected, err := Sign(hash, group)
+		require.Nil(t, err)
+
+		for _, signers := range [][]int{{0, 1}, {0, 2}, {1, 2}, {0, 1, 2}} {
+			signed := make(map[string]string, len(signers))
+			for _, i := range signers {
+				signature, err := SignPartial(hash, shares[i])
+				require.Nil(t, err)
+				signed[shares[i].ID] = signature
+			}
+
+			recovered, err := RecoverSignature(signed, 2)
+			require.Nil(t, err)
+			require.Equal(t, expected, recovered, "shares %v", signers)
+
+			valid, err := blsSigner{}.Verify(group.PublicKey, recovered, hash)
+			require.Nil(t, err)
+			require.True(t, valid, "shares %v", signers)
+		}
+	})
+
+	t.Run("RecoverSignature should fail below the threshold", func(t *testing.T) {
+		t.Parallel()
+
+		group := mustKeys(t, BLS0Chain)
+		shares, err := GenerateThresholdKeys(group, 3, 4)
+		require.Nil(t, err)
+
+		signed := make(map[string]string)
+		for _, share := range shares[:2] {
+			signature, err := SignPartial(hash, share)
+			require.Nil(t, err)
+			signed[share.ID] = signature
+		}
+
+		_, err = RecoverSignature(signed, 3)
+		require.True(t, errors.Is(err, ErrBelowThreshold), err)
+		_, err = RecoverSignature(nil, 1)
+		require.True(t, errors.Is(err, ErrBelowThreshold), err)
+	})
+
+	t.Run("A signature recovered from fewer shares than the polynomial needs should not verify", func(t *testing.T) {
+		t.Parallel()
+
+		group := mustKeys(t, BLS0Chain)
+		shares, err := GenerateThresholdKeys(group, 3, 4)
+		require.Nil(t, err)
+
+		signed := make(map[string]string)
+		for _, share := range shares[:2] {
+			signature, err := SignPartial(hash, share)
+			require.Nil(t, err)
+			signed[share.ID] = signature
+		}
+
+		recovered, err := RecoverSignature(signed, 2)
+		require.Nil(t, err)
+		valid, err := blsSigner{}.Verify(group.PublicKey, recovered, hash)
+		require.Nil(t, err)
+		require.False(t, valid)
+	})
+
+	t.Run("GenerateThresholdKeys should reject other schemes and thresholds outside the shares", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := GenerateThresholdKeys(mustKeys(t, ED25519), 2, 3)
+		require.NotNil(t, err)
+
+		group := mustKeys(t, BLS0Chain)
+		for _, threshold := range []int{0, 4} {
+			_, err := GenerateThresholdKeys(group, threshold, 3)
+			require.NotNil(t, err, "threshold %d", threshold)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/klauspost/reedsolomon"
+)
+
+// ValidationLeaves is the number of leaves of the fixed merkle tree blobbers are challenged over,
+// every chunk of a shard is split into as many parts and part i of every chunk goes into leaf i
+const ValidationLeaves = 1024
+
+// ShardHashes are the hashes of the shard of a file one blobber stores
+type ShardHashes struct {
+	Size int64
+	// ContentHash is the compact merkle root over the sha256 hashes of the chunks of the shard
+	ContentHash string
+	// ValidationRoot is the root of the fixed merkle tree of the shard, the merkle_root of its ref
+	ValidationRoot string
+}
+
+// FileHashes are the hashes gosdk uploads a file with, Shards holds the data shards followed by the parity shards,
+// in the order of the blobbers of the allocation
+type FileHashes struct {
+	ActualHash string
+	ActualSize int64
+	ChunkSize  int64
+	Shards     []*ShardHashes
+}
+
+// HashShards erasure codes content into dataShards and parityShards the way gosdk uploads an unencrypted file,
+// reading chunkSize bytes per data shard at a time, and hashes every shard
+func HashShards(content io.Reader, dataShards, parityShards int, chunkSize int64) (*FileHashes, error) {
+	hasher, err := NewFileHasher(dataShards, parityShards, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(hasher, content); err != nil {
+		return nil, err
+	}
+
+	return hasher.Hashes()
+}
+
+// FileHasher hashes a file like HashShards while it is written in parts of any size, e.g. the requests of an upload
+type FileHasher struct {
+	encoder    reedsolomon.Encoder
+	chunkSize  int64
+	dataShards int
+	actualHash hash.Hash
+	actualSize int64
+	pending    []byte
+	shards     []*shardHasher
+}
+
+func NewFileHasher(dataShards, parityShards int, chunkSize int64) (*FileHasher, error) {
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("invalid chunk size [%d]", chunkSize)
+	}
+	encoder, err := reedsolomon.New(dataShards, parityShards)
+	if err != nil {
+		return nil, err
+	}
+
+	shards := make([]*shardHasher, dataShards+parityShards)
+	for i := range shards {
+		shards[i] = newShardHasher(chunkSize)
+	}
+
+	return &FileHasher{encoder: encoder, chunkSize: chunkSize, dataShards: dataShards, actualHash: sha256.New(), shards: shards}, nil
+}
+
+// Write hashes every complete chunk of data shards and keeps the rest until more is written or Hashes is called
+func (h *FileHasher) Write(p []byte) (int, error) {
+	h.actualHash.Write(p)
+	h.actualSize += int64(len(p))
+	h.pending = append(h.pending, p...)
+
+	size := int(h.chunkSize) * h.dataShards
+	for len(h.pending) >= size {
+		if err := h.encode(h.pending[:size]); err != nil {
+			return 0, err
+		}
+		h.pending = h.pending[size:]
+	}
+
+	return len(p), nil
+}
+
+// Hashes hashes what is left of the file as its last chunk and returns the hashes of the whole file
+func (h *FileHasher) Hashes() (*FileHashes, error) {
+	if len(h.pending) > 0 {
+		if err := h.encode(h.pending); err != nil {
+			return nil, err
+		}
+		h.pending = nil
+	}
+
+	hashes := &FileHashes{
+		ActualHash: hex.EncodeToString(h.actualHash.Sum(nil)),
+		ActualSize: h.actualSize,
+		ChunkSize:  h.chunkSize,
+	}
+	for _, shard := range h.shards {
+		hashes.Shards = append(hashes.Shards, shard.hashes())
+	}
+
+	return hashes, nil
+}
+
+// encode splits a chunk into its data and parity fragments and hashes every fragment into its shard
+func (h *FileHasher) encode(chunk []byte) error {
+	// Split zeroes up to dataShards bytes past the chunk when it has the capacity, and indexes past it when it has none
+	data := make([]byte, len(chunk), len(chunk)+h.dataShards)
+	copy(data, chunk)
+	fragments, err := h.encoder.Split(data)
+	if err != nil {
+		return err
+	}
+	if err := h.encoder.Encode(fragments); err != nil {
+		return err
+	}
+	for i, fragment := range fragments {
+		h.shards[i].write(fragment)
+	}
+
+	return nil
+}
+
+// shardHasher collects the leaves of the content and validation trees of a shard chunk by chunk
+type shardHasher struct {
+	partSize         int64
+	size             int64
+	contentLeaves    []string
+	validationLeaves [ValidationLeaves][]string
+}
+
+func newShardHasher(chunkSize int64) *shardHasher {
+	partSize := chunkSize / ValidationLeaves
+	if partSize == 0 {
+		partSize = 1
+	}
+
+	return &shardHasher{partSize: partSize}
+}
+
+func (h *shardHasher) write(fragment []byte) {
+	h.size += int64(len(fragment))
+	h.contentLeaves = append(h.contentLeaves, sha256Hex(fragment))
+
+	leaf := 0
+	for start := int64(0); start < int64(len(fragment)); start += h.partSize {
+		end := start + h.partSize
+		if end > int64(len(fragment)) {
+			end = int64(len(fragment))
+		}
+		h.validationLeaves[leaf] = append(h.validationLeaves[leaf], sha256Hex(fragment[start:end]))
+		leaf = (leaf + 1) % ValidationLeaves
+	}
+}
+
+func (h *shardHasher) hashes() *ShardHashes {
+	roots := make([]string, ValidationLeaves)
+	for i, leaves := range h.validationLeaves {
+		roots[i] = CompactMerkleRoot(leaves)
+	}
+
+	return &ShardHashes{
+		Size:           h.size,
+		ContentHash:    CompactMerkleRoot(h.contentLeaves),
+		ValidationRoot: MerkleRoot(roots),
+	}
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,115 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/klauspost/reedsolomon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashShards(t *testing.T) {
+	t.Parallel()
+
+	const chunkSize = 64 * 1024
+
+	for _, test := range []struct {
+		name         string
+		size         int
+		dataShards   int
+		parityShards int
+	}{
+		{name: "a file smaller than a validation leaf part", size: 100, dataShards: 1},
+		{name: "a file of exactly one chunk", size: chunkSize, dataShards: 1},
+		{name: "a file of one chunk and a byte", size: chunkSize + 1, dataShards: 1},
+		{name: "a file of a balanced number of chunks", size: 4 * chunkSize, dataShards: 1},
+		{name: "a file of an unbalanced number of chunks", size: 3*chunkSize + 1024, dataShards: 1},
+		{name: "an erasure coded file", size: 5*chunkSize + 7, dataShards: 2, parityShards: 1},
+		{name: "an erasure coded file of many shards", size: 9 * chunkSize, dataShards: 4, parityShards: 2},
+	} {
+		test := test
+		t.Run("Hashes should match gosdk for "+test.name, func(t *testing.T) {
+			t.Parallel()
+
+			content := knownContent(test.size)
+			expected := gosdkHashes(t, content, test.dataShards, test.parityShards, chunkSize)
+
+			hashes, err := HashShards(bytes.NewReader(content), test.dataShards, test.parityShards, chunkSize)
+			require.Nil(t, err)
+			require.Equal(t, expected, hashes)
+
+			// an upload writes parts unrelated to the chunk size
+			hasher, err := NewFileHasher(test.dataShards, test.parityShards, chunkSize)
+			require.Nil(t, err)
+			for start := 0; start < len(content); start += 3 * chunkSize / 2 {
+				end := start + 3*chunkSize/2
+				if end > len(content) {
+					end = len(content)
+				}
+				_, err := hasher.Write(content[start:end])
+				require.Nil(t, err)
+			}
+			streamed, err := hasher.Hashes()
+			require.Nil(t, err)
+			require.Equal(t, expected, streamed)
+		})
+	}
+}
+
+// knownContent is a file whose bytes count up, the same on every run
+func knownContent(size int) []byte {
+	content := make([]byte, size)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+
+	return content
+}
+
+// gosdkHashes hashes content with the hasher gosdk uploads every shard with, one hasher per blobber
+func gosdkHashes(t *testing.T, content []byte, dataShards, parityShards int, chunkSize int64) *FileHashes {
+	encoder, err := reedsolomon.New(dataShards, parityShards)
+	require.Nil(t, err)
+
+	hashers := make([]sdk.Hasher, dataShards+parityShards)
+	for i := range hashers {
+		hashers[i] = sdk.CreateHasher(int(chunkSize))
+	}
+	sizes := make([]int64, len(hashers))
+
+	chunkIndex := 0
+	for start := 0; start < len(content); start += int(chunkSize) * dataShards {
+		end := start + int(chunkSize)*dataShards
+		if end > len(content) {
+			end = len(content)
+		}
+
+		data := make([]byte, end-start, end-start+dataShards)
+		copy(data, content[start:end])
+		fragments, err := encoder.Split(data)
+		require.Nil(t, err)
+		require.Nil(t, encoder.Encode(fragments))
+		for i, fragment := range fragments {
+			leaf := sha256.Sum256(fragment)
+			require.Nil(t, hashers[i].WriteToChallenge(fragment, chunkIndex))
+			require.Nil(t, hashers[i].WriteHashToContent(hex.EncodeToString(leaf[:]), chunkIndex))
+			sizes[i] += int64(len(fragment))
+		}
+		chunkIndex++
+	}
+
+	actualHash := sha256.Sum256(content)
+	hashes := &FileHashes{ActualHash: hex.EncodeToString(actualHash[:]), ActualSize: int64(len(content)), ChunkSize: chunkSize}
+	for i, hasher := range hashers {
+		contentHash, err := hasher.GetContentHash()
+		require.Nil(t, err)
+		challengeHash, err := hasher.GetChallengeHash()
+		require.Nil(t, err)
+		hashes.Shards = append(hashes.Shards, &ShardHashes{Size: sizes[i], ContentHash: contentHash, ValidationRoot: challengeHash})
+	}
+
+	return hashes
+}
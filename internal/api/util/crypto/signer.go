@@ -0,0 +1,35 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/0chain/system_test/internal/api/model"
+)
+
+// Signature schemes of 0chain wallets, the signature_scheme of zbox_config.yaml
+const (
+	BLS0Chain = "bls0chain"
+	ED25519   = "ed25519"
+)
+
+// Signer generates the keys of wallets and signs with them for one signature scheme.
+// Keys and signatures are hex encoded and hashes are signed over their raw bytes, as 0chain expects.
+type Signer interface {
+	Scheme() string
+	// GenerateKeys derives the keys of a wallet from mnemonic, the same keys gosdk recovers from it
+	GenerateKeys(mnemonic string) (*model.KeyPair, error)
+	Sign(hash, privateKey string) (string, error)
+	Verify(publicKey, signature, hash string) (bool, error)
+}
+
+// NewSigner returns the signer of scheme, bls0chain when scheme is empty
+func NewSigner(scheme string) (Signer, error) {
+	switch scheme {
+	case BLS0Chain, "":
+		return blsSigner{}, nil
+	case ED25519:
+		return ed25519Signer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown signature scheme [%s]", scheme)
+	}
+}
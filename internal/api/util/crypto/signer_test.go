@@ -0,0 +1,143 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/stretchr/testify/require"
+)
+
+const fixedMnemonic = "travel twenty hen negative fresh sentence hen flat swift embody increase juice eternal satisfy want vessel matter honey video begin dutch trigger romance assault"
+
+// gosdkKeys recovers the wallet of mnemonic with gosdk, holding the lock of the bls random source gosdk draws from as well
+func gosdkKeys(t *testing.T, scheme, mnemonic string) *zcncrypto.Wallet {
+	blsLock.Lock()
+	defer blsLock.Unlock()
+
+	wallet, err := zcncrypto.NewSignatureScheme(scheme).RecoverKeys(mnemonic)
+	require.Nil(t, err)
+
+	return wallet
+}
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+
+	hash := Sha3256([]byte("signed transaction"))
+
+	for _, scheme := range []string{BLS0Chain, ED25519} {
+		scheme := scheme
+
+		t.Run("GenerateKeys should derive the keys gosdk recovers from the same mnemonic with "+scheme, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := NewSigner(scheme)
+			require.Nil(t, err)
+			require.Equal(t, scheme, signer.Scheme())
+
+			keys, err := signer.GenerateKeys(fixedMnemonic)
+			require.Nil(t, err)
+			expected := gosdkKeys(t, scheme, fixedMnemonic)
+			require.Equal(t, scheme, keys.Scheme)
+			require.Equal(t, expected.Keys[0].PublicKey, keys.PublicKey)
+			require.Equal(t, expected.Keys[0].PrivateKey, keys.PrivateKey)
+			require.Equal(t, expected.ClientID, ClientID(keys.PublicKey))
+
+			gosdkScheme := zcncrypto.NewSignatureScheme(scheme)
+			require.Nil(t, gosdkScheme.SetPrivateKey(keys.PrivateKey))
+			expectedSignature, err := gosdkScheme.Sign(hash)
+			require.Nil(t, err)
+			signature, err := signer.Sign(hash, keys.PrivateKey)
+			require.Nil(t, err)
+			require.Equal(t, expectedSignature, signature)
+		})
+
+		t.Run("Signatures should verify with the signing keys only with "+scheme, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := NewSigner(scheme)
+			require.Nil(t, err)
+			keys := mustKeys(t, scheme)
+			other := mustKeys(t, scheme)
+
+			signature, err := signer.Sign(hash, keys.PrivateKey)
+			require.Nil(t, err)
+
+			valid, err := signer.Verify(keys.PublicKey, signature, hash)
+			require.Nil(t, err)
+			require.True(t, valid)
+
+			valid, err = signer.Verify(other.PublicKey, signature, hash)
+			require.Nil(t, err)
+			require.False(t, valid, "another public key")
+
+			valid, err = signer.Verify(keys.PublicKey, signature, Sha3256([]byte("another transaction")))
+			require.Nil(t, err)
+			require.False(t, valid, "another hash")
+		})
+
+		t.Run("Keys, signatures and hashes which are not hex should be rejected with "+scheme, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := NewSigner(scheme)
+			require.Nil(t, err)
+			keys := mustKeys(t, scheme)
+			signature, err := signer.Sign(hash, keys.PrivateKey)
+			require.Nil(t, err)
+
+			_, err = signer.Sign(hash, "not hex")
+			require.NotNil(t, err, "private key")
+			_, err = signer.Sign("not hex", keys.PrivateKey)
+			require.NotNil(t, err, "hash")
+
+			_, err = signer.Verify("not hex", signature, hash)
+			require.NotNil(t, err, "public key")
+			_, err = signer.Verify(keys.PublicKey, "not hex", hash)
+			require.NotNil(t, err, "signature")
+			_, err = signer.Verify(keys.PublicKey, signature, "not hex")
+			require.NotNil(t, err, "hash")
+		})
+	}
+
+	t.Run("ed25519 keys of the wrong length should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		keys := mustKeys(t, ED25519)
+		signature, err := ed25519Signer{}.Sign(hash, keys.PrivateKey)
+		require.Nil(t, err)
+
+		_, err = ed25519Signer{}.Sign(hash, keys.PrivateKey[:len(keys.PrivateKey)-2])
+		require.NotNil(t, err)
+		_, err = ed25519Signer{}.Sign(hash, keys.PrivateKey+"00")
+		require.NotNil(t, err)
+		_, err = ed25519Signer{}.Verify(keys.PublicKey[:len(keys.PublicKey)-2], signature, hash)
+		require.NotNil(t, err)
+		_, err = ed25519Signer{}.Verify(keys.PublicKey+"00", signature, hash)
+		require.NotNil(t, err)
+	})
+
+	t.Run("bls keys of the wrong length should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		keys := mustKeys(t, BLS0Chain)
+		signature, err := blsSigner{}.Sign(hash, keys.PrivateKey)
+		require.Nil(t, err)
+
+		_, err = blsSigner{}.Verify(keys.PublicKey[:len(keys.PublicKey)-2], signature, hash)
+		require.NotNil(t, err)
+		_, err = blsSigner{}.Verify(keys.PublicKey, signature[:len(signature)-2], hash)
+		require.NotNil(t, err)
+	})
+
+	t.Run("NewSigner should default to bls0chain and reject unknown schemes", func(t *testing.T) {
+		t.Parallel()
+
+		signer, err := NewSigner("")
+		require.Nil(t, err)
+		require.Equal(t, BLS0Chain, signer.Scheme())
+
+		_, err = NewSigner("secp256k1")
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "secp256k1")
+	})
+}
@@ -8,8 +8,12 @@ const (
 
 // Addresses of SC
 const (
-	FaucetSmartContractAddress  = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d3"
-	StorageSmartContractAddress = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7"
+	FaucetSmartContractAddress   = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d3"
+	StorageSmartContractAddress  = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7"
+	MinerSmartContractAddress    = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9"
+	VestingSmartContractAddress  = "2bba5b05949ea59c80aed3ac3474d7379d3be737e8eb5a968c52295e48333ead"
+	ZCNSmartContractAddress      = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e0"
+	MultiSigSmartContractAddress = "27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7"
 )
 
 // Statuses of transactions
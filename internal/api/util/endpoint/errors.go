@@ -0,0 +1,163 @@
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	resty "github.com/go-resty/resty/v2" //nolint
+)
+
+// ErrNoHealthyNodes is returned when there are no miners or sharders to call
+var ErrNoHealthyNodes = errors.New("no healthy nodes")
+
+// NodeOutcome is the result of calling a single node during a fan-out
+type NodeOutcome struct {
+	Node     string
+	Response *resty.Response //nolint
+	Err      error
+	TimedOut bool
+}
+
+func (o NodeOutcome) String() string {
+	switch {
+	case o.TimedOut:
+		return fmt.Sprintf("node [%s] timed out with error [%v]", o.Node, o.Err)
+	case o.Err != nil:
+		return fmt.Sprintf("node [%s] failed with error [%v]", o.Node, o.Err)
+	case o.Response == nil:
+		return fmt.Sprintf("node [%s] returned no response", o.Node)
+	default:
+		return fmt.Sprintf("node [%s] returned HTTP [%s]", o.Node, o.Response.Status())
+	}
+}
+
+// ConsensusNotReachedError is returned when the nodes of a fan-out did not agree on a result
+type ConsensusNotReachedError struct {
+	// Reason describes how the nodes disagreed
+	Reason string
+	// Outcomes of every node called, in the order the nodes are known to Zerochain
+	Outcomes []NodeOutcome
+}
+
+func (e *ConsensusNotReachedError) Error() string {
+	return "consensus not reached: " + e.Reason
+}
+
+// Unwrap returns the error most nodes failed with, so errors.As can tell what kind of failure prevailed
+func (e *ConsensusNotReachedError) Unwrap() error {
+	var nodeErrors []error
+	for _, outcome := range e.Outcomes {
+		if outcome.Err != nil {
+			nodeErrors = append(nodeErrors, outcome.Err)
+		}
+	}
+
+	return mostDominantError(nodeErrors)
+}
+
+// NodeHTTPError is returned when a node responded with an HTTP error status
+type NodeHTTPError struct {
+	Node       string
+	Endpoint   string
+	Status     string
+	StatusCode int
+	Body       []byte
+}
+
+func newNodeHTTPError(node, endpoint string, resp *resty.Response) *NodeHTTPError { //nolint
+	return &NodeHTTPError{
+		Node:       node,
+		Endpoint:   endpoint,
+		Status:     resp.Status(),
+		StatusCode: resp.StatusCode(),
+		Body:       resp.Body(),
+	}
+}
+
+func (e *NodeHTTPError) Error() string {
+	return "node [" + e.Node + "] endpoint [" + e.Endpoint + "] responded with HTTP [" + e.Status + "] and body [" + string(e.Body) + "]"
+}
+
+// DecodeError is returned when the body of a node could not be decoded into the target object
+type DecodeError struct {
+	Node     string
+	Endpoint string
+	Body     []byte
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	return "failed to decode body [" + string(e.Body) + "] from node [" + e.Node + "] endpoint [" + e.Endpoint + "]: " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func isHTTPError(err error) bool {
+	var httpError *NodeHTTPError
+	return errors.As(err, &httpError)
+}
+
+func isDecodeError(err error) bool {
+	var decodeError *DecodeError
+	return errors.As(err, &decodeError)
+}
+
+// errorKind groups errors of different nodes which failed the same way
+func errorKind(err error) string {
+	var httpError *NodeHTTPError
+	var decodeError *DecodeError
+
+	switch {
+	case errors.As(err, &httpError):
+		return "http " + httpError.Status
+	case errors.As(err, &decodeError):
+		return "decode"
+	case isTimeout(err):
+		return "timeout"
+	default:
+		return fmt.Sprintf("%T", err)
+	}
+}
+
+// mostDominantError returns the first error of the kind most nodes failed with
+func mostDominantError(nodeErrors []error) error {
+	var mostFrequent error
+	topFrequencyCount := 0
+
+	for _, currentError := range nodeErrors {
+		currentFrequencyCount := 0
+		for _, compareToError := range nodeErrors {
+			if errorKind(currentError) == errorKind(compareToError) {
+				currentFrequencyCount++
+			}
+		}
+
+		if currentFrequencyCount > topFrequencyCount {
+			topFrequencyCount = currentFrequencyCount
+			mostFrequent = currentError
+		}
+	}
+
+	return mostFrequent
+}
+
+func describeOutcomes(outcomes []*nodeOutcome) string {
+	description := make([]string, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		description = append(description, outcome.String())
+	}
+
+	return strings.Join(description, "; ")
+}
+
+func newConsensusNotReachedError(reason string, outcomes []*nodeOutcome) *ConsensusNotReachedError {
+	nodeOutcomes := make([]NodeOutcome, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		nodeOutcomes = append(nodeOutcomes, outcome.NodeOutcome)
+	}
+
+	return &ConsensusNotReachedError{Reason: reason, Outcomes: nodeOutcomes}
+}
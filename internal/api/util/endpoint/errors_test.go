@@ -0,0 +1,124 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func respond(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func hang(w http.ResponseWriter, r *http.Request) {
+	<-r.Context().Done()
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	badRequest := newTestNode(t, respond(http.StatusBadRequest, `{"error":"invalid nonce"}`))
+	notJSON := newTestNode(t, respond(http.StatusOK, `not json`))
+	ok := newTestNode(t, respond(http.StatusOK, `{"round":1}`))
+	slow := newTestNode(t, hang)
+
+	type round struct {
+		Round int `json:"round"`
+	}
+
+	t.Run("A node responding with an error status should return its response with a NodeHTTPError", func(t *testing.T) {
+		t.Parallel()
+
+		z, _ := newTestZerochain(t, []string{badRequest}, []string{ok})
+		var target round
+		resp, err := z.GetFromMiner(context.Background(), badRequest, "/v1/x", &target)
+		require.NotNil(t, resp)
+
+		var httpError *NodeHTTPError
+		require.True(t, errors.As(err, &httpError), err)
+		require.Equal(t, badRequest, httpError.Node)
+		require.Equal(t, "/v1/x", httpError.Endpoint)
+		require.Equal(t, http.StatusBadRequest, httpError.StatusCode)
+		require.Equal(t, `{"error":"invalid nonce"}`, string(httpError.Body))
+		require.Zero(t, target.Round)
+	})
+
+	t.Run("A body which does not decode should return a DecodeError wrapping the JSON error", func(t *testing.T) {
+		t.Parallel()
+
+		z, _ := newTestZerochain(t, []string{notJSON}, []string{ok})
+		resp, err := z.GetFromMiner(context.Background(), notJSON, "/v1/x", &round{})
+		require.NotNil(t, resp)
+
+		var decodeError *DecodeError
+		require.True(t, errors.As(err, &decodeError), err)
+		require.Equal(t, "not json", string(decodeError.Body))
+		var syntaxError *json.SyntaxError
+		require.True(t, errors.As(err, &syntaxError), err)
+	})
+
+	t.Run("Consensus on an expected error status should return the response without an error", func(t *testing.T) {
+		t.Parallel()
+
+		z, _ := newTestZerochain(t, []string{badRequest, badRequest}, []string{ok})
+		resp, err := z.GetFromMiners(context.Background(), "/v1/x", ConsensusByHttpStatus("400 Bad Request"), &round{})
+		require.Nil(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("Consensus on an unexpected error status should return the response with a NodeHTTPError", func(t *testing.T) {
+		t.Parallel()
+
+		z, _ := newTestZerochain(t, []string{badRequest, badRequest, ok}, []string{ok})
+		resp, err := z.GetFromMiners(context.Background(), "/v1/x", ConsensusByHttpStatus(HttpOkStatus), &round{})
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+		var httpError *NodeHTTPError
+		require.True(t, errors.As(err, &httpError), err)
+		require.Equal(t, http.StatusBadRequest, httpError.StatusCode)
+	})
+
+	t.Run("Consensus not reached should unwrap to the timeout most nodes failed with", func(t *testing.T) {
+		t.Parallel()
+
+		z, _ := newTestZerochain(t, []string{slow, slow, ok}, []string{ok})
+		z.NodeTimeout = 100 * time.Millisecond
+		resp, err := z.GetFromMiners(context.Background(), "/v1/x", ConsensusByHttpStatus(HttpOkStatus), &round{})
+		require.Nil(t, resp)
+
+		var consensusError *ConsensusNotReachedError
+		require.True(t, errors.As(err, &consensusError), err)
+		require.Len(t, consensusError.Outcomes, 3)
+		require.True(t, consensusError.Outcomes[0].TimedOut)
+		require.True(t, consensusError.Outcomes[1].TimedOut)
+		require.False(t, consensusError.Outcomes[2].TimedOut)
+		require.True(t, isTimeout(consensusError.Unwrap()), consensusError.Unwrap())
+		require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+
+		var decodeError *DecodeError
+		require.False(t, errors.As(err, &decodeError), err)
+	})
+
+	t.Run("Consensus not reached should unwrap to the DecodeError most nodes failed with", func(t *testing.T) {
+		t.Parallel()
+
+		z, _ := newTestZerochain(t, []string{notJSON, notJSON, slow}, []string{ok})
+		z.NodeTimeout = 100 * time.Millisecond
+		_, err := z.GetFromMiners(context.Background(), "/v1/x", ConsensusByHttpStatus(HttpOkStatus), &round{})
+
+		var consensusError *ConsensusNotReachedError
+		require.True(t, errors.As(err, &consensusError), err)
+		var decodeError *DecodeError
+		require.True(t, errors.As(err, &decodeError), err)
+		require.Equal(t, notJSON, decodeError.Node)
+		require.False(t, errors.Is(err, context.DeadlineExceeded), err)
+	})
+}
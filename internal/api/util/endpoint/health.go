@@ -0,0 +1,285 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Defaults of the background health tracking of Zerochain
+const (
+	DefaultHealthCheckInterval = 30 * time.Second
+	DefaultDiscoveryInterval   = 5 * time.Minute
+	DefaultUnhealthyAfter      = 3
+	DefaultHealthyAfter        = 1
+)
+
+// Types of nodes tracked by Zerochain
+const (
+	MinerNodeType   = "miner"
+	SharderNodeType = "sharder"
+)
+
+const healthCheckEndpoint = "/v1/chain/get/stats"
+
+// NodeStatus is the health of a node as last seen by Zerochain
+type NodeStatus struct {
+	Node                string    `json:"node"`
+	Type                string    `json:"type"`
+	Healthy             bool      `json:"healthy"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+	LastChecked         time.Time `json:"last_checked"`
+}
+
+// network is the body returned by the network entrypoint of 0dns
+type network struct {
+	Miners   []string `json:"miners"`
+	Sharders []string `json:"sharders"`
+}
+
+// healthTracker holds the health of every known node by nodeKey, nodes keep the order 0dns returned them in
+type healthTracker struct {
+	mu        sync.RWMutex
+	nodes     map[string]*NodeStatus
+	miners    []string
+	sharders  []string
+	successes map[string]int
+	stop      context.CancelFunc
+}
+
+// Nodes returns the health of every node known to Zerochain, miners first
+func (z *Zerochain) Nodes() []NodeStatus {
+	z.health.mu.RLock()
+	defer z.health.mu.RUnlock()
+
+	statuses := make([]NodeStatus, 0, len(z.health.nodes))
+	for _, node := range z.health.miners {
+		statuses = append(statuses, *z.health.nodes[nodeKey(MinerNodeType, node)])
+	}
+	for _, node := range z.health.sharders {
+		statuses = append(statuses, *z.health.nodes[nodeKey(SharderNodeType, node)])
+	}
+
+	return statuses
+}
+
+// StopHealthTracking stops the background probing and discovery started by Init
+func (z *Zerochain) StopHealthTracking() {
+	z.health.mu.Lock()
+	defer z.health.mu.Unlock()
+
+	if z.health.stop != nil {
+		z.health.stop()
+		z.health.stop = nil
+	}
+}
+
+func (z *Zerochain) startHealthTracking() {
+	if z.HealthCheckInterval < 0 && z.DiscoveryInterval < 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	z.health.mu.Lock()
+	z.health.stop = cancel
+	z.health.mu.Unlock()
+
+	go z.trackHealth(ctx)
+}
+
+func (z *Zerochain) trackHealth(ctx context.Context) {
+	var healthChecks, discoveries <-chan time.Time
+	if z.HealthCheckInterval > 0 {
+		ticker := time.NewTicker(z.HealthCheckInterval)
+		defer ticker.Stop()
+		healthChecks = ticker.C
+	}
+	if z.DiscoveryInterval > 0 {
+		ticker := time.NewTicker(z.DiscoveryInterval)
+		defer ticker.Stop()
+		discoveries = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-healthChecks:
+			z.probeNodes(ctx)
+		case <-discoveries:
+			if err := z.discoverNodes(ctx); err != nil {
+				logf(ctx, "0dns call failed!: encountered error [%v], keeping the known nodes", err)
+				continue
+			}
+			z.probeNodes(ctx)
+		}
+	}
+}
+
+// discoverNodes queries the network entrypoint and tracks the nodes it returns, forgetting the ones it no longer does.
+// An error status or a network without miners or sharders is an error which leaves the known nodes as they are.
+func (z *Zerochain) discoverNodes(ctx context.Context) error {
+	resp, err := z.restClient.R().SetContext(ctx).Get(z.networkEntrypoint)
+	if err != nil {
+		return err
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("network entrypoint responded with HTTP [%s] and body [%s]", resp.Status(), resp.String())
+	}
+
+	var discovered network
+	if err := json.Unmarshal(resp.Body(), &discovered); err != nil {
+		return fmt.Errorf("%w when trying to serialize body [%s]", err, resp.String())
+	}
+	if len(discovered.Miners) == 0 || len(discovered.Sharders) == 0 {
+		return fmt.Errorf("network entrypoint returned [%d] miners and [%d] sharders in body [%s]", len(discovered.Miners), len(discovered.Sharders), resp.String())
+	}
+
+	z.health.mu.Lock()
+	defer z.health.mu.Unlock()
+
+	if z.health.nodes == nil {
+		z.health.nodes = make(map[string]*NodeStatus)
+		z.health.successes = make(map[string]int)
+	}
+
+	known := make(map[string]bool)
+	for nodeType, nodes := range map[string][]string{MinerNodeType: discovered.Miners, SharderNodeType: discovered.Sharders} {
+		for _, node := range nodes {
+			key := nodeKey(nodeType, node)
+			known[key] = true
+			if _, ok := z.health.nodes[key]; !ok {
+				z.health.nodes[key] = &NodeStatus{Node: node, Type: nodeType}
+			}
+		}
+	}
+
+	for key, status := range z.health.nodes {
+		if !known[key] {
+			logf(ctx, "%s %s is no longer part of the network!", status.Type, status.Node)
+			delete(z.health.nodes, key)
+			delete(z.health.successes, key)
+		}
+	}
+
+	z.health.miners = discovered.Miners
+	z.health.sharders = discovered.Sharders
+	z.refreshHealthyNodes()
+
+	return nil
+}
+
+// probeNodes calls the health check endpoint of every known node concurrently
+func (z *Zerochain) probeNodes(ctx context.Context) {
+	z.health.mu.RLock()
+	nodes := make([]NodeStatus, 0, len(z.health.nodes))
+	for _, status := range z.health.nodes {
+		nodes = append(nodes, *status)
+	}
+	z.health.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, status := range nodes {
+		wg.Add(1)
+		go func(nodeType, node string) {
+			defer wg.Done()
+
+			probeCtx, cancel := context.WithTimeout(ctx, z.NodeTimeout)
+			defer cancel()
+
+			resp, err := z.restClient.R().SetContext(probeCtx).Get(node + healthCheckEndpoint)
+			if err == nil && resp.IsError() {
+				err = newNodeHTTPError(node, healthCheckEndpoint, resp)
+			}
+			if ctx.Err() == nil {
+				z.recordHealth(ctx, nodeType, node, err, true)
+			}
+		}(status.Type, status.Node)
+	}
+	wg.Wait()
+}
+
+// recordHealth counts a successful or failed call to node, probes are the only successes that re-admit an unhealthy node.
+// A newly discovered node is admitted if its first probe succeeds, so HealthyAfter only holds back nodes which failed.
+func (z *Zerochain) recordHealth(ctx context.Context, nodeType, node string, err error, probe bool) {
+	z.health.mu.Lock()
+	defer z.health.mu.Unlock()
+
+	key := nodeKey(nodeType, node)
+	status, ok := z.health.nodes[key]
+	if !ok {
+		return
+	}
+
+	wasHealthy := status.Healthy
+	discovered := status.LastChecked.IsZero()
+	status.LastChecked = time.Now()
+
+	if err != nil {
+		status.ConsecutiveFailures++
+		status.LastError = err.Error()
+		z.health.successes[key] = 0
+		if status.Healthy && status.ConsecutiveFailures >= z.UnhealthyAfter {
+			status.Healthy = false
+		}
+	} else {
+		status.ConsecutiveFailures = 0
+		status.LastError = ""
+		if probe {
+			z.health.successes[key]++
+			if !status.Healthy && (discovered || z.health.successes[key] >= z.HealthyAfter) {
+				status.Healthy = true
+			}
+		}
+	}
+
+	if wasHealthy != status.Healthy {
+		if status.Healthy {
+			logf(ctx, "%s %s is UP!", nodeType, node)
+		} else {
+			logf(ctx, "%s %s is DOWN!", nodeType, node)
+		}
+		z.refreshHealthyNodes()
+	}
+}
+
+// refreshHealthyNodes updates Miners and Sharders from the tracked health, the caller must hold the lock
+func (z *Zerochain) refreshHealthyNodes() {
+	z.Miners = z.healthyOf(MinerNodeType, z.health.miners)
+	z.Sharders = z.healthyOf(SharderNodeType, z.health.sharders)
+}
+
+func (z *Zerochain) healthyOf(nodeType string, nodes []string) []string {
+	healthy := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if status, ok := z.health.nodes[nodeKey(nodeType, node)]; ok && status.Healthy {
+			healthy = append(healthy, node)
+		}
+	}
+
+	return healthy
+}
+
+// miners returns the healthy miners
+func (z *Zerochain) miners() []string {
+	z.health.mu.RLock()
+	defer z.health.mu.RUnlock()
+
+	return z.Miners
+}
+
+// sharders returns the healthy sharders
+func (z *Zerochain) sharders() []string {
+	z.health.mu.RLock()
+	defer z.health.mu.RUnlock()
+
+	return z.Sharders
+}
+
+func nodeKey(nodeType, node string) string {
+	return nodeType + " " + node
+}
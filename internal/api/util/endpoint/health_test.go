@@ -0,0 +1,243 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testDNS is a network entrypoint whose answer can be changed while a test runs
+type testDNS struct {
+	mu     sync.Mutex
+	status int
+	body   string
+}
+
+func (d *testDNS) answer(status int, body string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.status, d.body = status, body
+}
+
+func (d *testDNS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(d.status)
+	_, _ = w.Write([]byte(d.body))
+}
+
+// newTestNode serves the health check endpoint and answers every other call with handler
+func newTestNode(t *testing.T, handler http.HandlerFunc) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == healthCheckEndpoint {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+// newTestZerochain initialises a Zerochain against a 0dns of miners and sharders, without background health tracking
+func newTestZerochain(t *testing.T, miners, sharders []string) (*Zerochain, *testDNS) {
+	dns := &testDNS{}
+	dns.answer(http.StatusOK, networkBody(miners, sharders))
+	server := httptest.NewServer(dns)
+	t.Cleanup(server.Close)
+
+	z := &Zerochain{HealthCheckInterval: -1, DiscoveryInterval: -1}
+	z.Init(server.URL)
+
+	return z, dns
+}
+
+func networkBody(miners, sharders []string) string {
+	body, _ := json.Marshal(network{Miners: miners, Sharders: sharders})
+	return string(body)
+}
+
+func TestDiscoverNodes(t *testing.T) {
+	t.Parallel()
+
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	miners := []string{newTestNode(t, ok), newTestNode(t, ok)}
+	sharders := []string{newTestNode(t, ok), newTestNode(t, ok)}
+
+	for _, test := range []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "an error status with a JSON body", status: http.StatusInternalServerError, body: `{"miners":[],"sharders":[]}`},
+		{name: "an error status with the nodes", status: http.StatusServiceUnavailable, body: networkBody(miners[:1], sharders[:1])},
+		{name: "an empty network", status: http.StatusOK, body: `{}`},
+		{name: "a network without sharders", status: http.StatusOK, body: networkBody(miners, nil)},
+		{name: "a body which is not JSON", status: http.StatusOK, body: `<html></html>`},
+	} {
+		test := test
+		t.Run("Discovery should keep the known nodes given "+test.name, func(t *testing.T) {
+			t.Parallel()
+
+			z, dns := newTestZerochain(t, miners, sharders)
+			known := z.Nodes()
+
+			dns.answer(test.status, test.body)
+			require.NotNil(t, z.discoverNodes(context.Background()))
+			require.Equal(t, known, z.Nodes())
+			require.Equal(t, miners, z.miners())
+			require.Equal(t, sharders, z.sharders())
+		})
+	}
+
+	t.Run("Discovery should forget the nodes which are no longer part of the network", func(t *testing.T) {
+		t.Parallel()
+
+		z, dns := newTestZerochain(t, miners, sharders)
+
+		dns.answer(http.StatusOK, networkBody(miners, sharders[1:]))
+		require.Nil(t, z.discoverNodes(context.Background()))
+		require.Len(t, z.Nodes(), 3)
+		require.Equal(t, miners, z.miners())
+		require.Equal(t, sharders[1:], z.sharders())
+	})
+}
+
+// testHealth is the health check endpoint of a node which can go down and come back while a test runs
+type testHealth struct {
+	mu   sync.Mutex
+	down bool
+}
+
+func (h *testHealth) set(down bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.down = down
+}
+
+func (h *testHealth) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func newTestHealthNode(t *testing.T, health *testHealth) string {
+	server := httptest.NewServer(health)
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestNodeHealth(t *testing.T) {
+	t.Parallel()
+
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+
+	t.Run("Init should admit the nodes which pass the bootstrap probe whatever HealthyAfter is", func(t *testing.T) {
+		t.Parallel()
+
+		dns := &testDNS{}
+		miners := []string{newTestNode(t, ok), newTestHealthNode(t, &testHealth{down: true})}
+		sharders := []string{newTestNode(t, ok)}
+		dns.answer(http.StatusOK, networkBody(miners, sharders))
+		server := httptest.NewServer(dns)
+		t.Cleanup(server.Close)
+
+		z := &Zerochain{HealthCheckInterval: -1, DiscoveryInterval: -1, HealthyAfter: 3}
+		require.NotPanics(t, func() { z.Init(server.URL) })
+		require.Equal(t, miners[:1], z.miners())
+		require.Equal(t, sharders, z.sharders())
+	})
+
+	t.Run("A node should be dropped after UnhealthyAfter consecutive failures and re-admitted after HealthyAfter successes", func(t *testing.T) {
+		t.Parallel()
+
+		health := &testHealth{}
+		flaky := newTestHealthNode(t, health)
+		stable := newTestNode(t, ok)
+		z, _ := newTestZerochain(t, []string{stable, flaky}, []string{stable})
+		z.UnhealthyAfter, z.HealthyAfter = 3, 2
+		require.Equal(t, []string{stable, flaky}, z.miners())
+
+		health.set(true)
+		for failures := 1; failures < z.UnhealthyAfter; failures++ {
+			z.probeNodes(context.Background())
+			require.Equal(t, []string{stable, flaky}, z.miners(), "after %d failures", failures)
+		}
+		z.probeNodes(context.Background())
+		require.Equal(t, []string{stable}, z.miners())
+
+		health.set(false)
+		for successes := 1; successes < z.HealthyAfter; successes++ {
+			z.probeNodes(context.Background())
+			require.Equal(t, []string{stable}, z.miners(), "after %d successes", successes)
+		}
+		z.probeNodes(context.Background())
+		require.Equal(t, []string{stable, flaky}, z.miners())
+	})
+
+	t.Run("A success should reset the failures and a failure the successes counted towards a change", func(t *testing.T) {
+		t.Parallel()
+
+		health := &testHealth{}
+		flaky := newTestHealthNode(t, health)
+		stable := newTestNode(t, ok)
+		z, _ := newTestZerochain(t, []string{stable, flaky}, []string{stable})
+		z.UnhealthyAfter, z.HealthyAfter = 2, 2
+
+		for _, down := range []bool{true, false, true, false} {
+			health.set(down)
+			z.probeNodes(context.Background())
+		}
+		require.Equal(t, []string{stable, flaky}, z.miners(), "failures interrupted by successes should not drop the node")
+
+		health.set(true)
+		z.probeNodes(context.Background())
+		z.probeNodes(context.Background())
+		require.Equal(t, []string{stable}, z.miners())
+
+		for _, down := range []bool{false, true, false} {
+			health.set(down)
+			z.probeNodes(context.Background())
+		}
+		require.Equal(t, []string{stable}, z.miners(), "successes interrupted by a failure should not re-admit the node")
+
+		z.probeNodes(context.Background())
+		require.Equal(t, []string{stable, flaky}, z.miners())
+	})
+
+	t.Run("Failed calls should drop a node but successful ones should not re-admit it", func(t *testing.T) {
+		t.Parallel()
+
+		stable := newTestNode(t, ok)
+		z, _ := newTestZerochain(t, []string{stable, newTestNode(t, ok)}, []string{stable})
+		other := z.miners()[1]
+
+		for i := 0; i < z.UnhealthyAfter; i++ {
+			z.recordHealth(context.Background(), MinerNodeType, other, errors.New("call failed"), false)
+		}
+		require.Equal(t, []string{stable}, z.miners())
+
+		z.recordHealth(context.Background(), MinerNodeType, other, nil, false)
+		require.Equal(t, []string{stable}, z.miners())
+
+		z.probeNodes(context.Background())
+		require.Equal(t, []string{stable, other}, z.miners())
+	})
+}
@@ -0,0 +1,63 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger receives the log lines of Zerochain, *testing.T satisfies it
+type Logger interface {
+	Logf(format string, args ...interface{})
+}
+
+// StdLogger logs to the standard logger, it is used when a context carries no Logger
+type StdLogger struct{}
+
+func (StdLogger) Logf(format string, args ...interface{}) {
+	log.Printf(format, args...)
+}
+
+type loggerKey struct{}
+
+type logFieldsKey struct{}
+
+// WithLogger returns a context whose calls to Zerochain log to logger
+func WithLogger(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFrom returns the Logger carried by ctx or StdLogger if there is none
+func LoggerFrom(ctx context.Context) Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(Logger); ok && logger != nil {
+		return logger
+	}
+
+	return StdLogger{}
+}
+
+// WithLogFields returns a context whose log lines are prefixed with the given key value pairs, added to the ones ctx already carries
+func WithLogFields(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	fields, _ := ctx.Value(logFieldsKey{}).([]string)
+	fields = append([]string(nil), fields...)
+
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields = append(fields, fmt.Sprintf("%v=%v", keysAndValues[i], keysAndValues[i+1]))
+	}
+
+	return context.WithValue(ctx, logFieldsKey{}, fields)
+}
+
+func logf(ctx context.Context, format string, args ...interface{}) {
+	if fields, ok := ctx.Value(logFieldsKey{}).([]string); ok && len(fields) > 0 {
+		format = "[" + strings.Join(fields, " ") + "] " + format
+	}
+
+	logger := LoggerFrom(ctx)
+	if helper, ok := logger.(interface{ Helper() }); ok {
+		helper.Helper()
+	}
+
+	logger.Logf(format, args...)
+}
@@ -0,0 +1,349 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	resty "github.com/go-resty/resty/v2" //nolint
+)
+
+// Statuses of calls which did not get an HTTP response
+const (
+	StatusError   = "error"
+	StatusTimeout = "timeout"
+)
+
+// Call is a single call made to a node through Zerochain
+type Call struct {
+	NodeType string `json:"node_type"`
+	Node     string `json:"node"`
+	Method   string `json:"method"`
+	// Endpoint is the endpoint without its query and with IDs replaced, see EndpointTemplate
+	Endpoint string `json:"endpoint"`
+	// Status is the HTTP status code, StatusError or StatusTimeout when the node did not respond
+	Status       string        `json:"status"`
+	Latency      time.Duration `json:"latency"`
+	Failed       bool          `json:"failed"`
+	DecodeFailed bool          `json:"decode_failed"`
+}
+
+// Metrics summarizes the calls made through Zerochain by node
+type Metrics struct {
+	Nodes []NodeMetrics `json:"nodes"`
+}
+
+// NodeMetrics summarizes the calls made to one node, Errors counts calls without a response or with an HTTP error status
+type NodeMetrics struct {
+	NodeType     string            `json:"node_type"`
+	Node         string            `json:"node"`
+	Calls        int               `json:"calls"`
+	Errors       int               `json:"errors"`
+	Timeouts     int               `json:"timeouts"`
+	DecodeErrors int               `json:"decode_errors"`
+	ErrorRate    float64           `json:"error_rate"`
+	Latency      LatencySummary    `json:"latency"`
+	Statuses     map[string]int    `json:"statuses"`
+	Endpoints    []EndpointMetrics `json:"endpoints"`
+}
+
+// EndpointMetrics summarizes the calls made to one endpoint of a node
+type EndpointMetrics struct {
+	Method       string         `json:"method"`
+	Endpoint     string         `json:"endpoint"`
+	Calls        int            `json:"calls"`
+	Errors       int            `json:"errors"`
+	DecodeErrors int            `json:"decode_errors"`
+	ErrorRate    float64        `json:"error_rate"`
+	Latency      LatencySummary `json:"latency"`
+}
+
+// LatencySummary holds latency percentiles in seconds
+type LatencySummary struct {
+	P50 float64 `json:"p50"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+	Sum float64 `json:"sum"`
+}
+
+// metricsRecorder holds every call made through Zerochain, test runs make few enough of them to keep exact percentiles
+type metricsRecorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns every call made through Zerochain so far, in the order they completed
+func (z *Zerochain) Calls() []Call {
+	z.metrics.mu.Lock()
+	defer z.metrics.mu.Unlock()
+
+	return append([]Call(nil), z.metrics.calls...)
+}
+
+// Metrics summarizes the calls made through Zerochain so far, nodes are sorted by type and URL
+func (z *Zerochain) Metrics() Metrics {
+	return Summarize(z.Calls())
+}
+
+// recordCall records the outcome of a call to node, as seen by handleResponse
+func (z *Zerochain) recordCall(ctx context.Context, method, nodeType, node, endpoint string, resp *resty.Response, err error, decodeFailed bool) { //nolint
+	call := Call{
+		NodeType:     nodeType,
+		Node:         node,
+		Method:       method,
+		Endpoint:     EndpointTemplate(endpoint),
+		Failed:       err != nil && !decodeFailed,
+		DecodeFailed: decodeFailed,
+	}
+
+	switch {
+	case resp != nil && resp.RawResponse != nil:
+		call.Status = strconv.Itoa(resp.StatusCode())
+	case ctx.Err() != nil || isTimeout(err):
+		call.Status = StatusTimeout
+	default:
+		call.Status = StatusError
+	}
+	if resp != nil && resp.Request != nil {
+		call.Latency = resp.Time()
+	}
+
+	z.metrics.mu.Lock()
+	defer z.metrics.mu.Unlock()
+
+	z.metrics.calls = append(z.metrics.calls, call)
+}
+
+// EndpointTemplate drops the query of endpoint and replaces the IDs in its path with :id, so calls to the same endpoint are counted together
+func EndpointTemplate(endpoint string) string {
+	path, _, _ := strings.Cut(endpoint, "?")
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if isID(segment) {
+			segments[i] = ":id"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// isID reports whether segment is a hex encoded hash, as client, transaction, allocation and blobber IDs are
+func isID(segment string) bool {
+	if len(segment) < 32 {
+		return false
+	}
+	for _, r := range segment {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Summarize groups calls by node and endpoint
+func Summarize(calls []Call) Metrics {
+	byNode := make(map[string][]Call)
+	for _, call := range calls {
+		key := nodeKey(call.NodeType, call.Node)
+		byNode[key] = append(byNode[key], call)
+	}
+
+	metrics := Metrics{Nodes: make([]NodeMetrics, 0, len(byNode))}
+	for _, nodeCalls := range byNode {
+		metrics.Nodes = append(metrics.Nodes, summarizeNode(nodeCalls))
+	}
+	sort.Slice(metrics.Nodes, func(i, j int) bool {
+		return nodeKey(metrics.Nodes[i].NodeType, metrics.Nodes[i].Node) < nodeKey(metrics.Nodes[j].NodeType, metrics.Nodes[j].Node)
+	})
+
+	return metrics
+}
+
+// summarizeNode summarizes the calls to a single node, endpoints are sorted by path and method
+func summarizeNode(calls []Call) NodeMetrics {
+	node := NodeMetrics{
+		NodeType: calls[0].NodeType,
+		Node:     calls[0].Node,
+		Calls:    len(calls),
+		Latency:  summarizeLatency(calls),
+		Statuses: make(map[string]int),
+	}
+
+	byEndpoint := make(map[string][]Call)
+	for _, call := range calls {
+		node.Statuses[call.Status]++
+		if call.Failed {
+			node.Errors++
+		}
+		if call.Status == StatusTimeout {
+			node.Timeouts++
+		}
+		if call.DecodeFailed {
+			node.DecodeErrors++
+		}
+		key := call.Method + " " + call.Endpoint
+		byEndpoint[key] = append(byEndpoint[key], call)
+	}
+	node.ErrorRate = float64(node.Errors) / float64(node.Calls)
+
+	for _, endpointCalls := range byEndpoint {
+		node.Endpoints = append(node.Endpoints, summarizeEndpoint(endpointCalls))
+	}
+	sort.Slice(node.Endpoints, func(i, j int) bool {
+		if node.Endpoints[i].Endpoint != node.Endpoints[j].Endpoint {
+			return node.Endpoints[i].Endpoint < node.Endpoints[j].Endpoint
+		}
+		return node.Endpoints[i].Method < node.Endpoints[j].Method
+	})
+
+	return node
+}
+
+func summarizeEndpoint(calls []Call) EndpointMetrics {
+	endpoint := EndpointMetrics{
+		Method:   calls[0].Method,
+		Endpoint: calls[0].Endpoint,
+		Calls:    len(calls),
+		Latency:  summarizeLatency(calls),
+	}
+	for _, call := range calls {
+		if call.Failed {
+			endpoint.Errors++
+		}
+		if call.DecodeFailed {
+			endpoint.DecodeErrors++
+		}
+	}
+	endpoint.ErrorRate = float64(endpoint.Errors) / float64(endpoint.Calls)
+
+	return endpoint
+}
+
+func summarizeLatency(calls []Call) LatencySummary {
+	latencies := make([]float64, len(calls))
+	var summary LatencySummary
+	for i, call := range calls {
+		latencies[i] = call.Latency.Seconds()
+		summary.Sum += latencies[i]
+	}
+	sort.Float64s(latencies)
+
+	summary.P50 = percentile(latencies, 0.50)
+	summary.P95 = percentile(latencies, 0.95)
+	summary.P99 = percentile(latencies, 0.99)
+	if len(latencies) > 0 {
+		summary.Max = latencies[len(latencies)-1]
+	}
+
+	return summary
+}
+
+// percentile returns the nearest-rank percentile p of sorted
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+
+	return sorted[rank]
+}
+
+// WriteJSON writes the metrics as indented JSON
+func (m Metrics) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(m)
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+func (m Metrics) WritePrometheus(w io.Writer) error {
+	var b strings.Builder
+
+	writeMetricHeader(&b, "zerochain_node_requests_total", "counter", "Calls made to a node by endpoint and HTTP status, error or timeout when the node did not respond.")
+	for _, node := range m.Nodes {
+		statuses := make([]string, 0, len(node.Statuses))
+		for status := range node.Statuses {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			fmt.Fprintf(&b, "zerochain_node_requests_total{%s} %d\n", labels("node_type", node.NodeType, "node", node.Node, "status", status), node.Statuses[status])
+		}
+	}
+
+	writeMetricHeader(&b, "zerochain_endpoint_requests_total", "counter", "Calls made to an endpoint of a node.")
+	for _, node := range m.Nodes {
+		for _, endpoint := range node.Endpoints {
+			fmt.Fprintf(&b, "zerochain_endpoint_requests_total{%s} %d\n", labels("node_type", node.NodeType, "node", node.Node, "method", endpoint.Method, "endpoint", endpoint.Endpoint), endpoint.Calls)
+		}
+	}
+
+	writeMetricHeader(&b, "zerochain_endpoint_errors_total", "counter", "Calls to an endpoint of a node which got no response or an HTTP error status.")
+	for _, node := range m.Nodes {
+		for _, endpoint := range node.Endpoints {
+			fmt.Fprintf(&b, "zerochain_endpoint_errors_total{%s} %d\n", labels("node_type", node.NodeType, "node", node.Node, "method", endpoint.Method, "endpoint", endpoint.Endpoint), endpoint.Errors)
+		}
+	}
+
+	writeMetricHeader(&b, "zerochain_endpoint_decode_errors_total", "counter", "Successful calls to an endpoint of a node whose body could not be decoded.")
+	for _, node := range m.Nodes {
+		for _, endpoint := range node.Endpoints {
+			fmt.Fprintf(&b, "zerochain_endpoint_decode_errors_total{%s} %d\n", labels("node_type", node.NodeType, "node", node.Node, "method", endpoint.Method, "endpoint", endpoint.Endpoint), endpoint.DecodeErrors)
+		}
+	}
+
+	writeMetricHeader(&b, "zerochain_node_error_rate", "gauge", "Share of calls to a node which got no response or an HTTP error status.")
+	for _, node := range m.Nodes {
+		fmt.Fprintf(&b, "zerochain_node_error_rate{%s} %s\n", labels("node_type", node.NodeType, "node", node.Node), formatFloat(node.ErrorRate))
+	}
+
+	writeMetricHeader(&b, "zerochain_node_request_duration_seconds", "summary", "Latency of calls to a node.")
+	for _, node := range m.Nodes {
+		for _, quantile := range []struct {
+			name  string
+			value float64
+		}{{"0.5", node.Latency.P50}, {"0.95", node.Latency.P95}, {"0.99", node.Latency.P99}} {
+			fmt.Fprintf(&b, "zerochain_node_request_duration_seconds{%s} %s\n", labels("node_type", node.NodeType, "node", node.Node, "quantile", quantile.name), formatFloat(quantile.value))
+		}
+		fmt.Fprintf(&b, "zerochain_node_request_duration_seconds_sum{%s} %s\n", labels("node_type", node.NodeType, "node", node.Node), formatFloat(node.Latency.Sum))
+		fmt.Fprintf(&b, "zerochain_node_request_duration_seconds_count{%s} %d\n", labels("node_type", node.NodeType, "node", node.Node), node.Calls)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMetricHeader(b *strings.Builder, name, metricType, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels formats key value pairs as a Prometheus label set
+func labels(keysAndValues ...string) string {
+	pairs := make([]string, 0, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		pairs = append(pairs, keysAndValues[i]+`="`+labelValueEscaper.Replace(keysAndValues[i+1])+`"`)
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,151 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/0chain/system_test/internal/api/util/cassette"
+	resty "github.com/go-resty/resty/v2" //nolint
+)
+
+// DefaultMinConfirmation is the percentage of nodes which have to agree, as min_confirmation in zbox_config.yaml
+const DefaultMinConfirmation = 50
+
+// Quorum requires a share of nodes to return identical bodies for a call to succeed
+type Quorum struct {
+	// Percentage of nodes which have to agree, Zerochain.MinConfirmation is used when zero
+	Percentage int
+	// IgnoredFields are JSON keys dropped at any depth of a body before bodies are compared, case insensitively as in cassettes, e.g. round or timestamps
+	IgnoredFields []string
+}
+
+// QuorumIgnoring returns a quorum of Zerochain.MinConfirmation which ignores the given volatile fields
+func QuorumIgnoring(ignoredFields ...string) Quorum {
+	return Quorum{IgnoredFields: ignoredFields}
+}
+
+// bodyGroup is a set of nodes which returned the same status and normalized body
+type bodyGroup struct {
+	status   string
+	body     string
+	outcomes []*nodeOutcome
+}
+
+func (z *Zerochain) GetFromShardersWithQuorum(ctx context.Context, endpoint string, quorum Quorum, targetObject interface{}) (*resty.Response, error) { //nolint
+	getFromSharder := func(ctx context.Context, sharder string, nodeTargetObject interface{}) (*resty.Response, error) { //nolint
+		return z.GetFromSharder(ctx, sharder, endpoint, nodeTargetObject)
+	}
+	return z.executeWithQuorum(ctx, SharderNodeType, z.sharders(), getFromSharder, targetObject, quorum)
+}
+
+func (z *Zerochain) GetFromMinersWithQuorum(ctx context.Context, endpoint string, quorum Quorum, targetObject interface{}) (*resty.Response, error) { //nolint
+	getFromMiner := func(ctx context.Context, miner string, nodeTargetObject interface{}) (*resty.Response, error) { //nolint
+		return z.GetFromMiner(ctx, miner, endpoint, nodeTargetObject)
+	}
+	return z.executeWithQuorum(ctx, MinerNodeType, z.miners(), getFromMiner, targetObject, quorum)
+}
+
+// executeWithQuorum calls every node like executeWithConsensus, then groups the nodes by the bodies they returned.
+// It succeeds only when the largest group holds the quorum and no other group is as large,
+// otherwise the error lists every group and its nodes.
+func (z *Zerochain) executeWithQuorum(ctx context.Context, nodeType string, nodes []string, callNode CallNode, targetObject interface{}, quorum Quorum) (*resty.Response, error) { //nolint
+	if len(nodes) == 0 {
+		return nil, ErrNoHealthyNodes
+	}
+
+	outcomes := z.fanOut(ctx, nodeType, nodes, callNode, targetObject)
+
+	var groups []*bodyGroup
+	var failed []*nodeOutcome
+	for _, outcome := range outcomes {
+		if outcome.Response == nil || (outcome.Err != nil && !isHTTPError(outcome.Err)) {
+			failed = append(failed, outcome)
+			continue
+		}
+
+		status := outcome.Response.Status()
+		body := cassette.NormalizeJSON(string(outcome.Response.Body()), quorum.IgnoredFields)
+		group := findGroup(groups, status, body)
+		if group == nil {
+			group = &bodyGroup{status: status, body: body}
+			groups = append(groups, group)
+		}
+		group.outcomes = append(group.outcomes, outcome)
+	}
+
+	var largest *bodyGroup
+	tied := false
+	for _, group := range groups {
+		switch {
+		case largest == nil || len(group.outcomes) > len(largest.outcomes):
+			largest, tied = group, false
+		case len(group.outcomes) == len(largest.outcomes):
+			tied = true
+		}
+	}
+
+	required := z.requiredAgreement(len(nodes), quorum)
+	agreeing := 0
+	if largest != nil {
+		agreeing = len(largest.outcomes)
+	}
+
+	logf(ctx, "Quorum for operation was [%d/%d] nodes agreeing across [%d] distinct bodies, [%d] nodes failed, [%d] required", agreeing, len(nodes), len(groups), len(failed), required)
+
+	if largest == nil || agreeing < required {
+		return nil, newConsensusNotReachedError(fmt.Sprintf("quorum of [%d/%d] nodes not reached: %s", required, len(nodes), describeGroups(groups, failed)), outcomes)
+	}
+	if tied {
+		return nil, newConsensusNotReachedError(fmt.Sprintf("quorum of [%d/%d] nodes not reached, the largest groups are tied: %s", required, len(nodes), describeGroups(groups, failed)), outcomes)
+	}
+
+	return largest.outcomes[0].resolve(targetObject)
+}
+
+// requiredAgreement returns how many nodes have to agree: the percentage of the quorum of them,
+// and never less than a strict majority so that a split vote does not pass at min_confirmation 50
+func (z *Zerochain) requiredAgreement(nodes int, quorum Quorum) int {
+	percentage := quorum.Percentage
+	if percentage <= 0 {
+		percentage = z.MinConfirmation
+	}
+	if percentage <= 0 {
+		percentage = DefaultMinConfirmation
+	}
+
+	required := int(math.Ceil(float64(nodes) * float64(percentage) / 100))
+	if majority := nodes/2 + 1; required < majority {
+		required = majority
+	}
+
+	return required
+}
+
+func findGroup(groups []*bodyGroup, status, body string) *bodyGroup {
+	for _, group := range groups {
+		if group.status == status && group.body == body {
+			return group
+		}
+	}
+
+	return nil
+}
+
+func describeGroups(groups []*bodyGroup, failed []*nodeOutcome) string {
+	var description []string
+	for i, group := range groups {
+		nodes := make([]string, 0, len(group.outcomes))
+		for _, outcome := range group.outcomes {
+			nodes = append(nodes, outcome.Node)
+		}
+		description = append(description, fmt.Sprintf("group %d of [%d] nodes %v returned HTTP [%s] with body [%s]", i+1, len(nodes), nodes, group.status, group.body))
+	}
+
+	for _, outcome := range failed {
+		description = append(description, outcome.String())
+	}
+
+	return strings.Join(description, "; ")
+}
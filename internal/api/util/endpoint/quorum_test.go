@@ -0,0 +1,89 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/cassette"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteWithQuorum(t *testing.T) {
+	t.Parallel()
+
+	a := newTestNode(t, respond(http.StatusOK, `{"round":1,"hash":"a"}`))
+	b := newTestNode(t, respond(http.StatusOK, `{"round":1,"hash":"b"}`))
+	c := newTestNode(t, respond(http.StatusOK, `{"round":1,"hash":"c"}`))
+	laterA := newTestNode(t, respond(http.StatusOK, `{"hash":"a","round":2}`))
+	notFoundA := newTestNode(t, respond(http.StatusNotFound, `{"round":1,"hash":"a"}`))
+	slow := newTestNode(t, hang)
+
+	for _, test := range []struct {
+		name     string
+		miners   []string
+		quorum   Quorum
+		expected *block
+	}{
+		{name: "a quorum of identical bodies", miners: []string{a, a, b}, quorum: Quorum{Percentage: 60}, expected: &block{Round: 1, Hash: "a"}},
+		{name: "a split vote below the percentage", miners: []string{a, a, b, c}, quorum: Quorum{Percentage: 60}},
+		{name: "a split vote of distinct bodies only", miners: []string{a, b, c}, quorum: Quorum{Percentage: 30}},
+		{name: "a 1-vs-1 split at the default percentage", miners: []string{a, b}, quorum: Quorum{Percentage: DefaultMinConfirmation}},
+		{name: "a 2-vs-2 tie at the default percentage", miners: []string{a, a, b, b}, quorum: Quorum{Percentage: DefaultMinConfirmation}},
+		{name: "a tie below a percentage it would hold", miners: []string{a, a, b, b, c}, quorum: Quorum{Percentage: 30}},
+		{name: "a strict majority at the default percentage", miners: []string{a, a, b}, quorum: Quorum{Percentage: DefaultMinConfirmation}, expected: &block{Round: 1, Hash: "a"}},
+		{name: "a percentage below a strict majority", miners: []string{a, a, b, c, c, c}, quorum: Quorum{Percentage: 30}},
+		{name: "bodies which differ in a field which is not ignored", miners: []string{a, laterA}, quorum: Quorum{Percentage: 100}},
+		{name: "bodies which differ only in an ignored field", miners: []string{laterA, a}, quorum: Quorum{Percentage: 100, IgnoredFields: []string{"round"}}, expected: &block{Round: 2, Hash: "a"}},
+		{name: "identical bodies of different statuses", miners: []string{a, notFoundA}, quorum: Quorum{Percentage: 100}},
+		{name: "a node which times out, counted against the quorum", miners: []string{a, a, slow}, quorum: Quorum{Percentage: 100}},
+		{name: "a node which times out, within the quorum", miners: []string{a, a, slow}, quorum: Quorum{Percentage: 60}, expected: &block{Round: 1, Hash: "a"}},
+	} {
+		test := test
+		t.Run("Quorum should be decided given "+test.name, func(t *testing.T) {
+			t.Parallel()
+
+			z, _ := newTestZerochain(t, test.miners, []string{a})
+			z.NodeTimeout = 100 * time.Millisecond
+
+			var target block
+			resp, err := z.GetFromMinersWithQuorum(context.Background(), "/v1/x", test.quorum, &target)
+			if test.expected == nil {
+				require.Nil(t, resp)
+				var consensusError *ConsensusNotReachedError
+				require.True(t, errors.As(err, &consensusError), err)
+				require.Len(t, consensusError.Outcomes, len(test.miners))
+				require.Zero(t, target)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode())
+			require.Equal(t, *test.expected, target)
+		})
+	}
+
+	t.Run("Quorum should default to MinConfirmation", func(t *testing.T) {
+		t.Parallel()
+
+		z, _ := newTestZerochain(t, []string{a, a, a, b, c}, []string{a})
+
+		z.MinConfirmation = 50
+		_, err := z.GetFromMinersWithQuorum(context.Background(), "/v1/x", Quorum{}, &block{})
+		require.Nil(t, err)
+
+		z.MinConfirmation = 75
+		_, err = z.GetFromMinersWithQuorum(context.Background(), "/v1/x", Quorum{}, &block{})
+		require.NotNil(t, err)
+	})
+
+	t.Run("Ignored fields should be dropped at any depth", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t,
+			cassette.NormalizeJSON(`{"b":{"round":1,"x":[{"round":2,"y":1}]},"a":1}`, []string{"round"}),
+			cassette.NormalizeJSON(`{"a":1,"b":{"x":[{"y":1,"Round":3}],"round":4}}`, []string{"round"}))
+	})
+}
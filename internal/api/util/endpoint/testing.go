@@ -0,0 +1,80 @@
+package endpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/cassette"
+	resty "github.com/go-resty/resty/v2" //nolint
+)
+
+// Context returns a context which logs to t, records into the cassette named after t and is cancelled when t or its deadline ends
+func Context(t *testing.T) context.Context {
+	ctx, cancel := context.WithCancel(cassette.WithName(context.Background(), t.Name()))
+	t.Cleanup(cancel)
+
+	if deadline, ok := t.Deadline(); ok {
+		var cancelDeadline context.CancelFunc
+		ctx, cancelDeadline = context.WithDeadline(ctx, deadline)
+		t.Cleanup(cancelDeadline)
+	}
+
+	return WithLogger(ctx, t)
+}
+
+// TestZerochain adapts Zerochain to the *testing.T based signatures the API tests are written against
+type TestZerochain struct {
+	*Zerochain
+}
+
+func NewTestZerochain() TestZerochain {
+	return TestZerochain{Zerochain: &Zerochain{}}
+}
+
+func (z TestZerochain) GetFromMiners(t *testing.T, endpoint string, consensusMet ConsensusMetFunction, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.GetFromMiners(Context(t), endpoint, consensusMet, targetObject)
+}
+
+func (z TestZerochain) GetFromMiner(t *testing.T, miner, endpoint string, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.GetFromMiner(Context(t), miner, endpoint, targetObject)
+}
+
+func (z TestZerochain) PostToMiners(t *testing.T, endpoint string, consensusMet ConsensusMetFunction, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.PostToMiners(Context(t), endpoint, consensusMet, body, targetObject)
+}
+
+func (z TestZerochain) PostToMiner(t *testing.T, miner, endpoint string, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.PostToMiner(Context(t), miner, endpoint, body, targetObject)
+}
+
+func (z TestZerochain) PostToShardersWithFormData(t *testing.T, endpoint string, consensusMet ConsensusMetFunction, formData map[string]string, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.PostToShardersWithFormData(Context(t), endpoint, consensusMet, formData, body, targetObject)
+}
+
+func (z TestZerochain) PostToSharder(t *testing.T, sharder, endpoint string, formData map[string]string, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.PostToSharder(Context(t), sharder, endpoint, formData, body, targetObject)
+}
+
+func (z TestZerochain) PostToBlobber(t *testing.T, blobber, endpoint string, headers, formData map[string]string, body []byte, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.PostToBlobber(Context(t), blobber, endpoint, headers, formData, body, targetObject)
+}
+
+func (z TestZerochain) GetFromBlobber(t *testing.T, blobber, endpoint string, headers, params map[string]string, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.GetFromBlobber(Context(t), blobber, endpoint, headers, params, targetObject)
+}
+
+func (z TestZerochain) GetFromSharders(t *testing.T, endpoint string, consensusMet ConsensusMetFunction, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.GetFromSharders(Context(t), endpoint, consensusMet, targetObject)
+}
+
+func (z TestZerochain) GetFromSharder(t *testing.T, sharder, endpoint string, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.GetFromSharder(Context(t), sharder, endpoint, targetObject)
+}
+
+func (z TestZerochain) GetFromShardersWithQuorum(t *testing.T, endpoint string, quorum Quorum, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.GetFromShardersWithQuorum(Context(t), endpoint, quorum, targetObject)
+}
+
+func (z TestZerochain) GetFromMinersWithQuorum(t *testing.T, endpoint string, quorum Quorum, targetObject interface{}) (*resty.Response, error) { //nolint
+	return z.Zerochain.GetFromMinersWithQuorum(Context(t), endpoint, quorum, targetObject)
+}
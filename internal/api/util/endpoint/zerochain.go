@@ -1,19 +1,54 @@
 package endpoint
 
 import (
+	"context"
 	"encoding/json"
-	"testing"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
 
 	resty "github.com/go-resty/resty/v2" //nolint
 )
 
+// Default deadlines of a fan-out to miners or sharders
+const (
+	DefaultNodeTimeout      = 20 * time.Second
+	DefaultConsensusTimeout = time.Minute
+)
+
 type Zerochain struct {
-	Miners     []string     `json:"miners"`
-	Sharders   []string     `json:"sharders"`
-	restClient resty.Client //nolint
+	// Miners and Sharders are the healthy nodes calls fan out to, they change while health tracking runs
+	Miners   []string `json:"miners"`
+	Sharders []string `json:"sharders"`
+	// NodeTimeout bounds a call to a single node of a fan-out
+	NodeTimeout time.Duration `json:"-"`
+	// ConsensusTimeout bounds the whole fan-out, nodes still pending when it passes are reported as timeouts
+	ConsensusTimeout time.Duration `json:"-"`
+	// MinConfirmation is the default percentage of nodes which have to agree on a body, see Quorum
+	MinConfirmation int `json:"-"`
+	// HealthCheckInterval is how often every known node is probed, a negative value disables probing
+	HealthCheckInterval time.Duration `json:"-"`
+	// DiscoveryInterval is how often the network entrypoint is queried for new nodes, a negative value disables discovery
+	DiscoveryInterval time.Duration `json:"-"`
+	// UnhealthyAfter is the number of consecutive failures after which a node is no longer called
+	UnhealthyAfter int `json:"-"`
+	// HealthyAfter is the number of consecutive successful probes after which an unhealthy node is called again,
+	// newly discovered nodes are called as soon as their first probe succeeds
+	HealthyAfter int `json:"-"`
+	// Transport replaces the HTTP transport of every call when set, e.g. to record or replay them with a cassette.Recorder
+	Transport http.RoundTripper `json:"-"`
+
+	networkEntrypoint string
+	health            healthTracker
+	metrics           metricsRecorder
+	restClient        resty.Client //nolint
 }
 
-type CallNode func(node string) (*resty.Response, error) // nolint
+type CallNode func(ctx context.Context, node string, targetObject interface{}) (*resty.Response, error) // nolint
 type ConsensusMetFunction func(response *resty.Response, resolvedObject interface{}) bool
 
 func ConsensusByHttpStatus(expectedStatus string) ConsensusMetFunction {
@@ -22,268 +57,288 @@ func ConsensusByHttpStatus(expectedStatus string) ConsensusMetFunction {
 	}
 }
 
+// Init discovers the nodes of the network, keeps the healthy ones and starts tracking their health in the background
 func (z *Zerochain) Init(networkEntrypoint string) {
+	z.setDefaults()
+
 	z.restClient = *resty.New() //nolint
-	resp, err := z.restClient.R().Get(networkEntrypoint)
-	if err != nil {
+	if z.Transport != nil {
+		z.restClient.SetTransport(z.Transport)
+	}
+	z.networkEntrypoint = networkEntrypoint
+
+	ctx := context.Background()
+	if err := z.discoverNodes(ctx); err != nil {
 		panic("0dns call failed!: encountered error [" + err.Error() + "]")
 	}
+	z.probeNodes(ctx)
 
-	err = json.Unmarshal(resp.Body(), z)
-	if err != nil {
-		panic("0dns call failed!: encountered error [" + err.Error() + "] when trying to serialize body [" + resp.String() + "]")
+	if len(z.miners()) == 0 {
+		panic(fmt.Errorf("no healthy miners found: %w", ErrNoHealthyNodes))
+	}
+	if len(z.sharders()) == 0 {
+		panic(fmt.Errorf("no healthy sharders found: %w", ErrNoHealthyNodes))
 	}
 
-	healthyMiners, healthySharders := z.performHealthcheck()
-	z.Miners = healthyMiners
-	z.Sharders = healthySharders
+	z.startHealthTracking()
 }
 
-func (z *Zerochain) GetFromMiners(t *testing.T, endpoint string, consensusMet ConsensusMetFunction, targetObject interface{}) (*resty.Response, error) { //nolint
-	getFromMiner := func(miner string) (*resty.Response, error) { //nolint
-		return z.GetFromMiner(t, miner, endpoint, targetObject)
+func (z *Zerochain) setDefaults() {
+	if z.NodeTimeout <= 0 {
+		z.NodeTimeout = DefaultNodeTimeout
 	}
-	return z.executeWithConsensus(t, z.Miners, getFromMiner, targetObject, consensusMet)
-}
-
-func (z *Zerochain) GetFromMiner(t *testing.T, miner, endpoint string, targetObject interface{}) (*resty.Response, error) { //nolint
-	resp, err := z.restClient.R().Get(miner + endpoint)
-	if resp != nil && resp.IsError() {
-		t.Logf("GET on miner [" + miner + "] endpoint [" + endpoint + "] was unsuccessful, resulting in HTTP [" + resp.Status() + "] and body [" + resp.String() + "]")
-		return resp, nil
-	} else if err != nil {
-		t.Logf("GET on miner [" + miner + "] endpoint [" + endpoint + "] processed with error [" + err.Error() + "]")
-		return resp, err
-	} else {
-		t.Logf("GET on miner [" + miner + "] endpoint [" + endpoint + "] processed without error, resulting in HTTP [" + resp.Status() + "] with body [" + resp.String() + "]")
-		unmarshalError := json.Unmarshal(resp.Body(), targetObject)
-
-		if unmarshalError != nil {
-			return resp, unmarshalError
-		}
-
-		return resp, nil
+	if z.ConsensusTimeout <= 0 {
+		z.ConsensusTimeout = DefaultConsensusTimeout
 	}
-}
-
-func (z *Zerochain) PostToMiners(t *testing.T, endpoint string, consensusMet ConsensusMetFunction, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
-	postToMiner := func(miner string) (*resty.Response, error) { //nolint
-		return z.PostToMiner(t, miner, endpoint, body, targetObject)
+	if z.MinConfirmation <= 0 {
+		z.MinConfirmation = DefaultMinConfirmation
 	}
-	return z.executeWithConsensus(t, z.Miners, postToMiner, targetObject, consensusMet)
-}
-
-func (z *Zerochain) PostToMiner(t *testing.T, miner, endpoint string, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
-	resp, err := z.restClient.R().SetBody(body).Post(miner + endpoint)
-	if err != nil {
-		return nil, err
+	if z.HealthCheckInterval == 0 {
+		z.HealthCheckInterval = DefaultHealthCheckInterval
 	}
-
-	if resp.IsError() {
-		t.Log("POST on miner [" + miner + "] endpoint [" + endpoint + "] was unsuccessful, resulting in HTTP [" + resp.Status() + "] and body [" + resp.String() + "]")
-		return resp, nil
+	if z.DiscoveryInterval == 0 {
+		z.DiscoveryInterval = DefaultDiscoveryInterval
 	}
-
-	t.Log("POST on miner [" + miner + "] endpoint [" + endpoint + "] processed without error, resulting in HTTP [" + resp.Status() + "] with body [" + resp.String() + "]")
-	err = json.Unmarshal(resp.Body(), targetObject)
-	if err != nil {
-		return nil, err
+	if z.UnhealthyAfter <= 0 {
+		z.UnhealthyAfter = DefaultUnhealthyAfter
+	}
+	if z.HealthyAfter <= 0 {
+		z.HealthyAfter = DefaultHealthyAfter
 	}
-
-	return resp, nil
 }
 
-func (z *Zerochain) PostToShardersWithFormData(t *testing.T, endpoint string, consensusMet ConsensusMetFunction, formData map[string]string, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
-	postToSharder := func(sharder string) (*resty.Response, error) { //nolint
-		return z.PostToSharder(t, sharder, endpoint, formData, body, targetObject)
+func (z *Zerochain) GetFromMiners(ctx context.Context, endpoint string, consensusMet ConsensusMetFunction, targetObject interface{}) (*resty.Response, error) { //nolint
+	getFromMiner := func(ctx context.Context, miner string, nodeTargetObject interface{}) (*resty.Response, error) { //nolint
+		return z.GetFromMiner(ctx, miner, endpoint, nodeTargetObject)
 	}
-	return z.executeWithConsensus(t, z.Sharders, postToSharder, targetObject, consensusMet)
+	return z.executeWithConsensus(ctx, MinerNodeType, z.miners(), getFromMiner, targetObject, consensusMet)
 }
 
-func (z *Zerochain) PostToSharder(t *testing.T, sharder, endpoint string, formData map[string]string, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
-	resp, err := z.restClient.R().SetFormData(formData).SetBody(body).Post(sharder + endpoint)
+func (z *Zerochain) GetFromMiner(ctx context.Context, miner, endpoint string, targetObject interface{}) (*resty.Response, error) { //nolint
+	resp, err := z.restClient.R().SetContext(ctx).Get(miner + endpoint)
+	return z.handleResponse(ctx, "GET", "miner", miner, endpoint, resp, err, targetObject)
+}
 
-	if resp != nil && resp.IsError() {
-		t.Logf("POST on sharder [" + sharder + "] endpoint [" + endpoint + "] was unsuccessful, resulting in HTTP [" + resp.Status() + "] and body [" + resp.String() + "]")
-		return resp, nil
-	} else if err != nil {
-		t.Logf("POST on sharder [" + sharder + "] endpoint [" + endpoint + "] processed with error [" + err.Error() + "]")
-		return resp, err
-	} else {
-		t.Logf("POST on sharder [" + sharder + "] endpoint [" + endpoint + "] processed without error, resulting in HTTP [" + resp.Status() + "] with body [" + resp.String() + "]")
-		unmarshalError := json.Unmarshal(resp.Body(), targetObject)
+func (z *Zerochain) PostToMiners(ctx context.Context, endpoint string, consensusMet ConsensusMetFunction, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
+	postToMiner := func(ctx context.Context, miner string, nodeTargetObject interface{}) (*resty.Response, error) { //nolint
+		return z.PostToMiner(ctx, miner, endpoint, body, nodeTargetObject)
+	}
+	return z.executeWithConsensus(ctx, MinerNodeType, z.miners(), postToMiner, targetObject, consensusMet)
+}
 
-		if unmarshalError != nil {
-			return resp, unmarshalError
-		}
+func (z *Zerochain) PostToMiner(ctx context.Context, miner, endpoint string, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
+	resp, err := z.restClient.R().SetContext(ctx).SetBody(body).Post(miner + endpoint)
+	return z.handleResponse(ctx, "POST", "miner", miner, endpoint, resp, err, targetObject)
+}
 
-		return resp, nil
+func (z *Zerochain) PostToShardersWithFormData(ctx context.Context, endpoint string, consensusMet ConsensusMetFunction, formData map[string]string, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
+	postToSharder := func(ctx context.Context, sharder string, nodeTargetObject interface{}) (*resty.Response, error) { //nolint
+		return z.PostToSharder(ctx, sharder, endpoint, formData, body, nodeTargetObject)
 	}
+	return z.executeWithConsensus(ctx, SharderNodeType, z.sharders(), postToSharder, targetObject, consensusMet)
 }
 
-func (z *Zerochain) PostToBlobber(t *testing.T, blobber, endpoint string, headers, formData map[string]string, body []byte, targetObject interface{}) (*resty.Response, error) { //nolint
+func (z *Zerochain) PostToSharder(ctx context.Context, sharder, endpoint string, formData map[string]string, body interface{}, targetObject interface{}) (*resty.Response, error) { //nolint
+	resp, err := z.restClient.R().SetContext(ctx).SetFormData(formData).SetBody(body).Post(sharder + endpoint)
+	return z.handleResponse(ctx, "POST", "sharder", sharder, endpoint, resp, err, targetObject)
+}
+
+func (z *Zerochain) PostToBlobber(ctx context.Context, blobber, endpoint string, headers, formData map[string]string, body []byte, targetObject interface{}) (*resty.Response, error) { //nolint
 	resp, err := z.restClient.R().
+		SetContext(ctx).
 		SetHeaders(headers).
 		SetFormData(formData).
 		SetBody(body).
 		Post(blobber + endpoint)
-
-	if resp != nil && resp.IsError() {
-		t.Logf("POST on blobber [" + blobber + "] endpoint [" + endpoint + "] was unsuccessful, resulting in HTTP [" + resp.Status() + "] and body [" + resp.String() + "]")
-		return resp, nil
-	} else if err != nil {
-		t.Logf("POST on blobber [" + blobber + "] endpoint [" + endpoint + "] processed with error [" + err.Error() + "]")
-		return resp, err
-	} else {
-		t.Logf("POST on blobber [" + blobber + "] endpoint [" + endpoint + "] processed without error, resulting in HTTP [" + resp.Status() + "] with body [" + resp.String() + "]")
-		unmarshalError := json.Unmarshal(resp.Body(), targetObject)
-
-		if unmarshalError != nil {
-			return resp, unmarshalError
-		}
-
-		return resp, nil
-	}
+	return z.handleResponse(ctx, "POST", "blobber", blobber, endpoint, resp, err, targetObject)
 }
 
-func (z *Zerochain) GetFromBlobber(t *testing.T, blobber, endpoint string, headers, params map[string]string, targetObject interface{}) (*resty.Response, error) { //nolint
+func (z *Zerochain) GetFromBlobber(ctx context.Context, blobber, endpoint string, headers, params map[string]string, targetObject interface{}) (*resty.Response, error) { //nolint
 	resp, err := z.restClient.R().
+		SetContext(ctx).
 		SetHeaders(headers).
 		SetQueryParams(params).
 		Get(blobber + endpoint)
+	return z.handleResponse(ctx, "GET", "blobber", blobber, endpoint, resp, err, targetObject)
+}
 
-	if resp != nil && resp.IsError() {
-		t.Logf("GET on blobber [" + blobber + "] endpoint [" + endpoint + "] was unsuccessful, resulting in HTTP [" + resp.Status() + "] and body [" + resp.String() + "]")
-		return resp, nil
-	} else if err != nil {
-		t.Logf("GET on blobber [" + blobber + "] endpoint [" + endpoint + "] processed with error [" + err.Error() + "]")
-		return resp, err
-	} else {
-		t.Logf("GET on blobber [" + blobber + "] endpoint [" + endpoint + "] processed without error, resulting in HTTP [" + resp.Status() + "] with body [" + resp.String() + "]")
-		unmarshalError := json.Unmarshal(resp.Body(), targetObject)
-
-		if unmarshalError != nil {
-			return resp, unmarshalError
-		}
-
-		return resp, nil
+func (z *Zerochain) GetFromSharders(ctx context.Context, endpoint string, consensusMet ConsensusMetFunction, targetObject interface{}) (*resty.Response, error) { //nolint
+	getFromSharder := func(ctx context.Context, sharder string, nodeTargetObject interface{}) (*resty.Response, error) { //nolint
+		return z.GetFromSharder(ctx, sharder, endpoint, nodeTargetObject)
 	}
+	return z.executeWithConsensus(ctx, SharderNodeType, z.sharders(), getFromSharder, targetObject, consensusMet)
 }
 
-func (z *Zerochain) GetFromSharders(t *testing.T, endpoint string, consensusMet ConsensusMetFunction, targetObject interface{}) (*resty.Response, error) { //nolint
-	getFromSharder := func(sharder string) (*resty.Response, error) { //nolint
-		return z.GetFromSharder(t, sharder, endpoint, targetObject)
-	}
-	return z.executeWithConsensus(t, z.Sharders, getFromSharder, targetObject, consensusMet)
+func (z *Zerochain) GetFromSharder(ctx context.Context, sharder, endpoint string, targetObject interface{}) (*resty.Response, error) { //nolint
+	resp, err := z.restClient.R().SetContext(ctx).Get(sharder + endpoint)
+	return z.handleResponse(ctx, "GET", "sharder", sharder, endpoint, resp, err, targetObject)
 }
 
-func (z *Zerochain) GetFromSharder(t *testing.T, sharder string, endpoint string, targetObject interface{}) (*resty.Response, error) { //nolint
-	resp, err := z.restClient.R().Get(sharder + endpoint)
+// handleResponse logs and records the result of a call to a node and decodes a successful body into targetObject, if one is given
+func (z *Zerochain) handleResponse(ctx context.Context, method, nodeType, node, endpoint string, resp *resty.Response, err error, targetObject interface{}) (*resty.Response, error) { //nolint
+	resp, err = z.decodeResponse(ctx, method, nodeType, node, endpoint, resp, err, targetObject)
+	z.recordCall(ctx, method, nodeType, node, endpoint, resp, err, isDecodeError(err))
 
+	return resp, err
+}
+
+func (z *Zerochain) decodeResponse(ctx context.Context, method, nodeType, node, endpoint string, resp *resty.Response, err error, targetObject interface{}) (*resty.Response, error) { //nolint
 	if resp != nil && resp.IsError() {
-		t.Logf("GET on sharder [" + sharder + "] endpoint [" + endpoint + "] was unsuccessful, resulting in HTTP [" + resp.Status() + "] and body [" + resp.String() + "]")
-		return resp, nil
+		logf(ctx, "%s on %s [%s] endpoint [%s] was unsuccessful, resulting in HTTP [%s] and body [%s]", method, nodeType, node, endpoint, resp.Status(), resp.String())
+		return resp, newNodeHTTPError(node, endpoint, resp)
 	} else if err != nil {
-		t.Logf("GET on sharder [" + sharder + "] endpoint [" + endpoint + "] processed with error [" + err.Error() + "]")
+		logf(ctx, "%s on %s [%s] endpoint [%s] processed with error [%s]", method, nodeType, node, endpoint, err.Error())
 		return resp, err
-	} else {
-		if targetObject != nil {
-			t.Logf("GET on sharder [" + sharder + "] endpoint [" + endpoint + "] processed without error, resulting in HTTP [" + resp.Status() + "] with body [" + resp.String() + "]")
-			unmarshalError := json.Unmarshal(resp.Body(), targetObject)
-			if unmarshalError != nil {
-				return resp, unmarshalError
-			}
-			return resp, nil
-		}
-		return resp, nil
 	}
-}
 
-func (z *Zerochain) performHealthcheck() ([]string, []string) {
-	healthyMiners := z.getHealthyNodes(z.Miners)
-	healthySharders := z.getHealthyNodes(z.Sharders)
-
-	if len(healthyMiners) == 0 {
-		panic("No healthy miners found!")
-	}
-	if len(healthySharders) == 0 {
-		panic("No healthy sharders found!")
+	logf(ctx, "%s on %s [%s] endpoint [%s] processed without error, resulting in HTTP [%s] with body [%s]", method, nodeType, node, endpoint, resp.Status(), resp.String())
+	if targetObject == nil {
+		return resp, nil
 	}
 
-	return healthyMiners, healthySharders
-}
-
-func (z *Zerochain) getHealthyNodes(nodes []string) []string {
-	var healthyNodes []string
-	for _, node := range nodes {
-		healthResponse, err := z.restClient.R().Get(node + "/v1/chain/get/stats")
-
-		if err == nil && healthResponse.IsSuccess() {
-			println(node + " is UP!")
-			healthyNodes = append(healthyNodes, node)
-		} else {
-			println(node + " is DOWN!")
-		}
+	unmarshalError := json.Unmarshal(resp.Body(), targetObject)
+	if unmarshalError != nil {
+		return resp, &DecodeError{Node: node, Endpoint: endpoint, Body: resp.Body(), Err: unmarshalError}
 	}
 
-	return healthyNodes
+	return resp, nil
 }
 
-func (z *Zerochain) executeWithConsensus(t *testing.T, nodes []string, callNode CallNode, targetObject interface{}, consensusMet ConsensusMetFunction) (*resty.Response, error) { //nolint
-	errors := make([]error, 0)
-	responsesAsExpected := make([]*resty.Response, 0)    //nolint
-	responsesNotAsExpected := make([]*resty.Response, 0) //nolint
-
-	for _, node := range nodes {
-		httpResponse, httpError := callNode(node)
+// nodeOutcome is the outcome of a node together with the object its body was decoded into
+type nodeOutcome struct {
+	NodeOutcome
+	targetObject interface{}
+}
 
-		if httpError != nil {
-			errors = append(errors, httpError)
-		}
+// executeWithConsensus calls every node concurrently, each one bounded by NodeTimeout and all of them by ConsensusTimeout.
+// Every node decodes into its own copy of targetObject, the copy of the chosen response is written back to targetObject.
+// HTTP error statuses count as responses. The NodeHTTPError of the chosen response is returned alongside it,
+// unless consensusMet expected that status, so callers asserting an error status get no error as before.
+func (z *Zerochain) executeWithConsensus(ctx context.Context, nodeType string, nodes []string, callNode CallNode, targetObject interface{}, consensusMet ConsensusMetFunction) (*resty.Response, error) { //nolint
+	if len(nodes) == 0 {
+		return nil, ErrNoHealthyNodes
+	}
 
-		if httpResponse != nil {
-			if consensusMet == nil || consensusMet(httpResponse, targetObject) {
-				responsesAsExpected = append(responsesAsExpected, httpResponse)
+	outcomes := z.fanOut(ctx, nodeType, nodes, callNode, targetObject)
+
+	errs := make([]error, 0)
+	responsesAsExpected := make([]*nodeOutcome, 0)    //nolint
+	responsesNotAsExpected := make([]*nodeOutcome, 0) //nolint
+	timeouts := 0
+
+	for _, outcome := range outcomes {
+		switch {
+		case outcome.TimedOut:
+			timeouts++
+			errs = append(errs, outcome.Err)
+		case outcome.Err != nil && !isHTTPError(outcome.Err):
+			errs = append(errs, outcome.Err)
+		case outcome.Response != nil:
+			if consensusMet == nil || consensusMet(outcome.Response, outcome.targetObject) {
+				responsesAsExpected = append(responsesAsExpected, outcome)
 			} else {
-				responsesNotAsExpected = append(responsesNotAsExpected, httpResponse)
+				responsesNotAsExpected = append(responsesNotAsExpected, outcome)
 			}
 		}
 	}
 
-	errorSize := float64(len(errors))
+	errorSize := float64(len(errs) - timeouts)
+	timeoutSize := float64(timeouts)
 	responsesAsExpectedSize := float64(len(responsesAsExpected))
 	responsesNotAsExpectedSize := float64(len(responsesNotAsExpected))
+	total := responsesAsExpectedSize + responsesNotAsExpectedSize + errorSize + timeoutSize
 
-	t.Logf("Consensus for operation was [%.2f%%] HTTP response as expeted, [%.2f%%] HTTP response NOT as expexted, [%.2f%%] error", (float64(100)/(responsesAsExpectedSize+responsesNotAsExpectedSize+errorSize))*responsesAsExpectedSize, (float64(100)/(responsesAsExpectedSize+responsesNotAsExpectedSize+errorSize))*responsesNotAsExpectedSize, (float64(100)/(responsesAsExpectedSize+responsesNotAsExpectedSize+errorSize))*errorSize)
+	if total == 0 {
+		return nil, newConsensusNotReachedError("no node returned a response or an error", outcomes)
+	}
 
-	if errorSize > responsesAsExpectedSize+responsesNotAsExpectedSize {
-		return nil, mostDominantError(errors)
+	logf(ctx, "Consensus for operation was [%.2f%%] HTTP response as expeted, [%.2f%%] HTTP response NOT as expexted, [%.2f%%] error, [%.2f%%] timeout", (float64(100)/total)*responsesAsExpectedSize, (float64(100)/total)*responsesNotAsExpectedSize, (float64(100)/total)*errorSize, (float64(100)/total)*timeoutSize)
+
+	if errorSize+timeoutSize > responsesAsExpectedSize+responsesNotAsExpectedSize {
+		return nil, newConsensusNotReachedError(fmt.Sprintf("[%d/%d] nodes failed: %s", len(errs), len(nodes), describeOutcomes(outcomes)), outcomes)
 	}
 
 	if responsesNotAsExpectedSize > responsesAsExpectedSize {
-		return responsesNotAsExpected[0], nil
+		return responsesNotAsExpected[0].resolve(targetObject)
 	}
 
-	return responsesAsExpected[0], nil
-}
+	resp, err := responsesAsExpected[0].resolve(targetObject)
+	if consensusMet != nil && isHTTPError(err) {
+		return resp, nil
+	}
 
-func mostDominantError(errors []error) error {
-	var mostFrequent error
-	topFrequencyCount := 0
+	return resp, err
+}
 
-	for _, currentError := range errors {
-		currentFrequencyCount := 0
-		for _, compareToError := range errors {
-			if currentError == compareToError {
-				currentFrequencyCount++
+// fanOut calls every node concurrently and returns their outcomes in the order of nodes
+func (z *Zerochain) fanOut(callerCtx context.Context, nodeType string, nodes []string, callNode CallNode, targetObject interface{}) []*nodeOutcome {
+	ctx, cancel := context.WithTimeout(callerCtx, z.ConsensusTimeout)
+	defer cancel()
+
+	outcomes := make([]*nodeOutcome, len(nodes))
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node string) {
+			defer wg.Done()
+
+			nodeCtx, nodeCancel := context.WithTimeout(ctx, z.NodeTimeout)
+			defer nodeCancel()
+
+			outcome := &nodeOutcome{NodeOutcome: NodeOutcome{Node: node}, targetObject: newTargetObject(targetObject)}
+			outcome.Response, outcome.Err = callNode(nodeCtx, node, outcome.targetObject)
+			if outcome.Err != nil && (nodeCtx.Err() != nil || isTimeout(outcome.Err)) {
+				outcome.TimedOut = true
+				outcome.Err = fmt.Errorf("node [%s] did not respond in time: %w", node, outcome.Err)
 			}
-		}
+			if callerCtx.Err() == nil {
+				z.recordHealth(callerCtx, nodeType, node, outcome.failure(), false)
+			}
+			outcomes[i] = outcome
+		}(i, node)
+	}
+	wg.Wait()
 
-		if currentFrequencyCount > topFrequencyCount {
-			topFrequencyCount = currentFrequencyCount
-			mostFrequent = currentError
-		}
+	return outcomes
+}
+
+// resolve copies the object decoded for this node into targetObject and returns the response of the node
+func (o *nodeOutcome) resolve(targetObject interface{}) (*resty.Response, error) { //nolint
+	if targetObject != nil && o.targetObject != nil {
+		reflect.ValueOf(targetObject).Elem().Set(reflect.ValueOf(o.targetObject).Elem())
+	}
+
+	return o.Response, o.Err
+}
+
+// failure returns the error of a node which did not respond at all, HTTP and decode errors prove a node is alive
+func (o *nodeOutcome) failure() error {
+	if o.Err == nil || isHTTPError(o.Err) || isDecodeError(o.Err) {
+		return nil
+	}
+
+	return o.Err
+}
+
+// newTargetObject allocates a new object of the type targetObject points to
+func newTargetObject(targetObject interface{}) interface{} {
+	if targetObject == nil {
+		return nil
+	}
+
+	targetType := reflect.TypeOf(targetObject)
+	if targetType.Kind() != reflect.Ptr {
+		return targetObject
+	}
+
+	return reflect.New(targetType.Elem()).Interface()
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
 	}
 
-	return mostFrequent
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
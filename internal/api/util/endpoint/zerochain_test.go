@@ -0,0 +1,107 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func delayed(delay time.Duration, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+			next(w, r)
+		case <-r.Context().Done():
+		}
+	}
+}
+
+type block struct {
+	Round int    `json:"round"`
+	Hash  string `json:"hash"`
+}
+
+func TestExecuteWithConsensus(t *testing.T) {
+	t.Parallel()
+
+	ok := newTestNode(t, respond(http.StatusOK, `{"round":1,"hash":"a"}`))
+	slow := newTestNode(t, hang)
+
+	t.Run("A node slower than NodeTimeout should time out without failing the call", func(t *testing.T) {
+		t.Parallel()
+
+		z, _ := newTestZerochain(t, []string{slow, ok, ok}, []string{ok})
+		z.NodeTimeout = 100 * time.Millisecond
+
+		var target block
+		started := time.Now()
+		resp, err := z.GetFromMiners(context.Background(), "/v1/x", ConsensusByHttpStatus(HttpOkStatus), &target)
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Equal(t, block{Round: 1, Hash: "a"}, target)
+		require.Less(t, time.Since(started), time.Second)
+	})
+
+	t.Run("ConsensusTimeout should bound the whole fan-out", func(t *testing.T) {
+		t.Parallel()
+
+		z, _ := newTestZerochain(t, []string{slow, slow, ok}, []string{ok})
+		z.NodeTimeout = time.Minute
+		z.ConsensusTimeout = 150 * time.Millisecond
+
+		started := time.Now()
+		_, err := z.GetFromMiners(context.Background(), "/v1/x", ConsensusByHttpStatus(HttpOkStatus), &block{})
+		require.Less(t, time.Since(started), time.Second)
+
+		var consensusError *ConsensusNotReachedError
+		require.True(t, errors.As(err, &consensusError), err)
+		require.True(t, consensusError.Outcomes[0].TimedOut)
+		require.True(t, consensusError.Outcomes[1].TimedOut)
+		require.Nil(t, consensusError.Outcomes[2].Err)
+	})
+
+	t.Run("Nodes should be called concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		delay := 200 * time.Millisecond
+		node := newTestNode(t, delayed(delay, respond(http.StatusOK, `{"round":1}`)))
+		z, _ := newTestZerochain(t, []string{node, node, node, node}, []string{ok})
+
+		started := time.Now()
+		_, err := z.GetFromMiners(context.Background(), "/v1/x", ConsensusByHttpStatus(HttpOkStatus), &block{})
+		require.Nil(t, err)
+		require.Less(t, time.Since(started), 2*delay)
+	})
+
+	t.Run("Every node should decode into its own target object", func(t *testing.T) {
+		t.Parallel()
+
+		// the hash of the later node must not leak into the object of the chosen, first one
+		withoutHash := newTestNode(t, respond(http.StatusOK, `{"round":2}`))
+		withHash := newTestNode(t, respond(http.StatusOK, `{"round":3,"hash":"b"}`))
+		z, _ := newTestZerochain(t, []string{withoutHash, withHash, withHash}, []string{ok})
+
+		for i := 0; i < 10; i++ {
+			var target block
+			_, err := z.GetFromMiners(context.Background(), "/v1/x", ConsensusByHttpStatus(HttpOkStatus), &target)
+			require.Nil(t, err)
+			require.Equal(t, block{Round: 2}, target)
+		}
+	})
+
+	t.Run("The target object should be left alone when consensus is not reached", func(t *testing.T) {
+		t.Parallel()
+
+		z, _ := newTestZerochain(t, []string{slow, slow, ok}, []string{ok})
+		z.NodeTimeout = 100 * time.Millisecond
+
+		target := block{Round: 7}
+		_, err := z.GetFromMiners(context.Background(), "/v1/x", ConsensusByHttpStatus(HttpOkStatus), &target)
+		require.NotNil(t, err)
+		require.Equal(t, block{Round: 7}, target)
+	})
+}
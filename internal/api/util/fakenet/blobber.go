@@ -0,0 +1,409 @@
+package fakenet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/0chain/system_test/internal/api/blobber"
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+)
+
+// connection collects the uploads of a client until they are committed with a write marker
+type connection struct {
+	allocationID string
+	clientID     string
+	files        map[string]*upload
+}
+
+type upload struct {
+	ref   model.BlobberFileRef
+	data  []byte
+	final bool
+}
+
+type allocationStorage struct {
+	tree           *blobber.Tree
+	data           map[string][]byte
+	allocationRoot string
+	latestWM       *model.BlobberCommitConnectionWriteMarker
+	readCounters   map[string]int64
+}
+
+// mockBlobber stores the allocations of one Blobber in memory and serves them over the storage REST protocol
+type mockBlobber struct {
+	blobber *Blobber
+	ledger  *ledger
+
+	mu          sync.Mutex
+	allocations map[string]*allocationStorage
+	connections map[string]*connection
+}
+
+func newMockBlobber(blobber *Blobber, ledger *ledger) *mockBlobber {
+	return &mockBlobber{
+		blobber:     blobber,
+		ledger:      ledger,
+		allocations: make(map[string]*allocationStorage),
+		connections: make(map[string]*connection),
+	}
+}
+
+func (b *mockBlobber) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/file/upload/", b.upload)
+	mux.HandleFunc("/v1/connection/commit/", b.commit)
+	mux.HandleFunc("/v1/file/list/", b.list)
+	mux.HandleFunc("/v1/file/referencepath/", b.referencePath)
+	mux.HandleFunc("/v1/file/download/", b.download)
+
+	return mux
+}
+
+// authorize checks the X-App-Client-* headers of r and that the client owns the allocation named by the last element of the path
+func (b *mockBlobber) authorize(r *http.Request, signatureRequired bool) (allocationID, clientID, clientKey string, err error) {
+	allocationID = path.Base(r.URL.Path)
+	clientID = r.Header.Get("X-App-Client-Id")
+	clientKey = r.Header.Get("X-App-Client-Key")
+	signature := r.Header.Get("X-App-Client-Signature")
+
+	if clientID == "" || clientKey == "" {
+		return "", "", "", errors.New("missing X-App-Client-Id or X-App-Client-Key header")
+	}
+	publicKey, err := hex.DecodeString(clientKey)
+	if err != nil || crypto.Sha3256(publicKey) != clientID {
+		return "", "", "", errors.New("client id does not match the client key")
+	}
+
+	if signature == "" && signatureRequired {
+		return "", "", "", errors.New("missing X-App-Client-Signature header")
+	}
+	if signature != "" {
+		valid, err := b.ledger.signer.Verify(clientKey, signature, crypto.Sha3256([]byte(allocationID)))
+		if err != nil || !valid {
+			return "", "", "", errors.New("invalid X-App-Client-Signature")
+		}
+	}
+
+	owner, stored, err := b.ledger.allocationAccess(allocationID, b.blobber.ID)
+	if err != nil {
+		return "", "", "", fmt.Errorf("allocation [%s]: %w", allocationID, err)
+	}
+	if !stored {
+		return "", "", "", fmt.Errorf("allocation [%s] is not stored on blobber [%s]", allocationID, b.blobber.ID)
+	}
+	if owner != clientID {
+		return "", "", "", errors.New("only the owner of the allocation has access")
+	}
+
+	return allocationID, clientID, clientKey, nil
+}
+
+// storage returns the storage of an allocation, the caller must hold the lock
+func (b *mockBlobber) storage(allocationID string) *allocationStorage {
+	storage, ok := b.allocations[allocationID]
+	if !ok {
+		storage = &allocationStorage{
+			tree:         blobber.NewTree(allocationID),
+			data:         make(map[string][]byte),
+			readCounters: make(map[string]int64),
+		}
+		b.allocations[allocationID] = storage
+	}
+
+	return storage
+}
+
+// upload receives a file in one or more chunks, the file is complete once a chunk has is_final set
+func (b *mockBlobber) upload(w http.ResponseWriter, r *http.Request) {
+	allocationID, clientID, _, err := b.authorize(r, true)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "invalid_signature", err.Error())
+		return
+	}
+
+	var meta model.BlobberUploadFileMeta
+	if err := json.Unmarshal([]byte(r.FormValue("uploadMeta")), &meta); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "invalid uploadMeta: "+err.Error())
+		return
+	}
+	connectionID := r.FormValue("connection_id")
+	if connectionID == "" || meta.ConnectionID != connectionID {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "connection_id is missing or does not match uploadMeta")
+		return
+	}
+	if meta.FilePath == "" || !strings.HasPrefix(meta.FilePath, "/") {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "filepath has to be absolute")
+		return
+	}
+
+	file, _, err := r.FormFile("uploadFile")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "missing uploadFile: "+err.Error())
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", err.Error())
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	conn, ok := b.connections[connectionID]
+	if !ok {
+		conn = &connection{allocationID: allocationID, clientID: clientID, files: make(map[string]*upload)}
+		b.connections[connectionID] = conn
+	}
+	if conn.allocationID != allocationID || conn.clientID != clientID {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "connection belongs to another allocation or client")
+		return
+	}
+
+	uploaded, ok := conn.files[meta.FilePath]
+	if !ok {
+		uploaded = &upload{ref: model.BlobberFileRef{Path: meta.FilePath, MimeType: meta.MimeType, ChunkSize: blobber.BlockSize}}
+		conn.files[meta.FilePath] = uploaded
+	}
+	if uploaded.final {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "file was already uploaded completely in this connection")
+		return
+	}
+	uploaded.data = append(uploaded.data, data...)
+
+	if meta.IsFinal {
+		// the mock stores every file whole, so its shard is the one data shard gosdk hashes
+		ref := blobber.ShardRef(meta.FilePath, blobber.FileHashes(uploaded.data), 0)
+		if meta.ContentHash != ref.ContentHash {
+			delete(conn.files, meta.FilePath)
+			writeError(w, http.StatusBadRequest, "content_hash_mismatch", fmt.Sprintf("content_hash [%s] does not match the uploaded data", meta.ContentHash))
+			return
+		}
+		if meta.MerkleRoot != ref.MerkleRoot {
+			delete(conn.files, meta.FilePath)
+			writeError(w, http.StatusBadRequest, "merkle_root_mismatch", fmt.Sprintf("merkle_root [%s] does not match the uploaded data", meta.MerkleRoot))
+			return
+		}
+		if meta.ActualHash == "" || meta.ActualSize <= 0 {
+			delete(conn.files, meta.FilePath)
+			writeError(w, http.StatusBadRequest, "invalid_parameters", "actual_hash and actual_size are required")
+			return
+		}
+		ref.MimeType = meta.MimeType
+		ref.ActualFileHash = meta.ActualHash
+		ref.ActualFileSize = meta.ActualSize
+		uploaded.ref = ref
+		uploaded.final = true
+	}
+
+	writeJSON(w, &model.BlobberUploadFileResponse{
+		Filename:     meta.FileName,
+		Size:         int64(len(uploaded.data)),
+		Hash:         uploaded.ref.ContentHash,
+		UploadLength: int64(len(uploaded.data)),
+		UploadOffset: int64(len(uploaded.data) - len(data)),
+	})
+}
+
+// commit applies the uploads of a connection, the write marker has to chain onto the latest one and sign the resulting allocation root
+func (b *mockBlobber) commit(w http.ResponseWriter, r *http.Request) {
+	allocationID, clientID, clientKey, err := b.authorize(r, false)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "invalid_signature", err.Error())
+		return
+	}
+
+	var writeMarker model.BlobberCommitConnectionWriteMarker
+	if err := json.Unmarshal([]byte(r.FormValue("write_marker")), &writeMarker); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "invalid write_marker: "+err.Error())
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	connectionID := r.FormValue("connection_id")
+	conn, ok := b.connections[connectionID]
+	if !ok || conn.allocationID != allocationID || conn.clientID != clientID {
+		writeError(w, http.StatusBadRequest, "invalid_connection", "no open connection ["+connectionID+"]")
+		return
+	}
+
+	storage := b.storage(allocationID)
+	tree := storage.tree.Clone()
+	var size int64
+	for filePath, uploaded := range conn.files {
+		if !uploaded.final {
+			writeError(w, http.StatusBadRequest, "invalid_connection", "upload of ["+filePath+"] is not complete")
+			return
+		}
+		tree.Put(uploaded.ref)
+		size += uploaded.ref.Size
+	}
+	expectedRoot := blobber.AllocationRoot(tree.CalculateHash(), writeMarker.Timestamp)
+
+	switch {
+	case writeMarker.AllocationID != allocationID || writeMarker.BlobberID != b.blobber.ID || writeMarker.ClientID != clientID:
+		err = errors.New("write marker is for another allocation, blobber or client")
+	case writeMarker.PreviousAllocationRoot != storage.allocationRoot:
+		err = fmt.Errorf("prev_allocation_root [%s] does not match the latest allocation root [%s]", writeMarker.PreviousAllocationRoot, storage.allocationRoot)
+	case writeMarker.Size != size:
+		err = fmt.Errorf("size [%d] does not match the [%d] bytes uploaded in the connection", writeMarker.Size, size)
+	case writeMarker.AllocationRoot != expectedRoot:
+		err = fmt.Errorf("allocation_root [%s] does not match the calculated allocation root [%s]", writeMarker.AllocationRoot, expectedRoot)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_write_marker", err.Error())
+		return
+	}
+
+	if err := crypto.VerifyWriteMarker(&writeMarker, clientKey, b.ledger.signer.Scheme()); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_write_marker", err.Error())
+		return
+	}
+
+	for _, uploaded := range conn.files {
+		storage.data[blobber.LookupHash(allocationID, uploaded.ref.Path)] = uploaded.data
+	}
+	storage.tree = tree
+	storage.allocationRoot = writeMarker.AllocationRoot
+	storage.latestWM = &writeMarker
+	delete(b.connections, connectionID)
+
+	writeJSON(w, &model.BlobberCommitConnectionResponse{
+		AllocationRoot: writeMarker.AllocationRoot,
+		WriteMarker:    writeMarker,
+		Success:        true,
+	})
+}
+
+// list returns the ref named by path_hash, or path, with its direct children
+func (b *mockBlobber) list(w http.ResponseWriter, r *http.Request) {
+	allocationID, _, _, err := b.authorize(r, true)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "invalid_signature", err.Error())
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	storage := b.storage(allocationID)
+	lookupHash := r.FormValue("path_hash")
+	if lookupHash == "" {
+		lookupHash = blobber.LookupHash(allocationID, r.FormValue("path"))
+	}
+	found := storage.tree.Find(lookupHash)
+	if found == nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "invalid path")
+		return
+	}
+
+	listed := &model.BlobberListFilesResponse{AllocationRoot: storage.allocationRoot, Meta: found.BlobberFileRef, List: []model.BlobberFileRef{}}
+	for _, child := range found.Children {
+		listed.List = append(listed.List, child.BlobberFileRef)
+	}
+	writeJSON(w, listed)
+}
+
+// referencePath returns the whole reference tree with the latest write marker, which is what clients need to build the next one
+func (b *mockBlobber) referencePath(w http.ResponseWriter, r *http.Request) {
+	allocationID, _, _, err := b.authorize(r, true)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "invalid_signature", err.Error())
+		return
+	}
+
+	var paths []string
+	if err := json.Unmarshal([]byte(r.FormValue("paths")), &paths); err != nil || len(paths) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "paths has to be a non empty JSON array")
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	storage := b.storage(allocationID)
+	writeJSON(w, &model.BlobberGetFileReferencePathResponse{
+		BlobberReferencePath: *storage.tree.Root.ReferencePath(),
+		LatestWM:             storage.latestWM,
+	})
+}
+
+// download returns num_blocks blocks of a file starting at the 1 based block_num, the read marker has to pay for them
+func (b *mockBlobber) download(w http.ResponseWriter, r *http.Request) {
+	allocationID, clientID, clientKey, err := b.authorize(r, false)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "invalid_signature", err.Error())
+		return
+	}
+
+	var readMarker model.BlobberDownloadFileReadMarker
+	if err := json.Unmarshal([]byte(r.FormValue("read_marker")), &readMarker); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "invalid read_marker: "+err.Error())
+		return
+	}
+	blockNum, err := strconv.ParseInt(r.FormValue("block_num"), 10, 64)
+	if err != nil || blockNum < 1 {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "block_num has to be a positive number")
+		return
+	}
+	numBlocks, err := strconv.ParseInt(r.FormValue("num_blocks"), 10, 64)
+	if err != nil || numBlocks < 1 {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "num_blocks has to be a positive number")
+		return
+	}
+
+	if readMarker.AllocationID != allocationID || readMarker.BlobberID != b.blobber.ID || readMarker.ClientID != clientID || readMarker.ClientKey != clientKey {
+		writeError(w, http.StatusBadRequest, "invalid_read_marker", "read marker is for another allocation, blobber or client")
+		return
+	}
+	if err := crypto.VerifyReadMarker(&readMarker, b.ledger.signer.Scheme()); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_read_marker", err.Error())
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	storage := b.storage(allocationID)
+	if latest := storage.readCounters[clientID]; readMarker.Counter < latest+numBlocks {
+		writeError(w, http.StatusBadRequest, "stale_read_marker", fmt.Sprintf("counter [%d] has to be at least [%d]", readMarker.Counter, latest+numBlocks))
+		return
+	}
+
+	data, ok := storage.data[r.FormValue("path_hash")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "invalid path_hash")
+		return
+	}
+
+	start := (blockNum - 1) * blobber.BlockSize
+	if start >= int64(len(data)) {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "block_num is beyond the end of the file")
+		return
+	}
+	end := start + numBlocks*blobber.BlockSize
+	if end > int64(len(data)) {
+		end = int64(len(data))
+	}
+	storage.readCounters[clientID] = readMarker.Counter
+
+	writeJSON(w, &model.BlobberDownloadFileResponse{
+		Success:      true,
+		Data:         data[start:end],
+		Path:         storage.tree.Find(r.FormValue("path_hash")).Path,
+		AllocationID: allocationID,
+		LatestRM:     &readMarker,
+	})
+}
@@ -0,0 +1,345 @@
+package fakenet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/0chain/system_test/internal/api/blobber"
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/stretchr/testify/require"
+)
+
+// remotePath has no extension, so its mime type is empty in the refs the tests build themselves
+const remotePath = "/file"
+
+// blobberTest is an allocation of one owner stored on the first mock blobber of a network of its own,
+// so that its faults do not leak into other tests
+type blobberTest struct {
+	network      *Network
+	zerochain    *endpoint.Zerochain
+	blobber      blobber.Blobber
+	allocationID string
+	ownerID      string
+	ownerKeys    *model.KeyPair
+	owner        *blobber.Client
+}
+
+func newBlobberTest(t *testing.T) *blobberTest {
+	signer, err := crypto.NewSigner(crypto.ED25519)
+	require.Nil(t, err)
+
+	network := New(Options{Signer: signer})
+	t.Cleanup(network.Close)
+
+	zerochain := &endpoint.Zerochain{HealthCheckInterval: -1, DiscoveryInterval: -1}
+	zerochain.Init(network.NetworkEntrypoint())
+	t.Cleanup(zerochain.StopHealthTracking)
+
+	keys, err := signer.GenerateKeys(crypto.GenerateMnemonic(t))
+	require.Nil(t, err)
+	ownerID := crypto.ClientID(keys.PublicKey)
+
+	stored := network.Blobbers()[0]
+	allocationID := crypto.Sha3256([]byte(t.Name()))
+	network.ledger.mu.Lock()
+	network.ledger.allocations[allocationID] = &sdk.Allocation{
+		ID:       allocationID,
+		Owner:    ownerID,
+		Blobbers: []*blockchain.StorageNode{{ID: stored.ID, Baseurl: stored.URL}},
+	}
+	network.ledger.mu.Unlock()
+
+	return &blobberTest{
+		network:      network,
+		zerochain:    zerochain,
+		blobber:      blobber.Blobber{ID: stored.ID, URL: stored.URL},
+		allocationID: allocationID,
+		ownerID:      ownerID,
+		ownerKeys:    keys,
+		owner:        blobber.NewClient(zerochain, ownerID, keys),
+	}
+}
+
+// content spans more than one block, so downloads of single blocks differ from downloads of the whole file
+func content() []byte {
+	return bytes.Repeat([]byte("mock blobber "), blobber.BlockSize/8)
+}
+
+func (test *blobberTest) upload(t *testing.T) *blobber.Connection {
+	conn := test.owner.NewConnection(test.blobber, test.allocationID)
+	_, err := conn.Upload(context.Background(), remotePath, bytes.NewReader(content()))
+	require.Nil(t, err)
+
+	return conn
+}
+
+func (test *blobberTest) store(t *testing.T) {
+	_, err := test.upload(t).Commit(context.Background())
+	require.Nil(t, err)
+}
+
+func requireBlobberError(t *testing.T, err error, statusCode int, code string) {
+	var httpError *endpoint.NodeHTTPError
+	require.True(t, errors.As(err, &httpError), err)
+	require.Equal(t, statusCode, httpError.StatusCode, string(httpError.Body))
+	require.Contains(t, string(httpError.Body), `"code":"`+code+`"`)
+}
+
+func TestMockBlobber(t *testing.T) {
+	t.Parallel()
+
+	t.Run("A committed file should download with the content it was uploaded with", func(t *testing.T) {
+		t.Parallel()
+
+		test := newBlobberTest(t)
+		test.store(t)
+
+		downloaded, err := test.owner.DownloadFile(context.Background(), test.blobber, test.allocationID, test.ownerID, remotePath)
+		require.Nil(t, err)
+		require.Equal(t, content(), downloaded)
+	})
+
+	for _, header := range []struct {
+		name    string
+		headers func(t *testing.T, test *blobberTest, other *model.KeyPair) map[string]string
+	}{
+		{
+			name: "no client headers",
+			headers: func(t *testing.T, test *blobberTest, other *model.KeyPair) map[string]string {
+				return map[string]string{}
+			},
+		},
+		{
+			name: "a client id which does not match the client key",
+			headers: func(t *testing.T, test *blobberTest, other *model.KeyPair) map[string]string {
+				return map[string]string{
+					"X-App-Client-Id":        test.ownerID,
+					"X-App-Client-Key":       other.PublicKey,
+					"X-App-Client-Signature": test.sign(t, other),
+				}
+			},
+		},
+		{
+			name: "no signature",
+			headers: func(t *testing.T, test *blobberTest, other *model.KeyPair) map[string]string {
+				return map[string]string{
+					"X-App-Client-Id":  test.ownerID,
+					"X-App-Client-Key": test.ownerKeys.PublicKey,
+				}
+			},
+		},
+		{
+			name: "a signature of another key",
+			headers: func(t *testing.T, test *blobberTest, other *model.KeyPair) map[string]string {
+				return map[string]string{
+					"X-App-Client-Id":        test.ownerID,
+					"X-App-Client-Key":       test.ownerKeys.PublicKey,
+					"X-App-Client-Signature": test.sign(t, other),
+				}
+			},
+		},
+		{
+			name: "valid headers of a client which does not own the allocation",
+			headers: func(t *testing.T, test *blobberTest, other *model.KeyPair) map[string]string {
+				return map[string]string{
+					"X-App-Client-Id":        crypto.ClientID(other.PublicKey),
+					"X-App-Client-Key":       other.PublicKey,
+					"X-App-Client-Signature": test.sign(t, other),
+				}
+			},
+		},
+	} {
+		header := header
+		t.Run("List should be unauthorized given "+header.name, func(t *testing.T) {
+			t.Parallel()
+
+			test := newBlobberTest(t)
+			params := map[string]string{"path": "/", "path_hash": blobber.LookupHash(test.allocationID, "/")}
+			_, err := test.zerochain.GetFromBlobber(context.Background(), test.blobber.URL, blobber.ListEndpoint+test.allocationID,
+				header.headers(t, test, test.otherKeys(t)), params, nil)
+			requireBlobberError(t, err, http.StatusUnauthorized, "invalid_signature")
+		})
+	}
+
+	t.Run("Upload should be unauthorized for a client which does not own the allocation", func(t *testing.T) {
+		t.Parallel()
+
+		test := newBlobberTest(t)
+		other := test.otherKeys(t)
+		intruder := blobber.NewClient(test.zerochain, crypto.ClientID(other.PublicKey), other)
+		_, err := intruder.NewConnection(test.blobber, test.allocationID).Upload(context.Background(), remotePath, bytes.NewReader(content()))
+		requireBlobberError(t, err, http.StatusUnauthorized, "invalid_signature")
+	})
+
+	for _, marker := range []struct {
+		name   string
+		tamper func(t *testing.T, test *blobberTest, wm *model.BlobberCommitConnectionWriteMarker) *model.KeyPair
+		valid  bool
+	}{
+		{
+			name:  "a write marker on top of the latest allocation root",
+			valid: true,
+		},
+		{
+			name: "a write marker with the wrong prev_allocation_root",
+			tamper: func(t *testing.T, test *blobberTest, wm *model.BlobberCommitConnectionWriteMarker) *model.KeyPair {
+				wm.PreviousAllocationRoot = crypto.Sha3256([]byte("another allocation root"))
+				return test.ownerKeys
+			},
+		},
+		{
+			name: "a write marker with the wrong size",
+			tamper: func(t *testing.T, test *blobberTest, wm *model.BlobberCommitConnectionWriteMarker) *model.KeyPair {
+				wm.Size++
+				return test.ownerKeys
+			},
+		},
+		{
+			name: "a write marker signed with another key",
+			tamper: func(t *testing.T, test *blobberTest, wm *model.BlobberCommitConnectionWriteMarker) *model.KeyPair {
+				return test.otherKeys(t)
+			},
+		},
+	} {
+		marker := marker
+		verdict := "reject"
+		if marker.valid {
+			verdict = "accept"
+		}
+		t.Run("Commit should "+verdict+" "+marker.name, func(t *testing.T) {
+			t.Parallel()
+
+			test := newBlobberTest(t)
+			conn := test.upload(t)
+
+			// the allocation is empty, so the file uploaded is the whole of the tree the write marker commits to
+			ref := blobber.ShardRef(remotePath, blobber.FileHashes(content()), 0)
+			tree := blobber.NewTree(test.allocationID)
+			tree.Put(ref)
+			wm := model.BlobberCommitConnectionWriteMarker{
+				AllocationID: test.allocationID,
+				BlobberID:    test.blobber.ID,
+				ClientID:     test.ownerID,
+				Size:         ref.Size,
+				Timestamp:    time.Now().Unix(),
+			}
+			wm.AllocationRoot = blobber.AllocationRoot(tree.CalculateHash(), wm.Timestamp)
+			keys := test.ownerKeys
+			if marker.tamper != nil {
+				keys = marker.tamper(t, test, &wm)
+			}
+			require.Nil(t, crypto.SignWriteMarker(&wm, keys))
+
+			encoded, err := json.Marshal(wm)
+			require.Nil(t, err)
+			headers := map[string]string{"X-App-Client-Id": test.ownerID, "X-App-Client-Key": test.ownerKeys.PublicKey}
+			formData := map[string]string{"connection_id": conn.ID, "write_marker": string(encoded)}
+			_, err = test.zerochain.PostToBlobber(context.Background(), test.blobber.URL, blobber.CommitEndpoint+test.allocationID, headers, formData, nil, nil)
+			if marker.valid {
+				require.Nil(t, err)
+			} else {
+				requireBlobberError(t, err, http.StatusBadRequest, "invalid_write_marker")
+			}
+		})
+	}
+
+	t.Run("Download should reject a read marker whose counter does not cover the blocks read before", func(t *testing.T) {
+		t.Parallel()
+
+		test := newBlobberTest(t)
+		test.store(t)
+
+		_, err := test.owner.Download(context.Background(), test.blobber, test.allocationID, test.ownerID, remotePath, 1, 2)
+		require.Nil(t, err)
+
+		// a client of its own starts counting from zero again
+		restarted := blobber.NewClient(test.zerochain, test.ownerID, test.ownerKeys)
+		_, err = restarted.Download(context.Background(), test.blobber, test.allocationID, test.ownerID, remotePath, 1, 1)
+		requireBlobberError(t, err, http.StatusBadRequest, "stale_read_marker")
+
+		_, err = test.owner.Download(context.Background(), test.blobber, test.allocationID, test.ownerID, remotePath, 1, 1)
+		require.Nil(t, err, "the stale marker should not have been redeemed")
+	})
+}
+
+func TestMockBlobberFaults(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DownloadFile should fail the content hash check of a blobber corrupting data", func(t *testing.T) {
+		t.Parallel()
+
+		test := newBlobberTest(t)
+		test.store(t)
+		test.network.InjectFault(test.blobber.URL, CorruptData())
+
+		_, err := test.owner.DownloadFile(context.Background(), test.blobber, test.allocationID, test.ownerID, remotePath)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "does not match the one of the blobber")
+
+		test.network.ClearFaults()
+		downloaded, err := test.owner.DownloadFile(context.Background(), test.blobber, test.allocationID, test.ownerID, remotePath)
+		require.Nil(t, err)
+		require.Equal(t, content(), downloaded)
+	})
+
+	t.Run("A blobber rejecting markers should refuse commits and downloads but still list files", func(t *testing.T) {
+		t.Parallel()
+
+		test := newBlobberTest(t)
+		test.store(t)
+		test.network.InjectFault(test.blobber.URL, RejectMarkers())
+
+		_, err := test.upload(t).Commit(context.Background())
+		requireBlobberError(t, err, http.StatusBadRequest, "invalid_write_marker")
+
+		_, err = test.owner.Download(context.Background(), test.blobber, test.allocationID, test.ownerID, remotePath, 1, 1)
+		requireBlobberError(t, err, http.StatusBadRequest, "invalid_read_marker")
+
+		listed, err := test.owner.List(context.Background(), test.blobber, test.allocationID, remotePath)
+		require.Nil(t, err)
+		require.Equal(t, blobber.ContentHash(content()), listed.Meta.ContentHash)
+	})
+
+	t.Run("Calls to a slow blobber should time out with the context of the caller", func(t *testing.T) {
+		t.Parallel()
+
+		test := newBlobberTest(t)
+		test.store(t)
+		test.network.InjectFault(test.blobber.URL, Slow(5*time.Second))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		_, err := test.owner.List(ctx, test.blobber, test.allocationID, remotePath)
+		require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+
+		test.network.ClearFaults()
+		_, err = test.owner.List(context.Background(), test.blobber, test.allocationID, remotePath)
+		require.Nil(t, err)
+	})
+}
+
+// otherKeys returns keys of a client which does not own the allocation of test
+func (test *blobberTest) otherKeys(t *testing.T) *model.KeyPair {
+	keys, err := test.network.ledger.signer.GenerateKeys(crypto.GenerateMnemonic(t))
+	require.Nil(t, err)
+	require.NotEqual(t, test.ownerKeys.PublicKey, keys.PublicKey)
+
+	return keys
+}
+
+// sign signs the allocation ID of test like the X-App-Client-Signature header of keys does
+func (test *blobberTest) sign(t *testing.T, keys *model.KeyPair) string {
+	signature, err := crypto.Sign(crypto.Sha3256([]byte(test.allocationID)), keys)
+	require.Nil(t, err)
+
+	return signature
+}
@@ -0,0 +1,134 @@
+package fakenet
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault wraps the handler of a node to change how it answers, see Network.InjectFault
+type Fault func(next http.Handler) http.Handler
+
+// Unavailable answers every request with 503 Service Unavailable
+func Unavailable() Fault {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeError(w, http.StatusServiceUnavailable, "unavailable", "node is down")
+		})
+	}
+}
+
+// Slow delays every answer by delay, or until the caller gives up
+func Slow(delay time.Duration) Fault {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(delay):
+				next.ServeHTTP(w, r)
+			case <-r.Context().Done():
+			}
+		})
+	}
+}
+
+// Lie answers with whatever rewrite makes of the body the node would have answered with
+func Lie(rewrite func(r *http.Request, body []byte) []byte) Fault {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			recorder := httptest.NewRecorder()
+			next.ServeHTTP(recorder, r)
+
+			body := rewrite(r, recorder.Body.Bytes())
+			for key, values := range recorder.Header() {
+				w.Header()[key] = values
+			}
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.WriteHeader(recorder.Code)
+			_, _ = w.Write(body)
+		})
+	}
+}
+
+// LieAboutBalances adds delta to every balance the node reports
+func LieAboutBalances(delta int64) Fault {
+	return Lie(func(r *http.Request, body []byte) []byte {
+		if r.URL.Path != "/v1/client/get/balance" {
+			return body
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+
+		var balance map[string]interface{}
+		if err := decoder.Decode(&balance); err != nil {
+			return body
+		}
+		reported, err := balance["balance"].(json.Number).Int64()
+		if err != nil {
+			return body
+		}
+		balance["balance"] = reported + delta
+
+		lie, err := json.Marshal(balance)
+		if err != nil {
+			return body
+		}
+
+		return lie
+	})
+}
+
+// CorruptData flips the bits of every block a mock blobber returns from a download
+func CorruptData() Fault {
+	return Lie(func(r *http.Request, body []byte) []byte {
+		if !strings.HasPrefix(r.URL.Path, "/v1/file/download/") {
+			return body
+		}
+
+		var download map[string]interface{}
+		if err := json.Unmarshal(body, &download); err != nil {
+			return body
+		}
+		data, ok := download["data"].(string)
+		if !ok {
+			return body
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return body
+		}
+		for i := range decoded {
+			decoded[i] = ^decoded[i]
+		}
+		download["data"] = decoded
+
+		lie, err := json.Marshal(download)
+		if err != nil {
+			return body
+		}
+
+		return lie
+	})
+}
+
+// RejectMarkers makes a mock blobber refuse every write and read marker
+func RejectMarkers() Fault {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case strings.HasPrefix(r.URL.Path, "/v1/connection/commit/"):
+				writeError(w, http.StatusBadRequest, "invalid_write_marker", "write marker rejected")
+			case strings.HasPrefix(r.URL.Path, "/v1/file/download/"):
+				writeError(w, http.StatusBadRequest, "invalid_read_marker", "read marker rejected")
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
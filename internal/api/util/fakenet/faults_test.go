@@ -0,0 +1,98 @@
+package fakenet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+	"github.com/stretchr/testify/require"
+)
+
+const balance = currency.Coin(1e10)
+
+func TestFaults(t *testing.T) {
+	network := New(Options{Sharders: 3})
+	t.Cleanup(network.Close)
+
+	clientID := crypto.Sha3256([]byte(t.Name()))
+	network.ledger.mu.Lock()
+	network.ledger.clients[clientID] = &client{ID: clientID, Balance: balance, LastTxn: crypto.Sha3256([]byte("pour"))}
+	network.ledger.mu.Unlock()
+
+	zerochain := &endpoint.Zerochain{
+		NodeTimeout:         200 * time.Millisecond,
+		ConsensusTimeout:    time.Second,
+		HealthCheckInterval: -1,
+		DiscoveryInterval:   -1,
+		UnhealthyAfter:      100,
+	}
+	zerochain.Init(network.NetworkEntrypoint())
+	t.Cleanup(zerochain.StopHealthTracking)
+
+	balanceEndpoint := "/v1/client/get/balance?client_id=" + clientID
+	sharders := network.Sharders
+
+	t.Run("Quorum should hold the honest balance when one sharder lies", func(t *testing.T) {
+		network.InjectFault(sharders[0], LieAboutBalances(1))
+		t.Cleanup(network.ClearFaults)
+
+		var result model.Balance
+		_, err := zerochain.GetFromShardersWithQuorum(context.Background(), balanceEndpoint, endpoint.Quorum{}, &result)
+		require.Nil(t, err)
+		require.Equal(t, balance, result.Balance)
+	})
+
+	t.Run("Quorum should not be reached when one sharder lies and another one times out", func(t *testing.T) {
+		network.InjectFault(sharders[0], LieAboutBalances(1))
+		network.InjectFault(sharders[2], Slow(5*time.Second))
+		t.Cleanup(network.ClearFaults)
+
+		var result model.Balance
+		_, err := zerochain.GetFromShardersWithQuorum(context.Background(), balanceEndpoint, endpoint.Quorum{}, &result)
+
+		var consensusError *endpoint.ConsensusNotReachedError
+		require.True(t, errors.As(err, &consensusError), err)
+		require.Len(t, consensusError.Outcomes, 3)
+		require.False(t, consensusError.Outcomes[0].TimedOut)
+		require.Nil(t, consensusError.Outcomes[0].Err)
+		require.True(t, consensusError.Outcomes[2].TimedOut)
+		require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+		require.Zero(t, result.Balance)
+	})
+
+	t.Run("Consensus should not be reached when most sharders time out", func(t *testing.T) {
+		network.InjectFault(sharders[1], Slow(5*time.Second))
+		network.InjectFault(sharders[2], Slow(5*time.Second))
+		t.Cleanup(network.ClearFaults)
+
+		resp, err := zerochain.GetFromSharders(context.Background(), balanceEndpoint, endpoint.ConsensusByHttpStatus(endpoint.HttpOkStatus), &model.Balance{})
+		require.Nil(t, resp)
+
+		var consensusError *endpoint.ConsensusNotReachedError
+		require.True(t, errors.As(err, &consensusError), err)
+		require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+
+		var httpError *endpoint.NodeHTTPError
+		require.False(t, errors.As(err, &httpError), err)
+	})
+
+	t.Run("Consensus should return the HTTP error of most sharders when they are unavailable", func(t *testing.T) {
+		network.InjectFault(sharders[0], Unavailable())
+		network.InjectFault(sharders[1], Unavailable())
+		t.Cleanup(network.ClearFaults)
+
+		resp, err := zerochain.GetFromSharders(context.Background(), balanceEndpoint, endpoint.ConsensusByHttpStatus(endpoint.HttpOkStatus), &model.Balance{})
+		require.NotNil(t, resp)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode())
+
+		var httpError *endpoint.NodeHTTPError
+		require.True(t, errors.As(err, &httpError), err)
+		require.Equal(t, http.StatusServiceUnavailable, httpError.StatusCode)
+	})
+}
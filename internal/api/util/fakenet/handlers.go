@@ -0,0 +1,202 @@
+package fakenet
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+)
+
+func (n *Network) minerHandler(minerIDs []string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/client/put", n.clientPut)
+	mux.HandleFunc("/v1/transaction/put", n.transactionPut)
+	mux.HandleFunc("/v1/miner/get/stats", func(w http.ResponseWriter, r *http.Request) {
+		round := n.ledger.round()
+		networkTimes := make(map[string]time.Duration, len(minerIDs))
+		for _, id := range minerIDs {
+			networkTimes[id] = time.Millisecond
+		}
+
+		writeJSON(w, &model.MinerStats{
+			BlockFinality:      1,
+			LastFinalizedRound: round,
+			BlocksFinalized:    round,
+			CurrentRound:       round + 1,
+			AverageBlockSize:   1,
+			NetworkTime:        networkTimes,
+		})
+	})
+	mux.HandleFunc("/v1/chain/get/stats", n.chainStats)
+
+	return mux
+}
+
+func (n *Network) sharderHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/transaction/get/confirmation", func(w http.ResponseWriter, r *http.Request) {
+		confirmation, err := n.ledger.confirmation(r.URL.Query().Get("hash"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "entity_not_found", err.Error())
+			return
+		}
+		writeJSON(w, confirmation)
+	})
+	mux.HandleFunc("/v1/block/get", n.blockGet)
+	mux.HandleFunc("/v1/client/get/balance", func(w http.ResponseWriter, r *http.Request) {
+		balance, err := n.ledger.balance(r.URL.Query().Get("client_id"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "value_not_present", err.Error())
+			return
+		}
+		writeJSON(w, balance)
+	})
+	mux.HandleFunc("/v1/screst/", n.screst)
+	mux.HandleFunc("/v1/scstate/get", func(w http.ResponseWriter, r *http.Request) {
+		value, err := n.ledger.scStateValue(r.FormValue("sc_address"), r.FormValue("key"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "value_not_present", err.Error())
+			return
+		}
+		writeJSON(w, value)
+	})
+	mux.HandleFunc("/v1/sharder/get/stats", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, &model.SharderStats{
+			LastFinalizedRound:     n.ledger.round(),
+			AverageBlockSize:       1,
+			PrevInvocationScanTime: time.Now().Format(time.RFC3339),
+		})
+	})
+	mux.HandleFunc("/v1/chain/get/stats", n.chainStats)
+
+	return mux
+}
+
+func (n *Network) dnsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/dns/network", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string][]string{"miners": n.Miners, "sharders": n.Sharders})
+	})
+
+	return mux
+}
+
+func (n *Network) chainStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]int64{"current_round": n.ledger.round()})
+}
+
+// blockGet serves the header of a block by hash or round, the only content fake blocks have
+func (n *Network) blockGet(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("content") != "header" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "only the header content is served")
+		return
+	}
+
+	round, _ := strconv.ParseInt(query.Get("round"), 10, 64)
+	header, err := n.ledger.blockSummary(query.Get("block"), round)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "entity_not_found", err.Error())
+		return
+	}
+	writeJSON(w, &model.BlockHeader{Header: header})
+}
+
+func (n *Network) clientPut(w http.ResponseWriter, r *http.Request) {
+	var request model.ClientPutWalletRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	registered, err := n.ledger.registerClient(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	writeJSON(w, registered)
+}
+
+func (n *Network) transactionPut(w http.ResponseWriter, r *http.Request) {
+	var txn model.Transaction
+	if err := json.NewDecoder(r.Body).Decode(&txn); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	accepted, err := n.ledger.submit(&txn)
+	if err != nil {
+		code := "invalid_request"
+		if errors.Is(err, errInvalidNonce) {
+			code = "invalid_nonce"
+		}
+		writeError(w, http.StatusBadRequest, code, err.Error())
+		return
+	}
+	writeJSON(w, &model.TransactionResponse{Async: true, Entity: *accepted})
+}
+
+// screst serves the storage smart contract REST endpoints under /v1/screst/<address>/<function>
+func (n *Network) screst(w http.ResponseWriter, r *http.Request) {
+	address, function, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v1/screst/"), "/")
+	if address != endpoint.StorageSmartContractAddress {
+		writeError(w, http.StatusNotFound, "not_found", "unknown smart contract "+address)
+		return
+	}
+
+	query := r.URL.Query()
+	switch function {
+	case "allocation":
+		allocation, err := n.ledger.allocation(query.Get("allocation"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "entity_not_found", err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(allocation)
+	case "alloc_blobbers":
+		var requirements model.BlobberRequirements
+		if err := json.Unmarshal([]byte(query.Get("allocation_data")), &requirements); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+			return
+		}
+		blobbers, err := n.ledger.matchingBlobbers(requirements)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+			return
+		}
+		writeJSON(w, blobbers)
+	case "getBlobber":
+		blobber, err := n.ledger.blobber(query.Get("blobber_id"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "entity_not_found", err.Error())
+			return
+		}
+		writeJSON(w, blobber)
+	case "openchallenges":
+		blobberID := query.Get("blobber")
+		if _, err := n.ledger.blobber(blobberID); err != nil {
+			writeError(w, http.StatusBadRequest, "entity_not_found", err.Error())
+			return
+		}
+		writeJSON(w, &model.BCChallengeResponse{BlobberID: blobberID, Challenges: []*model.ChallengeEntity{}})
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "unknown function "+function)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"code": code, "error": code + ": " + message})
+}
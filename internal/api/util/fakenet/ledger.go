@@ -0,0 +1,616 @@
+package fakenet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/sdk"
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract/faucetsc"
+	"github.com/0chain/system_test/internal/api/smartcontract/multisigsc"
+	"github.com/0chain/system_test/internal/api/smartcontract/storagesc"
+	"github.com/0chain/system_test/internal/api/transaction"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+)
+
+var (
+	errNotFound     = errors.New("value not present")
+	errInvalidNonce = errors.New("invalid transaction nonce")
+)
+
+type client struct {
+	ID           string
+	PublicKey    string
+	Balance      currency.Coin
+	Nonce        int
+	CreationDate int64
+	LastTxn      string
+}
+
+type block struct {
+	Hash            string
+	PrevHash        string
+	Round           int64
+	CreationDate    int64
+	MinerID         string
+	RoundRandomSeed int64
+
+	// MerkleTreeRoot and ReceiptMerkleTreeRoot are the roots of single leaf trees, as every block holds one transaction
+	MerkleTreeRoot        string
+	ReceiptMerkleTreeRoot string
+}
+
+// ledger is the state every fake node shares, miners write to it and sharders read from it
+type ledger struct {
+	mu sync.Mutex
+
+	chainID   string
+	pourLimit currency.Coin
+	signer    crypto.Signer
+	minerIDs  []string
+
+	blocks       []*block
+	clients      map[string]*client
+	pending      map[string]map[int]*model.Transaction
+	submitted    map[string]*model.Transaction
+	confirmed    map[string]*model.Confirmation
+	allocations  map[string]*sdk.Allocation
+	blobbers     []*Blobber
+	blobbersByID map[string]*Blobber
+	scState      map[string]map[string]interface{}
+}
+
+func newLedger(chainID string, pourLimit currency.Coin, signer crypto.Signer, minerIDs []string, blobbers []*Blobber) *ledger {
+	l := &ledger{
+		chainID:      chainID,
+		pourLimit:    pourLimit,
+		signer:       signer,
+		minerIDs:     minerIDs,
+		clients:      make(map[string]*client),
+		pending:      make(map[string]map[int]*model.Transaction),
+		submitted:    make(map[string]*model.Transaction),
+		confirmed:    make(map[string]*model.Confirmation),
+		allocations:  make(map[string]*sdk.Allocation),
+		blobbers:     blobbers,
+		blobbersByID: make(map[string]*Blobber, len(blobbers)),
+		scState:      make(map[string]map[string]interface{}),
+	}
+	for _, blobber := range blobbers {
+		l.blobbersByID[blobber.ID] = blobber
+	}
+
+	genesisHash := crypto.Sha3256([]byte(chainID))
+	l.blocks = append(l.blocks, &block{Hash: genesisHash, PrevHash: genesisHash, Round: 1, CreationDate: time.Now().Unix(), MinerID: minerIDs[0]})
+
+	return l
+}
+
+func (l *ledger) round() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.latestBlock().Round
+}
+
+// latestBlock returns the last finalized block, the caller must hold the lock
+func (l *ledger) latestBlock() *block {
+	return l.blocks[len(l.blocks)-1]
+}
+
+// blockSummary returns the header of the block with hash, or of the block of round when hash is empty
+func (l *ledger) blockSummary(hash string, round int64) (*model.BlockSummary, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, b := range l.blocks {
+		if (hash != "" && b.Hash == hash) || (hash == "" && b.Round == round) {
+			numTxns := 1
+			if b.MerkleTreeRoot == "" {
+				numTxns = 0
+			}
+			return &model.BlockSummary{
+				Hash:                  b.Hash,
+				Version:               "1.0",
+				CreationDate:          b.CreationDate,
+				Round:                 b.Round,
+				RoundRandomSeed:       b.RoundRandomSeed,
+				MinerID:               b.MinerID,
+				MerkleTreeRoot:        b.MerkleTreeRoot,
+				ReceiptMerkleTreeRoot: b.ReceiptMerkleTreeRoot,
+				NumTxns:               numTxns,
+				StateChangesCount:     numTxns,
+			}, nil
+		}
+	}
+
+	return nil, errNotFound
+}
+
+func (l *ledger) registerClient(request model.ClientPutWalletRequest) (*model.ClientPutWalletResponse, error) {
+	publicKey, err := hex.DecodeString(request.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if crypto.Sha3256(publicKey) != request.Id {
+		return nil, fmt.Errorf("client id [%s] does not match the hash of its public key", request.Id)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	registered, ok := l.clients[request.Id]
+	if !ok {
+		registered = &client{ID: request.Id, PublicKey: request.PublicKey, CreationDate: time.Now().Unix()}
+		l.clients[request.Id] = registered
+	}
+
+	creationDate := int(registered.CreationDate)
+	return &model.ClientPutWalletResponse{
+		Id:           registered.ID,
+		Version:      "1.0",
+		CreationDate: &creationDate,
+		PublicKey:    registered.PublicKey,
+		Nonce:        registered.Nonce,
+	}, nil
+}
+
+// submit verifies the hash, signature and nonce of txn and executes it as soon as every lower nonce of its client was executed.
+// Submitting the same transaction again, as every miner receives it, succeeds without executing it twice.
+func (l *ledger) submit(txn *model.Transaction) (*model.Transaction, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if submitted, ok := l.submitted[txn.Hash]; ok {
+		return submitted, nil
+	}
+
+	sender, ok := l.clients[txn.ClientId]
+	if !ok {
+		return nil, fmt.Errorf("client [%s] is not registered", txn.ClientId)
+	}
+	if txn.PublicKey != "" && txn.PublicKey != sender.PublicKey {
+		return nil, errors.New("public key does not match the registered one")
+	}
+
+	expected := *txn
+	crypto.HashTransaction(&expected)
+	if expected.Hash != txn.Hash {
+		return nil, fmt.Errorf("hash [%s] does not match the computed hash [%s]", txn.Hash, expected.Hash)
+	}
+
+	valid, err := l.signer.Verify(sender.PublicKey, txn.Signature, txn.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, errors.New("invalid signature")
+	}
+
+	if txn.TransactionNonce <= sender.Nonce {
+		return nil, fmt.Errorf("%w [%d], the last executed one is [%d]", errInvalidNonce, txn.TransactionNonce, sender.Nonce)
+	}
+	if _, ok := l.pending[sender.ID][txn.TransactionNonce]; ok {
+		return nil, fmt.Errorf("%w [%d], it is already pending", errInvalidNonce, txn.TransactionNonce)
+	}
+
+	accepted := *txn
+	accepted.ChainId = l.chainID
+	l.submitted[accepted.Hash] = &accepted
+
+	if l.pending[sender.ID] == nil {
+		l.pending[sender.ID] = make(map[int]*model.Transaction)
+	}
+	l.pending[sender.ID][accepted.TransactionNonce] = &accepted
+
+	for next, ok := l.pending[sender.ID][sender.Nonce+1]; ok; next, ok = l.pending[sender.ID][sender.Nonce+1] {
+		delete(l.pending[sender.ID], next.TransactionNonce)
+		l.execute(sender, next)
+	}
+
+	return &accepted, nil
+}
+
+// execute puts txn into a block of its own, the caller must hold the lock
+func (l *ledger) execute(sender *client, txn *model.Transaction) {
+	previous := l.latestBlock()
+	round := previous.Round + 1
+	current := &block{
+		Hash:            crypto.Sha3256([]byte(fmt.Sprintf("%d:%s:%s", round, previous.Hash, txn.Hash))),
+		PrevHash:        previous.Hash,
+		Round:           round,
+		CreationDate:    time.Now().Unix(),
+		MinerID:         l.minerIDs[int(round)%len(l.minerIDs)],
+		RoundRandomSeed: round * 7919,
+	}
+	l.blocks = append(l.blocks, current)
+
+	sender.Nonce = txn.TransactionNonce
+	sender.LastTxn = txn.Hash
+
+	status := endpoint.TxSuccessfulStatus
+	output, err := l.apply(sender, txn)
+	if err != nil {
+		status = endpoint.TxUnsuccessfulStatus
+		output = err.Error()
+	}
+
+	executed := *txn
+	executed.TransactionStatus = status
+	executed.TransactionOutput = output
+	executed.TxnOutputHash = crypto.Sha3256([]byte(output))
+	// a single leaf is hashed with itself, so its path is the leaf again
+	current.MerkleTreeRoot = crypto.MerkleRoot([]string{txn.Hash})
+	current.ReceiptMerkleTreeRoot = crypto.MerkleRoot([]string{executed.TxnOutputHash})
+
+	l.confirmed[txn.Hash] = &model.Confirmation{
+		Version:               "1.0",
+		Hash:                  txn.Hash,
+		BlockHash:             current.Hash,
+		PreviousBlockHash:     current.PrevHash,
+		Transaction:           &executed,
+		CreationDate:          current.CreationDate,
+		MinerID:               current.MinerID,
+		Round:                 current.Round,
+		Status:                status,
+		RoundRandomSeed:       current.RoundRandomSeed,
+		StateChangesCount:     1,
+		MerkleTreeRoot:        current.MerkleTreeRoot,
+		MerkleTreePath:        &model.MerkleTreePath{Nodes: []string{txn.Hash}},
+		ReceiptMerkleTreeRoot: current.ReceiptMerkleTreeRoot,
+		ReceiptMerkleTreePath: &model.MerkleTreePath{Nodes: []string{executed.TxnOutputHash}},
+	}
+}
+
+// apply runs txn against the ledger and returns its output. As on the chain a failed transaction still pays its fee,
+// but moves no other tokens.
+func (l *ledger) apply(sender *client, txn *model.Transaction) (string, error) {
+	if txn.TransactionFee > sender.Balance {
+		return "", errors.New("insufficient balance to pay the fee")
+	}
+	sender.Balance -= txn.TransactionFee
+
+	if txn.TransactionType != transaction.SmartContractType {
+		return l.transfer(sender, txn.ToClientId, txn.TransactionValue)
+	}
+
+	name, input := smartContractCall(txn.TransactionData)
+	switch txn.ToClientId {
+	case endpoint.FaucetSmartContractAddress:
+		if name == faucetsc.PourFunction {
+			return l.pour(sender, txn)
+		}
+	case endpoint.StorageSmartContractAddress:
+		switch name {
+		case storagesc.NewAllocationFunction:
+			return l.newAllocation(sender, txn, input)
+		case storagesc.UpdateAllocationFunction:
+			return l.updateAllocation(sender, txn, input)
+		case storagesc.UpdateBlobberSettingsFunction:
+			return l.updateBlobber(sender, input)
+		}
+	case endpoint.MultiSigSmartContractAddress:
+		switch name {
+		case multisigsc.RegisterFunction:
+			return l.registerMultiSig(sender, input)
+		case multisigsc.VoteFunction:
+			return l.voteMultiSig(sender, input)
+		}
+	default:
+		return "", fmt.Errorf("unknown smart contract [%s]", txn.ToClientId)
+	}
+
+	return "", fmt.Errorf("unknown smart contract function [%s]", name)
+}
+
+var smartContractName = regexp.MustCompile(`"name"\s*:\s*"([^"]+)"`)
+
+// smartContractCall splits transaction data into the called function and its input,
+// the name falls back to the first one present as the faucet is called with a duplicate null name
+func smartContractCall(data string) (string, json.RawMessage) {
+	var call struct {
+		Name  string          `json:"name"`
+		Input json.RawMessage `json:"input"`
+	}
+	_ = json.Unmarshal([]byte(data), &call)
+
+	if call.Name == "" {
+		if match := smartContractName.FindStringSubmatch(data); match != nil {
+			call.Name = match[1]
+		}
+	}
+
+	return call.Name, call.Input
+}
+
+func (l *ledger) transfer(sender *client, to string, value currency.Coin) (string, error) {
+	if value > sender.Balance {
+		return "", errors.New("insufficient balance")
+	}
+
+	receiver, ok := l.clients[to]
+	if !ok {
+		receiver = &client{ID: to, CreationDate: time.Now().Unix()}
+		l.clients[to] = receiver
+	}
+	sender.Balance -= value
+	receiver.Balance += value
+
+	return fmt.Sprintf("transferred %d from %s to %s", value, sender.ID, to), nil
+}
+
+func (l *ledger) pour(sender *client, txn *model.Transaction) (string, error) {
+	amount := txn.TransactionValue
+	if amount <= 0 || amount > l.pourLimit {
+		amount = l.pourLimit
+	}
+	sender.Balance += amount
+
+	state := l.state(endpoint.FaucetSmartContractAddress)
+	usage, ok := state[sender.ID].(model.SharderSCStateResponse)
+	if !ok {
+		usage = model.SharderSCStateResponse{ID: sender.ID, StartTime: time.Now()}
+	}
+	usage.Used += amount
+	state[sender.ID] = usage
+
+	return fmt.Sprintf("%d poured to %s", amount, sender.ID), nil
+}
+
+func (l *ledger) newAllocation(sender *client, txn *model.Transaction, input json.RawMessage) (string, error) {
+	var request model.BlobberRequirements
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", fmt.Errorf("malformed request: %w", err)
+	}
+	if request.DataShards <= 0 || request.ParityShards < 0 || request.Size <= 0 {
+		return "", errors.New("invalid request: data shards, parity shards and size have to be positive")
+	}
+	if request.Blobbers == nil {
+		return "", errors.New("invalid request: no preferred blobbers")
+	}
+	if txn.TransactionValue > sender.Balance {
+		return "", errors.New("not enough tokens to honor the min lock demand")
+	}
+
+	required := int(request.DataShards + request.ParityShards)
+	sizePerBlobber := (request.Size + request.DataShards - 1) / request.DataShards
+
+	var selected []*Blobber
+	for _, id := range *request.Blobbers {
+		blobber, ok := l.blobbersByID[id]
+		if ok && blobber.matches(request, sizePerBlobber) {
+			selected = append(selected, blobber)
+		}
+		if len(selected) == required {
+			break
+		}
+	}
+	if len(selected) < required {
+		return "", fmt.Errorf("not enough blobbers to honor the allocation: [%d/%d]", len(selected), required)
+	}
+
+	allocation := &sdk.Allocation{
+		ID:              txn.Hash,
+		Tx:              txn.Hash,
+		DataShards:      int(request.DataShards),
+		ParityShards:    int(request.ParityShards),
+		Size:            request.Size,
+		Expiration:      request.ExpirationDate,
+		Owner:           sender.ID,
+		OwnerPublicKey:  request.OwnerPublicKey,
+		Payer:           sender.ID,
+		Stats:           &sdk.AllocationStats{},
+		WritePool:       common.Balance(txn.TransactionValue),
+		ReadPriceRange:  sdk.PriceRange{Min: uint64(request.ReadPriceRange.Min), Max: uint64(request.ReadPriceRange.Max)},
+		WritePriceRange: sdk.PriceRange{Min: uint64(request.WritePriceRange.Min), Max: uint64(request.WritePriceRange.Max)},
+		StartTime:       common.Timestamp(time.Now().Unix()),
+	}
+	for _, blobber := range selected {
+		blobber.Allocated += sizePerBlobber
+		allocation.Blobbers = append(allocation.Blobbers, &blockchain.StorageNode{ID: blobber.ID, Baseurl: blobber.URL})
+	}
+	sender.Balance -= txn.TransactionValue
+	l.allocations[allocation.ID] = allocation
+
+	output, err := json.Marshal(allocation)
+	return string(output), err
+}
+
+func (l *ledger) updateAllocation(sender *client, txn *model.Transaction, input json.RawMessage) (string, error) {
+	var request model.AllocationUpdate
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", fmt.Errorf("malformed request: %w", err)
+	}
+
+	allocation, ok := l.allocations[request.ID]
+	if !ok {
+		return "", fmt.Errorf("allocation [%s] not found", request.ID)
+	}
+	if allocation.Owner != sender.ID {
+		return "", errors.New("only the owner can update the allocation")
+	}
+	if request.AddBlobberId == "" && request.RemoveBlobberId == "" && request.Size == 0 && request.Expiration == 0 {
+		return "", errors.New("update allocation changes nothing")
+	}
+	if request.RemoveBlobberId != "" && request.AddBlobberId == "" {
+		return "", errors.New("a blobber can only be removed when one is added in its place")
+	}
+	if txn.TransactionValue > sender.Balance {
+		return "", errors.New("not enough tokens to update the allocation")
+	}
+
+	blobbers := allocation.Blobbers
+	if request.AddBlobberId != "" {
+		added, ok := l.blobbersByID[request.AddBlobberId]
+		if !ok {
+			return "", fmt.Errorf("blobber [%s] not found", request.AddBlobberId)
+		}
+		if indexOfBlobber(blobbers, added.ID) >= 0 {
+			return "", fmt.Errorf("blobber [%s] is already part of the allocation", added.ID)
+		}
+
+		if request.RemoveBlobberId != "" {
+			removed := indexOfBlobber(blobbers, request.RemoveBlobberId)
+			if removed < 0 {
+				return "", fmt.Errorf("blobber [%s] is not part of the allocation", request.RemoveBlobberId)
+			}
+			blobbers = append(append([]*blockchain.StorageNode{}, blobbers[:removed]...), blobbers[removed+1:]...)
+		}
+		blobbers = append(blobbers, &blockchain.StorageNode{ID: added.ID, Baseurl: added.URL})
+	}
+
+	allocation.Blobbers = blobbers
+	allocation.Size += request.Size
+	allocation.Expiration += int64(request.Expiration)
+	allocation.WritePool += common.Balance(txn.TransactionValue)
+	sender.Balance -= txn.TransactionValue
+
+	output, err := json.Marshal(allocation)
+	return string(output), err
+}
+
+func (l *ledger) updateBlobber(sender *client, input json.RawMessage) (string, error) {
+	var request model.GetBlobberResponse
+	if err := json.Unmarshal(input, &request); err != nil {
+		return "", fmt.Errorf("malformed request: %w", err)
+	}
+
+	blobber, ok := l.blobbersByID[request.ID]
+	if !ok {
+		return "", fmt.Errorf("blobber [%s] not found", request.ID)
+	}
+	if blobber.StakePoolSettings.DelegateWallet != sender.ID {
+		return "", errors.New("access denied, allowed for delegate_wallet owner only")
+	}
+
+	blobber.Terms = request.Terms
+	blobber.Capacity = request.Capacity
+	blobber.StakePoolSettings = request.StakePoolSettings
+
+	return "blobber settings updated", nil
+}
+
+func indexOfBlobber(blobbers []*blockchain.StorageNode, id string) int {
+	for i, blobber := range blobbers {
+		if blobber.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// state returns the state of a smart contract by key, the caller must hold the lock
+func (l *ledger) state(address string) map[string]interface{} {
+	state, ok := l.scState[address]
+	if !ok {
+		state = make(map[string]interface{})
+		l.scState[address] = state
+	}
+
+	return state
+}
+
+func (l *ledger) balance(clientID string) (*model.Balance, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	balanceOf, ok := l.clients[clientID]
+	if !ok || balanceOf.LastTxn == "" {
+		return nil, errNotFound
+	}
+
+	return &model.Balance{Txn: balanceOf.LastTxn, Round: l.latestBlock().Round, Balance: balanceOf.Balance, Nonce: balanceOf.Nonce}, nil
+}
+
+func (l *ledger) confirmation(hash string) (*model.Confirmation, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	confirmation, ok := l.confirmed[hash]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return confirmation, nil
+}
+
+func (l *ledger) allocation(id string) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	allocation, ok := l.allocations[id]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return json.Marshal(allocation)
+}
+
+func (l *ledger) blobber(id string) (*model.GetBlobberResponse, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	blobber, ok := l.blobbersByID[id]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return blobber.response(), nil
+}
+
+func (l *ledger) matchingBlobbers(request model.BlobberRequirements) ([]string, error) {
+	if request.DataShards <= 0 || request.ParityShards < 0 {
+		return nil, errors.New("invalid data or parity shards")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sizePerBlobber := (request.Size + request.DataShards - 1) / request.DataShards
+	var ids []string
+	for _, blobber := range l.blobbers {
+		if blobber.matches(request, sizePerBlobber) {
+			ids = append(ids, blobber.ID)
+		}
+	}
+
+	if required := int(request.DataShards + request.ParityShards); len(ids) < required {
+		return nil, fmt.Errorf("not enough blobbers to honor the allocation: [%d/%d]", len(ids), required)
+	}
+
+	return ids, nil
+}
+
+func (l *ledger) scStateValue(address, key string) (interface{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	value, ok := l.scState[address][key]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	return value, nil
+}
+
+// allocationAccess returns the owner of an allocation and whether blobberID stores it
+func (l *ledger) allocationAccess(allocationID, blobberID string) (string, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	allocation, ok := l.allocations[allocationID]
+	if !ok {
+		return "", false, errNotFound
+	}
+
+	return allocation.Owner, indexOfBlobber(allocation.Blobbers, blobberID) >= 0, nil
+}
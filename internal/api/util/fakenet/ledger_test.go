@@ -0,0 +1,111 @@
+package fakenet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract/faucetsc"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmation(t *testing.T) {
+	t.Parallel()
+
+	signer, err := crypto.NewSigner(crypto.ED25519)
+	require.Nil(t, err)
+	keys, err := signer.GenerateKeys(crypto.GenerateMnemonic(t))
+	require.Nil(t, err)
+	clientID := crypto.ClientID(keys.PublicKey)
+
+	l := newLedger(DefaultChainID, DefaultPourLimit, signer, []string{crypto.Sha3256([]byte("miner-0"))}, nil)
+	_, err = l.registerClient(model.ClientPutWalletRequest{Id: clientID, PublicKey: keys.PublicKey})
+	require.Nil(t, err)
+
+	txn := &model.Transaction{
+		Version:          "1.0",
+		ClientId:         clientID,
+		ToClientId:       crypto.Sha3256([]byte("receiver")),
+		PublicKey:        keys.PublicKey,
+		CreationDate:     time.Now().Unix(),
+		TransactionNonce: 1,
+	}
+	crypto.HashTransaction(txn)
+	txn.Signature, err = signer.Sign(txn.Hash, keys.PrivateKey)
+	require.Nil(t, err)
+	_, err = l.submit(txn)
+	require.Nil(t, err)
+
+	confirmation, err := l.confirmation(txn.Hash)
+	require.Nil(t, err)
+	block, err := l.blockSummary(confirmation.BlockHash, 0)
+	require.Nil(t, err)
+
+	t.Run("Confirmations should prove their transaction with the merkle path of a real single leaf tree", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, crypto.MerkleHash(txn.Hash, txn.Hash), confirmation.MerkleTreeRoot)
+		require.Equal(t, []string{txn.Hash}, confirmation.MerkleTreePath.Nodes)
+		require.Nil(t, crypto.VerifyConfirmation(confirmation, block))
+	})
+
+	t.Run("Confirmations should not verify against the leaf as the root", func(t *testing.T) {
+		t.Parallel()
+
+		forged := *confirmation
+		forged.MerkleTreeRoot = txn.Hash
+		forged.MerkleTreePath = &model.MerkleTreePath{Nodes: []string{}}
+		require.ErrorIs(t, crypto.VerifyConfirmation(&forged, block), crypto.ErrInvalidMerkleProof)
+	})
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	signer, err := crypto.NewSigner(crypto.ED25519)
+	require.Nil(t, err)
+	newTestLedger := func() (*ledger, *client) {
+		l := newLedger(DefaultChainID, DefaultPourLimit, signer, []string{crypto.Sha3256([]byte("miner-0"))}, nil)
+		sender := &client{ID: crypto.Sha3256([]byte("sender")), Balance: 100}
+		l.clients[sender.ID] = sender
+		return l, sender
+	}
+	receiver := crypto.Sha3256([]byte("receiver"))
+
+	t.Run("A failed transaction should pay its fee and move no other tokens", func(t *testing.T) {
+		t.Parallel()
+
+		l, sender := newTestLedger()
+		_, err := l.apply(sender, &model.Transaction{ToClientId: receiver, TransactionValue: 100, TransactionFee: 10})
+		require.NotNil(t, err)
+		require.Equal(t, currency.Coin(90), sender.Balance)
+		require.NotContains(t, l.clients, receiver)
+	})
+
+	t.Run("A transaction whose fee exceeds the balance should pay nothing", func(t *testing.T) {
+		t.Parallel()
+
+		l, sender := newTestLedger()
+		_, err := l.apply(sender, &model.Transaction{ToClientId: receiver, TransactionValue: 1, TransactionFee: 101})
+		require.NotNil(t, err)
+		require.Equal(t, currency.Coin(100), sender.Balance)
+	})
+
+	t.Run("Pours should add up exactly in the faucet usage of the client", func(t *testing.T) {
+		t.Parallel()
+
+		l, sender := newTestLedger()
+		for _, amount := range []currency.Coin{1, 3, DefaultPourLimit + 1} {
+			_, err := l.apply(sender, faucetsc.Pour(amount))
+			require.Nil(t, err)
+		}
+
+		usage, err := l.scStateValue(endpoint.FaucetSmartContractAddress, sender.ID)
+		require.Nil(t, err)
+		require.Equal(t, 4+currency.Coin(DefaultPourLimit), usage.(model.SharderSCStateResponse).Used)
+		require.Equal(t, 104+currency.Coin(DefaultPourLimit), sender.Balance)
+	})
+}
@@ -0,0 +1,122 @@
+package fakenet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0chain/system_test/internal/api/smartcontract/multisigsc"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+)
+
+// proposal collects the votes of the signers of a group wallet for one transfer, keyed by their threshold IDs
+type proposal struct {
+	Transfer multisigsc.Transfer
+	Votes    map[string]string
+	Executed bool
+}
+
+func proposalKey(walletID, proposalID string) string {
+	return walletID + ":" + proposalID
+}
+
+func (l *ledger) registerMultiSig(sender *client, input json.RawMessage) (string, error) {
+	var wallet multisigsc.Wallet
+	if err := json.Unmarshal(input, &wallet); err != nil {
+		return "", fmt.Errorf("malformed request: %w", err)
+	}
+	if wallet.ClientID != sender.ID || wallet.PublicKey != sender.PublicKey {
+		return "", errors.New("a group wallet can only be registered by itself")
+	}
+	if wallet.SignatureScheme != crypto.BLS0Chain {
+		return "", fmt.Errorf("signature scheme [%s] does not support threshold signatures", wallet.SignatureScheme)
+	}
+	if len(wallet.SignerThresholdIDs) != len(wallet.SignerPublicKeys) {
+		return "", errors.New("every signer needs a threshold id and a public key")
+	}
+	if wallet.NumRequired < 1 || wallet.NumRequired > len(wallet.SignerPublicKeys) {
+		return "", fmt.Errorf("num_required [%d] has to be between 1 and the number of signers [%d]", wallet.NumRequired, len(wallet.SignerPublicKeys))
+	}
+
+	state := l.state(endpoint.MultiSigSmartContractAddress)
+	if _, ok := state[wallet.ClientID]; ok {
+		return "", fmt.Errorf("group wallet [%s] is already registered", wallet.ClientID)
+	}
+	state[wallet.ClientID] = wallet
+
+	return fmt.Sprintf("registered group wallet %s with %d of %d signers required", wallet.ClientID, wallet.NumRequired, len(wallet.SignerPublicKeys)), nil
+}
+
+// voteMultiSig counts the vote of sender, who has to be a signer of the group wallet the transfer is from.
+// Once the proposal has num_required votes the group signature is recovered from them and the transfer executes.
+func (l *ledger) voteMultiSig(sender *client, input json.RawMessage) (string, error) {
+	var vote multisigsc.Vote
+	if err := json.Unmarshal(input, &vote); err != nil {
+		return "", fmt.Errorf("malformed request: %w", err)
+	}
+
+	state := l.state(endpoint.MultiSigSmartContractAddress)
+	wallet, ok := state[vote.Transfer.From].(multisigsc.Wallet)
+	if !ok {
+		return "", fmt.Errorf("group wallet [%s] is not registered", vote.Transfer.From)
+	}
+
+	signer := -1
+	for i, publicKey := range wallet.SignerPublicKeys {
+		if crypto.ClientID(publicKey) == sender.ID {
+			signer = i
+			break
+		}
+	}
+	if signer < 0 {
+		return "", fmt.Errorf("client [%s] is not a signer of group wallet [%s]", sender.ID, wallet.ClientID)
+	}
+
+	bls, err := crypto.NewSigner(crypto.BLS0Chain)
+	if err != nil {
+		return "", err
+	}
+	hash := vote.Transfer.Hash()
+	if valid, err := bls.Verify(wallet.SignerPublicKeys[signer], vote.Signature, hash); err != nil || !valid {
+		return "", errors.New("invalid vote signature")
+	}
+
+	key := proposalKey(wallet.ClientID, vote.ProposalID)
+	voted, ok := state[key].(*proposal)
+	if !ok {
+		voted = &proposal{Transfer: vote.Transfer, Votes: make(map[string]string)}
+		state[key] = voted
+	}
+	if voted.Executed {
+		return "", fmt.Errorf("proposal [%s] was executed already", vote.ProposalID)
+	}
+	if voted.Transfer != vote.Transfer {
+		return "", fmt.Errorf("vote is for another transfer than proposal [%s]", vote.ProposalID)
+	}
+	voted.Votes[wallet.SignerThresholdIDs[signer]] = vote.Signature
+
+	if len(voted.Votes) < wallet.NumRequired {
+		return fmt.Sprintf("proposal %s has %d of %d votes", vote.ProposalID, len(voted.Votes), wallet.NumRequired), nil
+	}
+
+	signature, err := crypto.RecoverSignature(voted.Votes, wallet.NumRequired)
+	if err != nil {
+		return "", err
+	}
+	if valid, err := bls.Verify(wallet.PublicKey, signature, hash); err != nil || !valid {
+		return "", errors.New("votes do not recover the signature of the group wallet")
+	}
+
+	group, ok := l.clients[wallet.ClientID]
+	if !ok {
+		return "", fmt.Errorf("group wallet [%s] has no balance", wallet.ClientID)
+	}
+	output, err := l.transfer(group, vote.Transfer.To, vote.Transfer.Amount)
+	if err != nil {
+		return "", err
+	}
+	voted.Executed = true
+
+	return output, nil
+}
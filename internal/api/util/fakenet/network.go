@@ -0,0 +1,203 @@
+package fakenet
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/currency"
+)
+
+// Defaults of Options
+const (
+	DefaultMiners    = 3
+	DefaultSharders  = 2
+	DefaultBlobbers  = 6
+	DefaultChainID   = "0afc093ffb509f059c55478bc1a60351cef7b4e9c008a53a6cc8241ca8617dfe"
+	DefaultPourLimit = 10 * 1e10
+)
+
+type Options struct {
+	Miners   int
+	Sharders int
+	// Blobbers are registered with the storage smart contract, DefaultBlobbers mock blobbers are started when empty
+	Blobbers []*Blobber
+	ChainID  string
+	// PourLimit caps what a single faucet pour mints
+	PourLimit currency.Coin
+	// Signer verifies the signatures of clients, bls0chain ones when nil
+	Signer crypto.Signer
+}
+
+// Blobber is a storage node known to the fake storage smart contract
+type Blobber struct {
+	ID                string
+	URL               string
+	Terms             model.Terms
+	Capacity          int64
+	Allocated         int64
+	StakePoolSettings model.StakePoolSettings
+}
+
+// Network is an in-process stand-in for a 0chain network, its miners and sharders share one in-memory ledger
+type Network struct {
+	Miners   []string
+	Sharders []string
+	DNS      string
+
+	ledger  *ledger
+	servers []*httptest.Server
+
+	mu     sync.RWMutex
+	faults map[string]Fault
+}
+
+// New starts the miners, sharders and 0dns of a fake network, Close has to be called to stop them
+func New(options Options) *Network {
+	if options.Miners <= 0 {
+		options.Miners = DefaultMiners
+	}
+	if options.Sharders <= 0 {
+		options.Sharders = DefaultSharders
+	}
+	mockBlobbers := len(options.Blobbers) == 0
+	if mockBlobbers {
+		options.Blobbers = generateBlobbers(DefaultBlobbers)
+	}
+	if options.ChainID == "" {
+		options.ChainID = DefaultChainID
+	}
+	if options.PourLimit <= 0 {
+		options.PourLimit = DefaultPourLimit
+	}
+	if options.Signer == nil {
+		options.Signer, _ = crypto.NewSigner(crypto.BLS0Chain)
+	}
+
+	n := &Network{faults: make(map[string]Fault)}
+
+	minerIDs := make([]string, options.Miners)
+	for i := range minerIDs {
+		minerIDs[i] = crypto.Sha3256([]byte(fmt.Sprintf("miner-%d", i)))
+	}
+	n.ledger = newLedger(options.ChainID, options.PourLimit, options.Signer, minerIDs, options.Blobbers)
+
+	for i := 0; i < options.Miners; i++ {
+		n.Miners = append(n.Miners, n.start(n.minerHandler(minerIDs)))
+	}
+	for i := 0; i < options.Sharders; i++ {
+		n.Sharders = append(n.Sharders, n.start(n.sharderHandler()))
+	}
+	n.DNS = n.start(n.dnsHandler())
+	if mockBlobbers {
+		for _, blobber := range options.Blobbers {
+			blobber.URL = n.start(newMockBlobber(blobber, n.ledger).handler())
+		}
+	}
+
+	return n
+}
+
+// NetworkEntrypoint is what Zerochain.Init has to be called with to use the network
+func (n *Network) NetworkEntrypoint() string {
+	return n.DNS + "/dns/network"
+}
+
+// Blobbers returns the blobbers known to the storage smart contract
+func (n *Network) Blobbers() []Blobber {
+	n.ledger.mu.Lock()
+	defer n.ledger.mu.Unlock()
+
+	blobbers := make([]Blobber, len(n.ledger.blobbers))
+	for i, blobber := range n.ledger.blobbers {
+		blobbers[i] = *blobber
+	}
+
+	return blobbers
+}
+
+// InjectFault makes node, the URL of a miner, sharder or mock blobber, misbehave until ClearFaults is called
+func (n *Network) InjectFault(node string, fault Fault) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.faults[node] = fault
+}
+
+func (n *Network) ClearFaults() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.faults = make(map[string]Fault)
+}
+
+// Round is the round of the last finalized block, every executed transaction finalizes a block of its own
+func (n *Network) Round() int64 {
+	return n.ledger.round()
+}
+
+func (n *Network) Close() {
+	for _, server := range n.servers {
+		server.Close()
+	}
+}
+
+// start serves handler on a new server whose faults are looked up by its URL on every request
+func (n *Network) start(handler http.Handler) string {
+	server := httptest.NewUnstartedServer(nil)
+	url := "http://" + server.Listener.Addr().String()
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.mu.RLock()
+		fault, ok := n.faults[url]
+		n.mu.RUnlock()
+
+		if ok {
+			fault(handler).ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+	server.Start()
+	n.servers = append(n.servers, server)
+
+	return url
+}
+
+func generateBlobbers(count int) []*Blobber {
+	blobbers := make([]*Blobber, count)
+	for i := range blobbers {
+		id := crypto.Sha3256([]byte(fmt.Sprintf("blobber-%d", i)))
+		blobbers[i] = &Blobber{
+			ID:       id,
+			Terms:    model.Terms{ReadPrice: 1e9, WritePrice: 1e9, MinLockDemand: 0.1},
+			Capacity: 1 << 40,
+			StakePoolSettings: model.StakePoolSettings{
+				DelegateWallet: crypto.Sha3256([]byte(fmt.Sprintf("delegate-%d", i))),
+				NumDelegates:   50,
+				ServiceCharge:  0.1,
+			},
+		}
+	}
+
+	return blobbers
+}
+
+func (b *Blobber) matches(requirements model.BlobberRequirements, sizePerBlobber int64) bool {
+	return b.Capacity-b.Allocated >= sizePerBlobber &&
+		b.Terms.ReadPrice >= requirements.ReadPriceRange.Min && b.Terms.ReadPrice <= requirements.ReadPriceRange.Max &&
+		b.Terms.WritePrice >= requirements.WritePriceRange.Min && b.Terms.WritePrice <= requirements.WritePriceRange.Max
+}
+
+func (b *Blobber) response() *model.GetBlobberResponse {
+	return &model.GetBlobberResponse{
+		ID:                b.ID,
+		BaseURL:           b.URL,
+		Terms:             b.Terms,
+		Capacity:          b.Capacity,
+		Allocated:         b.Allocated,
+		StakePoolSettings: b.StakePoolSettings,
+	}
+}
@@ -1,35 +1,109 @@
 package wait
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
 	"time"
 )
 
-// PoolImmediately pools passed function for a certain amount of time
-func PoolImmediately(duration time.Duration, callback func() bool) {
-	ticker := time.NewTicker(time.Second * 3)
+// Defaults of Options
+const (
+	DefaultInterval    = time.Second
+	DefaultMaxInterval = 10 * time.Second
+	DefaultMultiplier  = 1.5
+)
 
-	defer ticker.Stop()
+var ErrTimeout = errors.New("timed out waiting")
 
-	after := time.After(duration)
-	postAfter := time.After(time.Second * 15)
+// Options tune how Until probes
+type Options struct {
+	// Timeout bounds the wait on top of the deadline of the context, no further bound when zero
+	Timeout time.Duration
+	// Interval is the delay before the second probe, DefaultInterval when zero
+	Interval time.Duration
+	// MaxInterval caps the delay between probes, DefaultMaxInterval when zero
+	MaxInterval time.Duration
+	// Multiplier grows the delay after every probe, DefaultMultiplier when zero and a constant delay when 1
+	Multiplier float64
+	// Jitter randomises every delay by up to this fraction of it, so parallel tests do not probe in lockstep
+	Jitter float64
+	// Consecutive is how many positive probes in a row it takes to succeed, 1 when zero.
+	// More than one rides out sharders which have not all caught up yet.
+	Consecutive int
+}
 
-primary:
-	for range ticker.C {
-		select {
-		case <-after:
-			break primary
-		default:
-			if callback() {
-				break primary
+// Until probes right away and then with a growing delay until probe reports success Consecutive times in a row.
+// It returns the value of the last probe, along with ErrTimeout once ctx is done or the timeout passed,
+// or with the error of a probe, which stops probing. Probes should report transient failures as unsuccessful instead.
+func Until[T any](ctx context.Context, probe func() (T, bool, error), opts Options) (T, error) {
+	opts = withDefaults(opts)
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var (
+		last      T
+		positives int
+	)
+	delay := opts.Interval
+	for probes := 1; ; probes++ {
+		value, ok, err := probe()
+		last = value
+		if err != nil {
+			return last, err
+		}
+		if ok {
+			positives++
+			if positives >= opts.Consecutive {
+				return last, nil
 			}
+		} else {
+			positives = 0
 		}
-	}
 
-	for range ticker.C {
+		timer := time.NewTimer(jitter(delay, opts.Jitter))
 		select {
-		case <-postAfter:
-			return
-		default:
+		case <-ctx.Done():
+			timer.Stop()
+			return last, fmt.Errorf("%w after %d probes: %v", ErrTimeout, probes, ctx.Err())
+		case <-timer.C:
+		}
+
+		delay = time.Duration(float64(delay) * opts.Multiplier)
+		if delay > opts.MaxInterval {
+			delay = opts.MaxInterval
 		}
 	}
 }
+
+func withDefaults(opts Options) Options {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = DefaultMaxInterval
+	}
+	if opts.MaxInterval < opts.Interval {
+		opts.MaxInterval = opts.Interval
+	}
+	if opts.Multiplier <= 0 {
+		opts.Multiplier = DefaultMultiplier
+	}
+	if opts.Consecutive <= 0 {
+		opts.Consecutive = 1
+	}
+
+	return opts
+}
+
+func jitter(delay time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return delay
+	}
+
+	return delay + time.Duration((rand.Float64()*2-1)*fraction*float64(delay)) //nolint:gosec
+}
@@ -0,0 +1,147 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// probeSequence answers with outcomes one probe at a time and keeps answering with the last one, the value is the number of the probe
+func probeSequence(outcomes ...bool) (probe func() (int, bool, error), probes *int) {
+	probes = new(int)
+	return func() (int, bool, error) {
+		*probes++
+		if *probes <= len(outcomes) {
+			return *probes, outcomes[*probes-1], nil
+		}
+		return *probes, outcomes[len(outcomes)-1], nil
+	}, probes
+}
+
+func TestUntil(t *testing.T) {
+	t.Parallel()
+
+	fast := Options{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Timeout: time.Second}
+
+	t.Run("Until should return right away when the first probe succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		probe, probes := probeSequence(true)
+		started := time.Now()
+		value, err := Until(context.Background(), probe, Options{Interval: time.Minute})
+		require.Nil(t, err)
+		require.Equal(t, 1, value)
+		require.Equal(t, 1, *probes)
+		require.Less(t, time.Since(started), time.Second)
+	})
+
+	t.Run("Until should wait for Consecutive successes in a row", func(t *testing.T) {
+		t.Parallel()
+
+		opts := fast
+		opts.Consecutive = 3
+		probe, probes := probeSequence(true, false, true, true, false, true, true, true, false)
+		value, err := Until(context.Background(), probe, opts)
+		require.Nil(t, err)
+		require.Equal(t, 8, value)
+		require.Equal(t, 8, *probes)
+	})
+
+	t.Run("Until should stop at the first probe error", func(t *testing.T) {
+		t.Parallel()
+
+		probeError := errors.New("probe failed")
+		probes := 0
+		value, err := Until(context.Background(), func() (int, bool, error) {
+			probes++
+			if probes == 3 {
+				return probes, false, probeError
+			}
+			return probes, false, nil
+		}, fast)
+		require.True(t, errors.Is(err, probeError), err)
+		require.False(t, errors.Is(err, ErrTimeout), err)
+		require.Equal(t, 3, value)
+		require.Equal(t, 3, probes)
+	})
+
+	t.Run("Until should time out with the value of the last probe", func(t *testing.T) {
+		t.Parallel()
+
+		opts := fast
+		opts.Timeout = 50 * time.Millisecond
+		probe, probes := probeSequence(false)
+		started := time.Now()
+		value, err := Until(context.Background(), probe, opts)
+		require.True(t, errors.Is(err, ErrTimeout), err)
+		require.GreaterOrEqual(t, time.Since(started), opts.Timeout)
+		require.Less(t, time.Since(started), time.Second)
+		require.Greater(t, *probes, 1)
+		require.Equal(t, *probes, value)
+	})
+
+	t.Run("Until should time out once the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		probe, _ := probeSequence(false)
+		_, err := Until(ctx, probe, Options{Interval: time.Millisecond})
+		require.True(t, errors.Is(err, ErrTimeout), err)
+	})
+
+	t.Run("Until should not count a success interrupted by a failure towards Consecutive before timing out", func(t *testing.T) {
+		t.Parallel()
+
+		opts := fast
+		opts.Timeout = 50 * time.Millisecond
+		opts.Consecutive = 2
+		flapping := false
+		_, err := Until(context.Background(), func() (bool, bool, error) {
+			flapping = !flapping
+			return flapping, flapping, nil
+		}, opts)
+		require.True(t, errors.Is(err, ErrTimeout), err)
+	})
+
+	t.Run("Until should grow the delay between probes up to MaxInterval", func(t *testing.T) {
+		t.Parallel()
+
+		var probedAt []time.Time
+		_, err := Until(context.Background(), func() (int, bool, error) {
+			probedAt = append(probedAt, time.Now())
+			return len(probedAt), len(probedAt) == 5, nil
+		}, Options{Interval: 10 * time.Millisecond, MaxInterval: 20 * time.Millisecond, Multiplier: 2})
+		require.Nil(t, err)
+		require.Len(t, probedAt, 5)
+
+		for i, minimum := range []time.Duration{10, 20, 20, 20} {
+			require.GreaterOrEqual(t, probedAt[i+1].Sub(probedAt[i]), minimum*time.Millisecond, "delay before probe %d", i+2)
+		}
+	})
+}
+
+func TestWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Zero options should take the defaults", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, Options{
+			Interval:    DefaultInterval,
+			MaxInterval: DefaultMaxInterval,
+			Multiplier:  DefaultMultiplier,
+			Consecutive: 1,
+		}, withDefaults(Options{}))
+	})
+
+	t.Run("MaxInterval should not be lower than Interval", func(t *testing.T) {
+		t.Parallel()
+
+		opts := withDefaults(Options{Interval: time.Minute})
+		require.Equal(t, time.Minute, opts.MaxInterval)
+	})
+}
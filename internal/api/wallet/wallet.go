@@ -0,0 +1,108 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	climodel "github.com/0chain/system_test/internal/cli/model"
+)
+
+const version = "1.0"
+
+// Load reads a zwallet JSON wallet, such as the ./config/*_wallet.json files of the CLI tests
+func Load(path string) (*climodel.WalletFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var wallet climodel.WalletFile
+	if err := json.Unmarshal(content, &wallet); err != nil {
+		return nil, fmt.Errorf("invalid wallet file [%s]: %w", path, err)
+	}
+
+	return &wallet, nil
+}
+
+// Save writes wallet as a zwallet JSON wallet which zwallet and zbox can be pointed to with --wallet
+func Save(path string, wallet *climodel.WalletFile) error {
+	content, err := json.Marshal(wallet)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o600)
+}
+
+// DeriveKeys recovers the keys of scheme from mnemonic, the same keys zwallet creates a wallet with
+func DeriveKeys(scheme, mnemonic string) (*model.KeyPair, error) {
+	signer, err := crypto.NewSigner(scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.GenerateKeys(mnemonic)
+}
+
+// FromMnemonic creates the wallet of mnemonic, it still has to be registered with the network before it is used
+func FromMnemonic(scheme, mnemonic string) (*model.Wallet, *model.KeyPair, error) {
+	keys, err := DeriveKeys(scheme, mnemonic)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &model.Wallet{
+		ClientID:    crypto.ClientID(keys.PublicKey),
+		ClientKey:   keys.PublicKey,
+		Keys:        []*sys.KeyPair{{PublicKey: keys.PublicKey, PrivateKey: keys.PrivateKey}},
+		Mnemonics:   mnemonic,
+		Version:     version,
+		DateCreated: time.Now().Format(time.RFC3339),
+	}, keys, nil
+}
+
+// KeyPair returns the first keys of wallet, which it signs with, as keys of scheme
+func KeyPair(wallet *model.Wallet, scheme string) (*model.KeyPair, error) {
+	if len(wallet.Keys) == 0 {
+		return nil, fmt.Errorf("wallet [%s] has no keys", wallet.ClientID)
+	}
+
+	return &model.KeyPair{Scheme: scheme, PublicKey: wallet.Keys[0].PublicKey, PrivateKey: wallet.Keys[0].PrivateKey}, nil
+}
+
+// ToFile converts a wallet of the API tests into a zwallet one, the nonce is not part of wallet files
+func ToFile(wallet *model.Wallet) *climodel.WalletFile {
+	file := &climodel.WalletFile{
+		ClientID:    wallet.ClientID,
+		ClientKey:   wallet.ClientKey,
+		Mnemonic:    wallet.Mnemonics,
+		Version:     wallet.Version,
+		DateCreated: wallet.DateCreated,
+	}
+	for _, keys := range wallet.Keys {
+		file.Keys = append(file.Keys, climodel.KeyPair{PublicKey: keys.PublicKey, PrivateKey: keys.PrivateKey})
+	}
+
+	return file
+}
+
+// FromFile converts a zwallet wallet into one of the API tests, its nonce is left to be synced from the network
+func FromFile(file *climodel.WalletFile) *model.Wallet {
+	wallet := &model.Wallet{
+		ClientID:    file.ClientID,
+		ClientKey:   file.ClientKey,
+		Mnemonics:   file.Mnemonic,
+		Version:     file.Version,
+		DateCreated: file.DateCreated,
+	}
+	for _, keys := range file.Keys {
+		wallet.Keys = append(wallet.Keys, &sys.KeyPair{PublicKey: keys.PublicKey, PrivateKey: keys.PrivateKey})
+	}
+
+	return wallet
+}
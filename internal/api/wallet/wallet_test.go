@@ -0,0 +1,110 @@
+package wallet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// fixtures are the zwallet wallets the CLI tests run with, all of them created by zwallet with BLS keys
+func fixtures(t *testing.T) []string {
+	paths, err := filepath.Glob("../../../tests/cli_tests/config/wallets/*.json")
+	require.Nil(t, err)
+	require.NotEmpty(t, paths)
+
+	return paths
+}
+
+func TestWalletFiles(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range fixtures(t) {
+		path := path
+		name := filepath.Base(path)
+
+		t.Run("DeriveKeys should reproduce the keys zwallet created "+name+" with", func(t *testing.T) {
+			t.Parallel()
+
+			file, err := Load(path)
+			require.Nil(t, err)
+			require.Len(t, file.Keys, 1)
+
+			keys, err := DeriveKeys(crypto.BLS0Chain, file.Mnemonic)
+			require.Nil(t, err)
+			require.Equal(t, file.Keys[0].PublicKey, keys.PublicKey)
+			require.Equal(t, file.Keys[0].PrivateKey, keys.PrivateKey)
+			require.Equal(t, file.ClientKey, keys.PublicKey)
+			require.Equal(t, file.ClientID, crypto.ClientID(keys.PublicKey))
+
+			wallet, walletKeys, err := FromMnemonic(crypto.BLS0Chain, file.Mnemonic)
+			require.Nil(t, err)
+			require.Equal(t, keys, walletKeys)
+			require.Equal(t, file.ClientID, wallet.ClientID)
+
+			signingKeys, err := KeyPair(wallet, crypto.BLS0Chain)
+			require.Nil(t, err)
+			require.Equal(t, keys, signingKeys)
+		})
+
+		t.Run("ToFile should undo FromFile for "+name, func(t *testing.T) {
+			t.Parallel()
+
+			file, err := Load(path)
+			require.Nil(t, err)
+			require.Equal(t, file, ToFile(FromFile(file)))
+		})
+
+		t.Run("Save should write "+name+" as Load read it", func(t *testing.T) {
+			t.Parallel()
+
+			file, err := Load(path)
+			require.Nil(t, err)
+
+			saved := filepath.Join(t.TempDir(), name)
+			require.Nil(t, Save(saved, file))
+			loaded, err := Load(saved)
+			require.Nil(t, err)
+			require.Equal(t, file, loaded)
+
+			// wallet files are rewritten field by field, except for the nonce, which is synced from the network instead
+			var original, written map[string]interface{}
+			require.Nil(t, json.Unmarshal(mustRead(t, path), &original))
+			require.Nil(t, json.Unmarshal(mustRead(t, saved), &written))
+			delete(original, "nonce")
+			require.Equal(t, original, written)
+		})
+	}
+
+	t.Run("Load should fail on a file which is not a wallet", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "wallet.json")
+		require.Nil(t, os.WriteFile(path, []byte("not a wallet"), 0o600))
+		_, err := Load(path)
+		require.NotNil(t, err)
+
+		_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+		require.NotNil(t, err)
+	})
+
+	t.Run("KeyPair should fail on a wallet without keys", func(t *testing.T) {
+		t.Parallel()
+
+		wallet, _, err := FromMnemonic(crypto.BLS0Chain, crypto.GenerateMnemonic(t))
+		require.Nil(t, err)
+		wallet.Keys = nil
+		_, err = KeyPair(wallet, crypto.BLS0Chain)
+		require.NotNil(t, err)
+	})
+}
+
+func mustRead(t *testing.T, path string) []byte {
+	content, err := os.ReadFile(path)
+	require.Nil(t, err)
+
+	return content
+}
@@ -62,7 +62,7 @@ type Allocation struct {
 	TimeUnit    time.Duration `json:"time_unit"`
 	IsImmutable bool          `json:"is_immutable"`
 
-	WritePool int64 `json:"write_pool"`
+	WritePool currency.Coin `json:"write_pool"`
 
 	// BlobberDetails contains real terms used for the allocation.
 	// If the allocation has updated, then terms calculated using
@@ -77,13 +77,13 @@ type Allocation struct {
 
 	ChallengeCompletionTime time.Duration `json:"challenge_completion_time"`
 
-	StartTime         int64    `json:"start_time"`
-	Finalized         bool     `json:"finalized,omitempty"`
-	Canceled          bool     `json:"canceled,omitempty"`
-	MovedToChallenge  int64    `json:"moved_to_challenge,omitempty"`
-	MovedBack         int64    `json:"moved_back,omitempty"`
-	MovedToValidators int64    `json:"moved_to_validators,omitempty"`
-	Curators          []string `json:"curators"`
+	StartTime         int64         `json:"start_time"`
+	Finalized         bool          `json:"finalized,omitempty"`
+	Canceled          bool          `json:"canceled,omitempty"`
+	MovedToChallenge  currency.Coin `json:"moved_to_challenge,omitempty"`
+	MovedBack         currency.Coin `json:"moved_back,omitempty"`
+	MovedToValidators currency.Coin `json:"moved_to_validators,omitempty"`
+	Curators          []string      `json:"curators"`
 }
 
 type AllocationFile struct {
@@ -99,7 +99,7 @@ type Blobber struct {
 }
 
 type ReadPoolInfo struct {
-	Balance int64 `json:"balance"`
+	Balance currency.Coin `json:"balance"`
 }
 
 type ListFileResult struct {
@@ -119,18 +119,18 @@ type ListFileResult struct {
 }
 
 type Terms struct {
-	Read_price         int64         `json:"read_price"`
-	Write_price        int64         `json:"write_price"`
+	Read_price         currency.Coin `json:"read_price"`
+	Write_price        currency.Coin `json:"write_price"`
 	Min_lock_demand    float64       `json:"min_lock_demand"`
 	Max_offer_duration time.Duration `json:"max_offer_duration"`
 }
 
 type Settings struct {
-	Delegate_wallet string  `json:"delegate_wallet"`
-	Min_stake       int     `json:"min_stake"`
-	Max_stake       int     `json:"max_stake"`
-	Num_delegates   int     `json:"num_delegates"`
-	Service_charge  float64 `json:"service_charge"`
+	Delegate_wallet string        `json:"delegate_wallet"`
+	Min_stake       currency.Coin `json:"min_stake"`
+	Max_stake       currency.Coin `json:"max_stake"`
+	Num_delegates   int           `json:"num_delegates"`
+	Service_charge  float64       `json:"service_charge"`
 }
 
 type BlobberInfo struct {
@@ -144,11 +144,11 @@ type BlobberInfo struct {
 }
 
 type ChallengePoolInfo struct {
-	Id         string `json:"id"`
-	Balance    int64  `json:"balance"`
-	StartTime  int64  `json:"start_time"`
-	Expiration int64  `json:"expiration"`
-	Finalized  bool   `json:"finalized"`
+	Id         string        `json:"id"`
+	Balance    currency.Coin `json:"balance"`
+	StartTime  int64         `json:"start_time"`
+	Expiration int64         `json:"expiration"`
+	Finalized  bool          `json:"finalized"`
 }
 
 type FileMetaResult struct {
@@ -199,49 +199,49 @@ type CommitResponse struct {
 }
 
 type PriceRange struct {
-	Min int64 `json:"min"`
-	Max int64 `json:"max"`
+	Min currency.Coin `json:"min"`
+	Max currency.Coin `json:"max"`
 }
 
 type BlobberAllocation struct {
-	BlobberID       string `json:"blobber_id"`
-	Size            int64  `json:"size"`
-	Terms           Terms  `json:"terms"`
-	MinLockDemand   int64  `json:"min_lock_demand"`
-	Spent           int64  `json:"spent"`
-	Penalty         int64  `json:"penalty"`
-	ReadReward      int64  `json:"read_reward"`
-	Returned        int64  `json:"returned"`
-	ChallengeReward int64  `json:"challenge_reward"`
-	FinalReward     int64  `json:"final_reward"`
+	BlobberID       string        `json:"blobber_id"`
+	Size            int64         `json:"size"`
+	Terms           Terms         `json:"terms"`
+	MinLockDemand   currency.Coin `json:"min_lock_demand"`
+	Spent           currency.Coin `json:"spent"`
+	Penalty         currency.Coin `json:"penalty"`
+	ReadReward      currency.Coin `json:"read_reward"`
+	Returned        currency.Coin `json:"returned"`
+	ChallengeReward currency.Coin `json:"challenge_reward"`
+	FinalReward     currency.Coin `json:"final_reward"`
 }
 
 type StakePoolInfo struct {
 	ID           string                      `json:"pool_id"`      // pool ID
-	Balance      int64                       `json:"balance"`      // total balance
-	Unstake      int64                       `json:"unstake"`      // total unstake amount
+	Balance      currency.Coin               `json:"balance"`      // total balance
+	Unstake      currency.Coin               `json:"unstake"`      // total unstake amount
 	Free         int64                       `json:"free"`         // free staked space
 	Capacity     int64                       `json:"capacity"`     // blobber bid
-	WritePrice   int64                       `json:"write_price"`  // its write price
-	OffersTotal  int64                       `json:"offers_total"` //
-	UnstakeTotal int64                       `json:"unstake_total"`
+	WritePrice   currency.Coin               `json:"write_price"`  // its write price
+	OffersTotal  currency.Coin               `json:"offers_total"` //
+	UnstakeTotal currency.Coin               `json:"unstake_total"`
 	Delegate     []StakePoolDelegatePoolInfo `json:"delegate"`
-	Penalty      int64                       `json:"penalty"` // total for all
-	Rewards      int64                       `json:"rewards"`
+	Penalty      currency.Coin               `json:"penalty"` // total for all
+	Rewards      currency.Coin               `json:"rewards"`
 	Settings     StakePoolSettings           `json:"settings"`
 }
 
 type StakePoolDelegatePoolInfo struct {
-	ID         string `json:"id"`          // blobber ID
-	Balance    int64  `json:"balance"`     // current balance
-	DelegateID string `json:"delegate_id"` // wallet
-	Rewards    int64  `json:"rewards"`     // current
-	UnStake    bool   `json:"unstake"`     // want to unstake
+	ID         string        `json:"id"`          // blobber ID
+	Balance    currency.Coin `json:"balance"`     // current balance
+	DelegateID string        `json:"delegate_id"` // wallet
+	Rewards    currency.Coin `json:"rewards"`     // current
+	UnStake    bool          `json:"unstake"`     // want to unstake
 
-	TotalReward  int64  `json:"total_reward"`
-	TotalPenalty int64  `json:"total_penalty"`
-	Status       string `json:"status"`
-	RoundCreated int64  `json:"round_created"`
+	TotalReward  currency.Coin `json:"total_reward"`
+	TotalPenalty currency.Coin `json:"total_penalty"`
+	Status       string        `json:"status"`
+	RoundCreated int64         `json:"round_created"`
 }
 
 type StakePoolSettings struct {
@@ -262,16 +262,16 @@ type NodeList struct {
 }
 
 type DelegatePool struct {
-	Balance      int64  `json:"balance"`
-	Reward       int64  `json:"reward"`
-	Status       int    `json:"status"`
-	RoundCreated int64  `json:"round_created"` // used for cool down
-	DelegateID   string `json:"delegate_id"`
+	Balance      currency.Coin `json:"balance"`
+	Reward       currency.Coin `json:"reward"`
+	Status       int           `json:"status"`
+	RoundCreated int64         `json:"round_created"` // used for cool down
+	DelegateID   string        `json:"delegate_id"`
 }
 
 type StakePool struct {
 	Pools    map[string]*DelegatePool `json:"pools"`
-	Reward   int64                    `json:"rewards"`
+	Reward   currency.Coin            `json:"rewards"`
 	Settings StakePoolSettings        `json:"settings"`
 	Minter   int                      `json:"minter"`
 }
@@ -279,20 +279,20 @@ type StakePool struct {
 type Node struct {
 	SimpleNode  `json:"simple_miner"`
 	StakePool   `json:"stake_pool"`
-	Round       int64 `json:"round"`
-	TotalReward int64 `json:"total_reward"`
+	Round       int64         `json:"round"`
+	TotalReward currency.Coin `json:"total_reward"`
 }
 
 type SimpleNode struct {
-	ID         string      `json:"id"`
-	N2NHost    string      `json:"n2n_host"`
-	Host       string      `json:"host"`
-	Port       int         `json:"port"`
-	PublicKey  string      `json:"public_key"`
-	ShortName  string      `json:"short_name"`
-	BuildTag   string      `json:"build_tag"`
-	TotalStake int64       `json:"total_stake"`
-	Stat       interface{} `json:"stat"`
+	ID         string        `json:"id"`
+	N2NHost    string        `json:"n2n_host"`
+	Host       string        `json:"host"`
+	Port       int           `json:"port"`
+	PublicKey  string        `json:"public_key"`
+	ShortName  string        `json:"short_name"`
+	BuildTag   string        `json:"build_tag"`
+	TotalStake currency.Coin `json:"total_stake"`
+	Stat       interface{}   `json:"stat"`
 }
 
 type Sharder struct {
@@ -345,15 +345,15 @@ type BlobberDetails struct {
 }
 
 type Validator struct {
-	ID             string  `json:"validator_id"`
-	BaseURL        string  `json:"url"`
-	PublicKey      string  `json:"-"`
-	DelegateWallet string  `json:"delegate_wallet"`
-	MinStake       int64   `json:"min_stake"`
-	MaxStake       int64   `json:"max_stake"`
-	NumDelegates   int     `json:"num_delegates"`
-	ServiceCharge  float64 `json:"service_charge"`
-	TotalStake     int64   `json:"stake"`
+	ID             string        `json:"validator_id"`
+	BaseURL        string        `json:"url"`
+	PublicKey      string        `json:"-"`
+	DelegateWallet string        `json:"delegate_wallet"`
+	MinStake       currency.Coin `json:"min_stake"`
+	MaxStake       currency.Coin `json:"max_stake"`
+	NumDelegates   int           `json:"num_delegates"`
+	ServiceCharge  float64       `json:"service_charge"`
+	TotalStake     currency.Coin `json:"stake"`
 }
 
 type FileDiff struct {
@@ -385,20 +385,20 @@ type KeyPair struct {
 }
 
 type Miner struct {
-	ID                string      `json:"id"`
-	N2NHost           string      `json:"n2n_host"`
-	Host              string      `json:"host"`
-	Port              int         `json:"port"`
-	PublicKey         string      `json:"public_key"`
-	ShortName         string      `json:"short_name"`
-	BuildTag          string      `json:"build_tag"`
-	TotalStake        int         `json:"total_stake"`
-	DelegateWallet    string      `json:"delegate_wallet"`
-	ServiceCharge     float64     `json:"service_charge"`
-	NumberOfDelegates int         `json:"number_of_delegates"`
-	MinStake          int64       `json:"min_stake"`
-	MaxStake          int64       `json:"max_stake"`
-	Stat              interface{} `json:"stat"`
+	ID                string        `json:"id"`
+	N2NHost           string        `json:"n2n_host"`
+	Host              string        `json:"host"`
+	Port              int           `json:"port"`
+	PublicKey         string        `json:"public_key"`
+	ShortName         string        `json:"short_name"`
+	BuildTag          string        `json:"build_tag"`
+	TotalStake        currency.Coin `json:"total_stake"`
+	DelegateWallet    string        `json:"delegate_wallet"`
+	ServiceCharge     float64       `json:"service_charge"`
+	NumberOfDelegates int           `json:"number_of_delegates"`
+	MinStake          currency.Coin `json:"min_stake"`
+	MaxStake          currency.Coin `json:"max_stake"`
+	Stat              interface{}   `json:"stat"`
 }
 
 type MinerSCNodes struct {
@@ -406,11 +406,11 @@ type MinerSCNodes struct {
 }
 
 type MinerSCDelegatePoolInfo struct {
-	ID         string `json:"id"`
-	Balance    int64  `json:"balance"`
-	Reward     int64  `json:"reward"`      // uncollected reread
-	RewardPaid int64  `json:"reward_paid"` // total reward all time
-	Status     string `json:"status"`
+	ID         string        `json:"id"`
+	Balance    currency.Coin `json:"balance"`
+	Reward     currency.Coin `json:"reward"`      // uncollected reread
+	RewardPaid currency.Coin `json:"reward_paid"` // total reward all time
+	Status     string        `json:"status"`
 }
 
 type LockConfig struct {
@@ -420,11 +420,11 @@ type LockConfig struct {
 }
 
 type SimpleGlobalNode struct {
-	MaxMint     int64   `json:"max_mint"`
-	TotalMinted int64   `json:"total_minted"`
-	MinLock     int64   `json:"min_lock"`
-	Apr         float64 `json:"apr"`
-	OwnerId     string  `json:"owner_id"`
+	MaxMint     currency.Coin `json:"max_mint"`
+	TotalMinted currency.Coin `json:"total_minted"`
+	MinLock     currency.Coin `json:"min_lock"`
+	Apr         float64       `json:"apr"`
+	OwnerId     string        `json:"owner_id"`
 }
 
 type MinerSCUserPoolsInfo struct {
@@ -432,18 +432,18 @@ type MinerSCUserPoolsInfo struct {
 }
 
 type PoolStats struct {
-	DelegateID   string `json:"delegate_id"`
-	High         int64  `json:"high"` // } interests and rewards
-	Low          int64  `json:"low"`  // }
-	InterestPaid int64  `json:"interest_paid"`
-	RewardPaid   int64  `json:"reward_paid"`
-	NumRounds    int64  `json:"number_rounds"`
-	Status       string `json:"status"`
+	DelegateID   string        `json:"delegate_id"`
+	High         currency.Coin `json:"high"` // } interests and rewards
+	Low          currency.Coin `json:"low"`  // }
+	InterestPaid currency.Coin `json:"interest_paid"`
+	RewardPaid   currency.Coin `json:"reward_paid"`
+	NumRounds    int64         `json:"number_rounds"`
+	Status       string        `json:"status"`
 }
 
 type TokenPool struct {
-	ID      string `json:"id"`
-	Balance int64  `json:"balance"`
+	ID      string        `json:"id"`
+	Balance currency.Coin `json:"balance"`
 }
 
 type ZCNLockingPool struct {
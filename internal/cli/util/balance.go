@@ -0,0 +1,20 @@
+package cliutils
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/0chain/system_test/internal/currency"
+)
+
+var balanceOutput = regexp.MustCompile(`^Balance: (\S+ \S+) \(\d*\.?\d+ USD\)$`)
+
+// ParseBalance reads the exact balance of a zwallet getbalance line such as "Balance: 500.000 mZCN (0.05 USD)"
+func ParseBalance(output string) (currency.Coin, error) {
+	match := balanceOutput.FindStringSubmatch(output)
+	if match == nil {
+		return 0, fmt.Errorf("unexpected balance output [%s]", output)
+	}
+
+	return currency.ParseCoin(match[1])
+}
@@ -8,12 +8,15 @@ import (
 	"testing"
 
 	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/currency"
 	"github.com/stretchr/testify/require"
 )
 
 const (
 	MaxQueryLimit    = 20
 	StorageScAddress = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7"
+	MinerScAddress   = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9"
 )
 
 type ChainHistory struct {
@@ -38,30 +41,58 @@ func (ch *ChainHistory) TimesWonBestMiner(minerId string) int64 {
 	return won
 }
 
-func (ch *ChainHistory) TotalFees() int64 {
-	var fees int64
+func (ch *ChainHistory) BlocksWonBy(minerId string) []model.EventDbBlock {
+	var won []model.EventDbBlock
 	for _, block := range ch.blocks {
-		fees += ch.TotalBlockFees(block)
+		if minerId == block.MinerID {
+			won = append(won, block)
+		}
+	}
+	return won
+}
+
+func (ch *ChainHistory) TotalFees() (currency.Coin, error) {
+	var fees currency.Coin
+	for _, block := range ch.blocks {
+		blockFees, err := ch.TotalBlockFees(block)
+		if err != nil {
+			return 0, err
+		}
+		if fees, err = currency.AddCoin(fees, blockFees); err != nil {
+			return 0, err
+		}
 	}
-	return fees
+	return fees, nil
 }
 
-func (ch *ChainHistory) TotalMinerFees(minerId string) int64 {
-	var fees int64
+func (ch *ChainHistory) TotalMinerFees(minerId string) (currency.Coin, error) {
+	var fees currency.Coin
 	for _, block := range ch.blocks {
-		if block.MinerID == minerId {
-			fees += ch.TotalBlockFees(block)
+		if block.MinerID != minerId {
+			continue
+		}
+		blockFees, err := ch.TotalBlockFees(block)
+		if err != nil {
+			return 0, err
+		}
+		if fees, err = currency.AddCoin(fees, blockFees); err != nil {
+			return 0, err
 		}
 	}
-	return fees
+	return fees, nil
 }
 
-func (ch *ChainHistory) TotalBlockFees(block model.EventDbBlock) int64 {
-	var fees int64
+func (ch *ChainHistory) TotalBlockFees(block model.EventDbBlock) (currency.Coin, error) {
+	var (
+		fees currency.Coin
+		err  error
+	)
 	for _, tx := range block.Transactions {
-		fees += tx.Fee
+		if fees, err = currency.AddCoin(fees, tx.Fee); err != nil {
+			return 0, err
+		}
 	}
-	return fees
+	return fees, nil
 }
 
 func apiGetBlocks(start, end, limit, offset int64, sharderBaseURL string) (*http.Response, error) {
@@ -103,6 +134,63 @@ func getBlocks(t *testing.T, from, to, limit, offset int64, sharderBaseUrl strin
 	return blocks
 }
 
+// VerifyBlocks checks the generator signature and the verification tickets of every block read,
+// fetching each in full from the sharder. Miners are resolved from the magic block each block was generated under,
+// threshold is the notarization threshold percentage of the chain, see crypto.DefaultNotarizationThreshold.
+func (ch *ChainHistory) VerifyBlocks(t *testing.T, sharderBaseUrl string, threshold int) {
+	minerKeysByMagicBlock := make(map[string]map[string]string)
+	for _, summary := range ch.blocks {
+		block := getBlock(t, summary.Round, sharderBaseUrl)
+		require.Equal(t, summary.Hash, block.Block.Hash, "block of round %d changed after it was read", summary.Round)
+
+		magicBlockHash := block.Block.LatestFinalizedMagicBlockHash
+		minerKeys, ok := minerKeysByMagicBlock[magicBlockHash]
+		if !ok {
+			minerKeys = getMagicBlockMinerKeys(t, block.Block.LatestFinalizedMagicBlockRound, magicBlockHash, sharderBaseUrl)
+			minerKeysByMagicBlock[magicBlockHash] = minerKeys
+		}
+		require.NoError(t, crypto.VerifyBlock(block, minerKeys, threshold), "verifying block of round %d", summary.Round)
+	}
+}
+
+func apiGetBlock(round int64, sharderBaseURL string) (*http.Response, error) {
+	return http.Get(fmt.Sprintf(sharderBaseURL+"/v1/block/get?content=full&round=%d", round))
+}
+
+func getBlock(t *testing.T, round int64, sharderBaseUrl string) *model.Block {
+	res, err := apiGetBlock(round, sharderBaseUrl)
+	require.NoError(t, err, "retrieving block %d", round)
+	defer res.Body.Close()
+	require.True(t, res.StatusCode >= 200 && res.StatusCode < 300,
+		"failed API request to get block %d, status code: %d", round, res.StatusCode)
+
+	resBody, err := io.ReadAll(res.Body)
+	require.NoError(t, err, "reading response body: %v", err)
+
+	var block model.Block
+	err = json.Unmarshal(resBody, &block)
+	require.NoError(t, err, "deserializing JSON string `%s`: %v", string(resBody), err)
+
+	return &block
+}
+
+// getMagicBlockMinerKeys maps the ID of every miner of the magic block finalized in round to its public key
+func getMagicBlockMinerKeys(t *testing.T, round int64, hash, sharderBaseUrl string) map[string]string {
+	block := getBlock(t, round, sharderBaseUrl)
+	require.Equal(t, hash, block.Block.Hash, "magic block of round %d", round)
+	magicBlock := block.Block.MagicBlock
+	require.NotNil(t, magicBlock, "block %s of round %d is not a magic block", hash, round)
+	require.NotNil(t, magicBlock.Miners, "magic block %s has no miners", hash)
+	require.NotEmpty(t, magicBlock.Miners.Nodes, "magic block %s has no miners", hash)
+
+	minerKeys := make(map[string]string, len(magicBlock.Miners.Nodes))
+	for id, miner := range magicBlock.Miners.Nodes {
+		minerKeys[id] = miner.PublicKey
+	}
+
+	return minerKeys
+}
+
 // debug dumps
 
 func (ch *ChainHistory) DumpTransactions() {
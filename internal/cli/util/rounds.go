@@ -0,0 +1,283 @@
+package cliutils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+)
+
+// RoundPollInterval is how often the round watcher polls the latest finalized block while tests wait on it
+const RoundPollInterval = 500 * time.Millisecond
+
+var ErrNoSharders = errors.New("no sharders to watch rounds of")
+
+// RoundWatcher follows the latest finalized block of the network for all the tests waiting on rounds.
+// It polls only while someone waits, moving on to the next sharder whenever one fails.
+type RoundWatcher struct {
+	sharders []string
+	client   *http.Client
+
+	mu      sync.Mutex
+	sharder int // index of the sharder polled
+	latest  *model.LatestFinalizedBlock
+	pollErr error
+	updated chan struct{} // closed and replaced after every poll
+	waiters int
+	polling bool
+
+	configMu        sync.Mutex
+	epoch           int64
+	rewardFrequency int64
+}
+
+var (
+	roundWatcherMu sync.Mutex
+	roundWatcher   *RoundWatcher
+)
+
+// Rounds returns the round watcher shared by the whole test process, created on first use
+// with the base URLs sharders returns
+func Rounds(sharders func() []string) *RoundWatcher {
+	roundWatcherMu.Lock()
+	defer roundWatcherMu.Unlock()
+	if roundWatcher == nil {
+		roundWatcher = NewRoundWatcher(sharders())
+	}
+
+	return roundWatcher
+}
+
+func NewRoundWatcher(sharderBaseURLs []string) *RoundWatcher {
+	return &RoundWatcher{
+		sharders: sharderBaseURLs,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		updated:  make(chan struct{}),
+	}
+}
+
+// Latest polls the latest finalized block right away
+func (w *RoundWatcher) Latest(ctx context.Context) (*model.LatestFinalizedBlock, error) {
+	w.poll(ctx)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.latest == nil {
+		return nil, w.pollErr
+	}
+
+	return w.latest, nil
+}
+
+// WaitForRound waits until round is finalized and returns the latest finalized block from then
+func (w *RoundWatcher) WaitForRound(ctx context.Context, round int64) (*model.LatestFinalizedBlock, error) {
+	w.subscribe()
+	defer w.unsubscribe()
+
+	for {
+		w.mu.Lock()
+		latest, pollErr, updated := w.latest, w.pollErr, w.updated
+		w.mu.Unlock()
+		if latest != nil && latest.Round >= round {
+			return latest, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if latest == nil {
+				return nil, fmt.Errorf("waiting for round %d: %w, last poll: %v", round, ctx.Err(), pollErr)
+			}
+			return latest, fmt.Errorf("waiting for round %d at round %d: %w", round, latest.Round, ctx.Err())
+		case <-updated:
+		}
+	}
+}
+
+// WaitForRounds waits until n more rounds are finalized
+func (w *RoundWatcher) WaitForRounds(ctx context.Context, n int64) (*model.LatestFinalizedBlock, error) {
+	latest, err := w.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.WaitForRound(ctx, latest.Round+n)
+}
+
+// WaitForEpochBoundary waits until the first round of epoch is finalized, epochs being as long as the epoch of the minersc config
+func (w *RoundWatcher) WaitForEpochBoundary(ctx context.Context, epoch int64) (*model.LatestFinalizedBlock, error) {
+	length, _, err := w.minerSCConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.WaitForRound(ctx, epoch*length)
+}
+
+// WaitForRewardRound waits until the next round minersc pays rewards and activates stake pools in is finalized,
+// every reward_round_frequency of the minersc config
+func (w *RoundWatcher) WaitForRewardRound(ctx context.Context) (*model.LatestFinalizedBlock, error) {
+	_, frequency, err := w.minerSCConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := w.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.WaitForRound(ctx, (latest.Round/frequency+1)*frequency)
+}
+
+// Epoch returns the epoch round belongs to
+func (w *RoundWatcher) Epoch(ctx context.Context, round int64) (int64, error) {
+	length, _, err := w.minerSCConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return round / length, nil
+}
+
+func (w *RoundWatcher) subscribe() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.waiters++
+	if !w.polling {
+		w.polling = true
+		go w.pollWhileWaited()
+	}
+}
+
+func (w *RoundWatcher) unsubscribe() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.waiters--
+}
+
+func (w *RoundWatcher) pollWhileWaited() {
+	ticker := time.NewTicker(RoundPollInterval)
+	defer ticker.Stop()
+	for {
+		w.poll(context.Background())
+
+		w.mu.Lock()
+		if w.waiters == 0 {
+			w.polling = false
+			w.mu.Unlock()
+			return
+		}
+		w.mu.Unlock()
+		<-ticker.C
+	}
+}
+
+// poll reads the latest finalized block, failing over to the next sharders. Rounds never go back,
+// a sharder lagging behind the one polled before does not undo rounds already seen.
+func (w *RoundWatcher) poll(ctx context.Context) {
+	var block model.LatestFinalizedBlock
+	err := w.getFromSharders(ctx, "/v1/block/get/latest_finalized", &block)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pollErr = err
+	if err == nil && (w.latest == nil || block.Round > w.latest.Round) {
+		w.latest = &block
+	}
+	close(w.updated)
+	w.updated = make(chan struct{})
+}
+
+// minerSCConfig returns the epoch length and the reward round frequency, read once from the minersc config
+func (w *RoundWatcher) minerSCConfig(ctx context.Context) (epoch, rewardFrequency int64, err error) {
+	w.configMu.Lock()
+	defer w.configMu.Unlock()
+	if w.epoch > 0 {
+		return w.epoch, w.rewardFrequency, nil
+	}
+
+	var config struct {
+		Fields map[string]string `json:"fields"`
+	}
+	if err := w.getFromSharders(ctx, "/v1/screst/"+MinerScAddress+"/configs", &config); err != nil {
+		return 0, 0, err
+	}
+	if w.epoch, err = positiveConfigValue(config.Fields, "epoch"); err != nil {
+		return 0, 0, err
+	}
+	if w.rewardFrequency, err = positiveConfigValue(config.Fields, "reward_round_frequency"); err != nil {
+		w.epoch = 0
+		return 0, 0, err
+	}
+
+	return w.epoch, w.rewardFrequency, nil
+}
+
+func positiveConfigValue(fields map[string]string, key string) (int64, error) {
+	value, err := strconv.ParseInt(fields[key], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("minersc config %s [%s]: %w", key, fields[key], err)
+	}
+	if value <= 0 {
+		return 0, fmt.Errorf("minersc config %s is %d, it has to be positive", key, value)
+	}
+
+	return value, nil
+}
+
+// getFromSharders decodes the response of the first sharder to answer, starting from the last one which did
+func (w *RoundWatcher) getFromSharders(ctx context.Context, path string, target interface{}) error {
+	if len(w.sharders) == 0 {
+		return ErrNoSharders
+	}
+
+	w.mu.Lock()
+	first := w.sharder
+	w.mu.Unlock()
+
+	var errs []error
+	for i := range w.sharders {
+		index := (first + i) % len(w.sharders)
+		err := w.get(ctx, w.sharders[index]+path, target)
+		if err == nil {
+			w.mu.Lock()
+			w.sharder = index
+			w.mu.Unlock()
+			return nil
+		}
+		errs = append(errs, err)
+	}
+
+	return fmt.Errorf("all %d sharders failed: %v", len(w.sharders), errs)
+}
+
+func (w *RoundWatcher) get(ctx context.Context, url string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("reading response of [%s]: %w", url, err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("[%s] returned status code %d: %s", url, res.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("deserializing response of [%s] `%s`: %w", url, body, err)
+	}
+
+	return nil
+}
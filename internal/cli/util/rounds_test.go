@@ -0,0 +1,160 @@
+package cliutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testSharder serves the latest finalized round and the minersc config, or fails with an error status when down
+type testSharder struct {
+	mu       sync.Mutex
+	round    int64
+	down     bool
+	advance  bool // every request for the latest finalized block finalizes one more round
+	requests int
+}
+
+func (s *testSharder) set(round int64, down bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.round, s.down = round, down
+}
+
+func (s *testSharder) requested() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func (s *testSharder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	if s.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	switch r.URL.Path {
+	case "/v1/block/get/latest_finalized":
+		if s.advance {
+			s.round++
+		}
+		_, _ = fmt.Fprintf(w, `{"round":%d,"hash":"%x"}`, s.round, s.round)
+	case "/v1/screst/" + MinerScAddress + "/configs":
+		_, _ = w.Write([]byte(`{"fields":{"epoch":"100","reward_round_frequency":"25"}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestSharder(t *testing.T, sharder *testSharder) string {
+	server := httptest.NewServer(sharder)
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestRoundWatcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Polls should fail over to the next sharder and stick to the one which answered", func(t *testing.T) {
+		t.Parallel()
+
+		down, up := &testSharder{down: true}, &testSharder{round: 5}
+		w := NewRoundWatcher([]string{newTestSharder(t, down), newTestSharder(t, up)})
+
+		latest, err := w.Latest(context.Background())
+		require.Nil(t, err)
+		require.EqualValues(t, 5, latest.Round)
+
+		_, err = w.Latest(context.Background())
+		require.Nil(t, err)
+		require.Equal(t, 1, down.requested(), "the failed sharder should not be polled again while the next one answers")
+		require.Equal(t, 2, up.requested())
+	})
+
+	t.Run("Rounds should never go back when a sharder lags behind", func(t *testing.T) {
+		t.Parallel()
+
+		ahead, behind := &testSharder{round: 10}, &testSharder{round: 7}
+		w := NewRoundWatcher([]string{newTestSharder(t, ahead), newTestSharder(t, behind)})
+
+		latest, err := w.Latest(context.Background())
+		require.Nil(t, err)
+		require.EqualValues(t, 10, latest.Round)
+
+		ahead.set(10, true)
+		latest, err = w.Latest(context.Background())
+		require.Nil(t, err)
+		require.EqualValues(t, 10, latest.Round)
+		require.Equal(t, 1, behind.requested())
+
+		behind.set(11, false)
+		latest, err = w.Latest(context.Background())
+		require.Nil(t, err)
+		require.EqualValues(t, 11, latest.Round)
+	})
+
+	t.Run("Latest should fail when every sharder fails and no round was seen", func(t *testing.T) {
+		t.Parallel()
+
+		w := NewRoundWatcher([]string{newTestSharder(t, &testSharder{down: true}), newTestSharder(t, &testSharder{down: true})})
+		latest, err := w.Latest(context.Background())
+		require.NotNil(t, err)
+		require.Nil(t, latest)
+
+		_, err = NewRoundWatcher(nil).Latest(context.Background())
+		require.True(t, errors.Is(err, ErrNoSharders), err)
+	})
+
+	t.Run("WaitForRound should return once the round is finalized", func(t *testing.T) {
+		t.Parallel()
+
+		w := NewRoundWatcher([]string{newTestSharder(t, &testSharder{round: 1, advance: true})})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		latest, err := w.WaitForRounds(ctx, 2)
+		require.Nil(t, err)
+		require.GreaterOrEqual(t, latest.Round, int64(4))
+	})
+
+	t.Run("WaitForRound should give up with the latest round once the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		w := NewRoundWatcher([]string{newTestSharder(t, &testSharder{round: 3})})
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		latest, err := w.WaitForRound(ctx, 100)
+		require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+		require.EqualValues(t, 3, latest.Round)
+	})
+
+	t.Run("Epochs should be read from the minersc config", func(t *testing.T) {
+		t.Parallel()
+
+		w := NewRoundWatcher([]string{newTestSharder(t, &testSharder{round: 260})})
+
+		epoch, err := w.Epoch(context.Background(), 250)
+		require.Nil(t, err)
+		require.EqualValues(t, 2, epoch)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		latest, err := w.WaitForRewardRound(ctx)
+		require.True(t, errors.Is(err, context.DeadlineExceeded), "round 275 should not be finalized: %v", err)
+		require.EqualValues(t, 260, latest.Round)
+	})
+}
@@ -53,32 +53,9 @@ func init() {
 //Coin - any quantity that is represented as an integer in the lowest denomination
 type Coin uint64
 
+// ParseZCN converts a float amount of ZCN, ParseCoin parses amounts without the float imprecision
 func ParseZCN(c float64) (Coin, error) {
-	d := decimal.NewFromFloat(c)
-	if d.Sign() == -1 {
-		return 0, ErrNegativeValue
-	}
-
-	// ZCN have a maximum of 10 decimal places
-	if d.Exponent() < -ZCNExponent {
-		return 0, ErrTooManyDecimals
-	}
-
-	// Multiply the coin balance by 1e10 to obtain coin amount
-	e := d.Shift(ZCNExponent)
-
-	// Check that there are no decimal places remaining. This error should not
-	// occur, because of the earlier check of ZCNExponent()
-	if e.Exponent() < 0 {
-		return 0, ErrTooManyDecimals
-	}
-
-	// Values greater than math.MaxInt64 will overflow after conversion to int64
-	if e.GreaterThan(maxDecimal) {
-		return 0, ErrTooLarge
-	}
-
-	return Coin(e.IntPart()), nil
+	return fromDecimal(decimal.NewFromFloat(c), ZCNExponent)
 }
 
 func (c Coin) ToZCN() (float64, error) {
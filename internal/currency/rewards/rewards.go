@@ -0,0 +1,183 @@
+package rewards
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/0chain/system_test/internal/currency"
+)
+
+var (
+	// ErrInvalidRatio is returned if a service charge, share ratio or decline rate is not between 0 and 1
+	ErrInvalidRatio = errors.New("ratio must be between 0 and 1")
+	// ErrInvalidEpoch is returned if epochs are not at least one round long
+	ErrInvalidEpoch = errors.New("epoch must be at least one round")
+	// ErrNoShares is returned if a reward is shared among nobody
+	ErrNoShares = errors.New("cannot share among no one")
+)
+
+// Delegate is a stake pool of a provider, it is rewarded in proportion of its stake
+type Delegate struct {
+	ID    string
+	Stake currency.Coin
+}
+
+// Split is how a reward paid to a provider ends up shared between the provider and its delegates
+type Split struct {
+	// Provider is the service charge of the provider, or all of the reward if nobody stakes on it
+	Provider currency.Coin
+	// Delegates is the reward of every delegate by ID
+	Delegates map[string]currency.Coin
+	// Unpaid is what rounding the reward of every delegate down to the SAS leaves, nobody is paid it
+	Unpaid currency.Coin
+}
+
+// SplitReward splits reward the way stake pools distribute rewards. The provider takes its service charge
+// rounded down, then every delegate takes its share of the rest in proportion of its stake, rounded down too.
+// The chain computes those shares through float64 ratios and so does SplitReward.
+// If there are no delegates or none of them stakes anything, the provider takes all of the reward.
+func SplitReward(reward currency.Coin, serviceCharge float64, delegates []Delegate) (*Split, error) {
+	if err := checkRatio("service charge", serviceCharge); err != nil {
+		return nil, err
+	}
+
+	split := &Split{Delegates: make(map[string]currency.Coin, len(delegates))}
+	var totalStake currency.Coin
+	for _, delegate := range delegates {
+		var err error
+		if totalStake, err = currency.AddCoin(totalStake, delegate.Stake); err != nil {
+			return nil, fmt.Errorf("summing stakes: %w", err)
+		}
+	}
+	if totalStake == 0 {
+		split.Provider = reward
+		for _, delegate := range delegates {
+			split.Delegates[delegate.ID] = 0
+		}
+		return split, nil
+	}
+
+	charge, err := currency.MultFloat64(reward, serviceCharge)
+	if err != nil {
+		return nil, fmt.Errorf("service charge %v of %d: %w", serviceCharge, reward, err)
+	}
+	split.Provider = charge
+	left := reward - charge
+
+	// sorted so that Unpaid does not depend on the order the delegates were listed in
+	sorted := append([]Delegate(nil), delegates...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	paid := charge
+	for _, delegate := range sorted {
+		share, err := currency.MultFloat64(left, float64(delegate.Stake)/float64(totalStake))
+		if err != nil {
+			return nil, fmt.Errorf("reward of delegate %s: %w", delegate.ID, err)
+		}
+		if split.Delegates[delegate.ID], err = currency.AddCoin(split.Delegates[delegate.ID], share); err != nil {
+			return nil, fmt.Errorf("reward of delegate %s: %w", delegate.ID, err)
+		}
+		if paid, err = currency.AddCoin(paid, share); err != nil {
+			return nil, fmt.Errorf("summing rewards: %w", err)
+		}
+	}
+	if paid > reward {
+		return nil, fmt.Errorf("rewards of %d add up to %d: %w", reward, paid, currency.ErrUint64MinusOverflow)
+	}
+	split.Unpaid = reward - paid
+
+	return split, nil
+}
+
+// Add adds the rewards of other to s, to total rewards paid over several rounds
+func (s *Split) Add(other *Split) error {
+	var err error
+	if s.Provider, err = currency.AddCoin(s.Provider, other.Provider); err != nil {
+		return err
+	}
+	if s.Unpaid, err = currency.AddCoin(s.Unpaid, other.Unpaid); err != nil {
+		return err
+	}
+	if s.Delegates == nil {
+		s.Delegates = make(map[string]currency.Coin, len(other.Delegates))
+	}
+	for id, reward := range other.Delegates {
+		if s.Delegates[id], err = currency.AddCoin(s.Delegates[id], reward); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ShareEvenly shares reward among n providers like the chain shares the sharders part of a block:
+// every one of them gets the same share rounded down, the remainder goes to nobody
+func ShareEvenly(reward currency.Coin, n int) (share, remainder currency.Coin, err error) {
+	if n <= 0 {
+		return 0, 0, fmt.Errorf("%w: %d", ErrNoShares, n)
+	}
+
+	return currency.DistributeCoin(reward, int64(n))
+}
+
+// ShareRatio splits reward between the generator of a block and its sharders by the share_ratio of the minersc config,
+// the generator gets its share rounded down and the sharders the rest
+func ShareRatio(reward currency.Coin, shareRatio float64) (generator, sharders currency.Coin, err error) {
+	if err := checkRatio("share ratio", shareRatio); err != nil {
+		return 0, 0, err
+	}
+	if generator, err = currency.MultFloat64(reward, shareRatio); err != nil {
+		return 0, 0, fmt.Errorf("share %v of %d: %w", shareRatio, reward, err)
+	}
+	if sharders, err = currency.MinusCoin(reward, generator); err != nil {
+		return 0, 0, err
+	}
+
+	return generator, sharders, nil
+}
+
+// Declined returns reward after declining it by declineRate every epoch up to epoch, the first epoch being 0
+func Declined(reward currency.Coin, declineRate float64, epoch int64) (currency.Coin, error) {
+	if err := checkRatio("decline rate", declineRate); err != nil {
+		return 0, err
+	}
+	if epoch < 0 {
+		return 0, fmt.Errorf("%w: epoch %d", ErrInvalidEpoch, epoch)
+	}
+
+	return currency.MultFloat64(reward, math.Pow(1-declineRate, float64(epoch)))
+}
+
+// BlockRewardConfig is the part of the minersc config block rewards are paid by
+type BlockRewardConfig struct {
+	// BlockReward is block_reward, the reward of a block of the first epoch
+	BlockReward currency.Coin
+	// ShareRatio is share_ratio, the part of block rewards and fees going to the generator of the block
+	ShareRatio float64
+	// DeclineRate is reward_decline_rate, how much block rewards decline from one epoch to the next
+	DeclineRate float64
+	// Epoch is epoch, how many rounds an epoch lasts
+	Epoch int64
+}
+
+// Rewards returns the rewards of the generator of the block of round and the rewards all sharders share
+func (c *BlockRewardConfig) Rewards(round int64) (generator, sharders currency.Coin, err error) {
+	if c.Epoch <= 0 {
+		return 0, 0, fmt.Errorf("%w: %d", ErrInvalidEpoch, c.Epoch)
+	}
+	reward, err := Declined(c.BlockReward, c.DeclineRate, round/c.Epoch)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return ShareRatio(reward, c.ShareRatio)
+}
+
+func checkRatio(name string, ratio float64) error {
+	if ratio < 0 || ratio > 1 || math.IsNaN(ratio) {
+		return fmt.Errorf("%w: %s %v", ErrInvalidRatio, name, ratio)
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package rewards
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/0chain/system_test/internal/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitReward(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name          string
+		reward        currency.Coin
+		serviceCharge float64
+		delegates     []Delegate
+		expected      Split
+	}{
+		{
+			name:          "no delegates",
+			reward:        100,
+			serviceCharge: 0.1,
+			expected:      Split{Provider: 100, Delegates: map[string]currency.Coin{}},
+		},
+		{
+			name:          "delegates which stake nothing",
+			reward:        100,
+			serviceCharge: 0.1,
+			delegates:     []Delegate{{ID: "a"}, {ID: "b"}},
+			expected:      Split{Provider: 100, Delegates: map[string]currency.Coin{"a": 0, "b": 0}},
+		},
+		{
+			name:          "delegates of different stakes",
+			reward:        100,
+			serviceCharge: 0.1,
+			delegates:     []Delegate{{ID: "a", Stake: 1}, {ID: "b", Stake: 4}},
+			expected:      Split{Provider: 10, Delegates: map[string]currency.Coin{"a": 18, "b": 72}},
+		},
+		{
+			name:          "a service charge which does not divide the reward",
+			reward:        10,
+			serviceCharge: 0.25,
+			delegates:     []Delegate{{ID: "a", Stake: 5}, {ID: "b", Stake: 5}},
+			expected:      Split{Provider: 2, Delegates: map[string]currency.Coin{"a": 4, "b": 4}},
+		},
+		{
+			name:      "shares which do not divide the reward",
+			reward:    100,
+			delegates: []Delegate{{ID: "a", Stake: 1}, {ID: "b", Stake: 1}, {ID: "c", Stake: 2}},
+			expected:  Split{Delegates: map[string]currency.Coin{"a": 25, "b": 25, "c": 50}},
+		},
+		{
+			name:      "a remainder nobody is paid",
+			reward:    7,
+			delegates: []Delegate{{ID: "a", Stake: 1}, {ID: "b", Stake: 1}},
+			expected:  Split{Delegates: map[string]currency.Coin{"a": 3, "b": 3}, Unpaid: 1},
+		},
+		{
+			name:          "a full service charge",
+			reward:        100,
+			serviceCharge: 1,
+			delegates:     []Delegate{{ID: "a", Stake: 1}},
+			expected:      Split{Provider: 100, Delegates: map[string]currency.Coin{"a": 0}},
+		},
+		{
+			name:      "several pools of the same delegate",
+			reward:    90,
+			delegates: []Delegate{{ID: "a", Stake: 1}, {ID: "b", Stake: 1}, {ID: "a", Stake: 1}},
+			expected:  Split{Delegates: map[string]currency.Coin{"a": 60, "b": 30}},
+		},
+	} {
+		test := test
+		t.Run("SplitReward should split a reward given "+test.name, func(t *testing.T) {
+			t.Parallel()
+
+			split, err := SplitReward(test.reward, test.serviceCharge, test.delegates)
+			require.Nil(t, err)
+			require.Equal(t, test.expected, *split)
+
+			total := split.Provider + split.Unpaid
+			for _, reward := range split.Delegates {
+				total += reward
+			}
+			require.Equal(t, test.reward, total, "the split should add up to the reward")
+		})
+	}
+
+	t.Run("SplitReward should not depend on the order of the delegates", func(t *testing.T) {
+		t.Parallel()
+
+		delegates := []Delegate{{ID: "a", Stake: 3}, {ID: "b", Stake: 7}, {ID: "c", Stake: 11}}
+		expected, err := SplitReward(1000003, 0.13, delegates)
+		require.Nil(t, err)
+
+		reversed, err := SplitReward(1000003, 0.13, []Delegate{delegates[2], delegates[1], delegates[0]})
+		require.Nil(t, err)
+		require.Equal(t, expected, reversed)
+	})
+
+	t.Run("SplitReward should reject service charges which are not ratios", func(t *testing.T) {
+		t.Parallel()
+
+		for _, serviceCharge := range []float64{-0.1, 1.1, math.NaN()} {
+			_, err := SplitReward(100, serviceCharge, nil)
+			require.True(t, errors.Is(err, ErrInvalidRatio), err)
+		}
+	})
+}
+
+func TestSplitAdd(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Add should add up the rewards of every part of the split", func(t *testing.T) {
+		t.Parallel()
+
+		var total Split
+		require.Nil(t, total.Add(&Split{Provider: 1, Delegates: map[string]currency.Coin{"a": 2}, Unpaid: 1}))
+		require.Nil(t, total.Add(&Split{Provider: 3, Delegates: map[string]currency.Coin{"a": 4, "b": 5}}))
+		require.Equal(t, Split{Provider: 4, Delegates: map[string]currency.Coin{"a": 6, "b": 5}, Unpaid: 1}, total)
+	})
+
+	t.Run("Add should fail on overflow", func(t *testing.T) {
+		t.Parallel()
+
+		total := Split{Provider: math.MaxUint64}
+		require.True(t, errors.Is(total.Add(&Split{Provider: 1}), currency.ErrUint64AddOverflow))
+	})
+}
+
+func TestShareEvenly(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name      string
+		reward    currency.Coin
+		n         int
+		share     currency.Coin
+		remainder currency.Coin
+	}{
+		{name: "a reward it divides", reward: 99, n: 3, share: 33},
+		{name: "a reward it does not divide", reward: 100, n: 3, share: 33, remainder: 1},
+		{name: "a reward smaller than the number of shares", reward: 2, n: 5, remainder: 2},
+		{name: "a single share", reward: 7, n: 1, share: 7},
+	} {
+		test := test
+		t.Run("ShareEvenly should share "+test.name, func(t *testing.T) {
+			t.Parallel()
+
+			share, remainder, err := ShareEvenly(test.reward, test.n)
+			require.Nil(t, err)
+			require.Equal(t, test.share, share)
+			require.Equal(t, test.remainder, remainder)
+		})
+	}
+
+	t.Run("ShareEvenly should refuse to share among nobody", func(t *testing.T) {
+		t.Parallel()
+
+		for _, n := range []int{0, -1} {
+			_, _, err := ShareEvenly(100, n)
+			require.True(t, errors.Is(err, ErrNoShares), err)
+		}
+	})
+}
+
+func TestDeclined(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Declined should decline the reward every epoch", func(t *testing.T) {
+		t.Parallel()
+
+		for epoch, expected := range []currency.Coin{1000, 500, 250, 125, 62, 31} {
+			declined, err := Declined(1000, 0.5, int64(epoch))
+			require.Nil(t, err)
+			require.Equal(t, expected, declined, "epoch %d", epoch)
+		}
+	})
+
+	t.Run("Declined should keep the reward without a decline rate and drop it with a full one", func(t *testing.T) {
+		t.Parallel()
+
+		declined, err := Declined(1000, 0, 10)
+		require.Nil(t, err)
+		require.Equal(t, currency.Coin(1000), declined)
+
+		declined, err = Declined(1000, 1, 0)
+		require.Nil(t, err)
+		require.Equal(t, currency.Coin(1000), declined)
+
+		declined, err = Declined(1000, 1, 1)
+		require.Nil(t, err)
+		require.Zero(t, declined)
+	})
+
+	t.Run("Declined should reject invalid decline rates and epochs", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Declined(1000, 1.5, 1)
+		require.True(t, errors.Is(err, ErrInvalidRatio), err)
+		_, err = Declined(1000, 0.5, -1)
+		require.True(t, errors.Is(err, ErrInvalidEpoch), err)
+	})
+}
+
+func TestBlockRewardConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Rewards should decline with the epoch of the round and be split by the share ratio", func(t *testing.T) {
+		t.Parallel()
+
+		config := &BlockRewardConfig{BlockReward: 1000, ShareRatio: 0.8, DeclineRate: 0.5, Epoch: 10}
+		for round, expected := range map[int64][2]currency.Coin{
+			0:  {800, 200},
+			9:  {800, 200},
+			10: {400, 100},
+			25: {200, 50},
+			30: {100, 25},
+		} {
+			generator, sharders, err := config.Rewards(round)
+			require.Nil(t, err)
+			require.Equal(t, expected, [2]currency.Coin{generator, sharders}, "round %d", round)
+		}
+	})
+
+	t.Run("Rewards should give the sharders whatever the generator share leaves", func(t *testing.T) {
+		t.Parallel()
+
+		config := &BlockRewardConfig{BlockReward: 1001, ShareRatio: 0.5, Epoch: 10}
+		generator, sharders, err := config.Rewards(1)
+		require.Nil(t, err)
+		require.Equal(t, currency.Coin(500), generator)
+		require.Equal(t, currency.Coin(501), sharders)
+	})
+
+	t.Run("Rewards should reject epochs shorter than a round", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := (&BlockRewardConfig{BlockReward: 1000}).Rewards(1)
+		require.True(t, errors.Is(err, ErrInvalidEpoch), err)
+	})
+}
@@ -0,0 +1,150 @@
+package currency
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+)
+
+// Unit is a denomination of ZCN as the CLIs print it
+type Unit string
+
+const (
+	ZCN  Unit = "ZCN"
+	MZCN Unit = "mZCN"
+	UZCN Unit = "uZCN"
+	SAS  Unit = "SAS"
+)
+
+var (
+	// ErrUnknownUnit is returned if a value is given in a unit other than ZCN, mZCN, uZCN or SAS
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrInvalidCoin is returned if a value cannot be parsed as an amount
+	ErrInvalidCoin = errors.New("invalid coin value")
+)
+
+// units from the largest to the smallest, String uses the first one not exceeding the value
+var units = []Unit{ZCN, MZCN, UZCN, SAS}
+
+// Exponent returns the power of ten one u is worth in SAS
+func (u Unit) Exponent() (int32, error) {
+	switch u {
+	case ZCN, "zcn":
+		return ZCNExponent, nil
+	case MZCN, "mzcn":
+		return MZCNExponent, nil
+	case UZCN, "uzcn", "µZCN":
+		return UZCNExponent, nil
+	case SAS, "sas":
+		return 0, nil
+	}
+
+	return 0, fmt.Errorf("%w [%s]", ErrUnknownUnit, string(u))
+}
+
+// ParseCoin parses a value followed by its unit such as "1.5 ZCN" or "250 mZCN", the space is optional.
+// A value without a unit is in SAS. It is exact, unlike ParseZCN which goes through a float.
+func ParseCoin(s string) (Coin, error) {
+	s = strings.TrimSpace(s)
+	split := strings.LastIndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) }) + 1
+	value, unit := strings.TrimSpace(s[:split]), Unit(s[split:])
+	if unit == "" {
+		unit = SAS
+	}
+
+	exponent, err := unit.Exponent()
+	if err != nil {
+		return 0, err
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w [%s]: %v", ErrInvalidCoin, s, err)
+	}
+
+	return fromDecimal(d, exponent)
+}
+
+// fromDecimal converts d, an amount in the unit worth 10^exponent SAS, into SAS
+func fromDecimal(d decimal.Decimal, exponent int32) (Coin, error) {
+	if d.Sign() == -1 {
+		return 0, ErrNegativeValue
+	}
+
+	e := d.Shift(exponent)
+	if !e.Equal(e.Truncate(0)) {
+		return 0, ErrTooManyDecimals
+	}
+
+	// Values greater than math.MaxInt64 will overflow after conversion to int64
+	if e.GreaterThan(maxDecimal) {
+		return 0, ErrTooLarge
+	}
+
+	return Coin(e.IntPart()), nil
+}
+
+// Format prints c in unit without losing precision, e.g. "1.5 ZCN"
+func (c Coin) Format(unit Unit) (string, error) {
+	amount, err := c.FormatAmount(unit)
+	if err != nil {
+		return "", err
+	}
+
+	return amount + " " + string(unit), nil
+}
+
+// FormatAmount prints c in unit without the unit, e.g. "1.5" for the --tokens flag of the CLIs, which take ZCN
+func (c Coin) FormatAmount(unit Unit) (string, error) {
+	exponent, err := unit.Exponent()
+	if err != nil {
+		return "", err
+	}
+
+	return decimal.NewFromBigInt(new(big.Int).SetUint64(uint64(c)), -exponent).String(), nil
+}
+
+// String prints c in the largest unit it is worth at least one of
+func (c Coin) String() string {
+	for _, unit := range units {
+		exponent, _ := unit.Exponent()
+		if c >= Coin(decimal.New(1, exponent).IntPart()) || unit == SAS {
+			s, _ := c.Format(unit)
+			return s
+		}
+	}
+
+	return ""
+}
+
+// UnmarshalJSON accepts amounts in SAS as JSON numbers as well as strings ParseCoin accepts
+func (c *Coin) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var (
+		parsed Coin
+		err    error
+	)
+	if len(data) > 0 && data[0] == '"' {
+		parsed, err = ParseCoin(strings.Trim(string(data), `"`))
+	} else {
+		var d decimal.Decimal
+		if d, err = decimal.NewFromString(string(data)); err != nil {
+			return fmt.Errorf("%w [%s]: %v", ErrInvalidCoin, data, err)
+		}
+		parsed, err = fromDecimal(d, 0)
+	}
+	if err != nil {
+		return err
+	}
+
+	*c = parsed
+	return nil
+}
@@ -0,0 +1,146 @@
+package currency
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCoin(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		input    string
+		expected Coin
+		err      error
+	}{
+		{input: "1.5 ZCN", expected: 15000000000},
+		{input: "250 mZCN", expected: 2500000000},
+		{input: "250mZCN", expected: 2500000000},
+		{input: "3 uZCN", expected: 30000},
+		{input: "3 µZCN", expected: 30000},
+		{input: "42 SAS", expected: 42},
+		{input: "0.5 zcn", expected: 5000000000},
+		{input: "  0.0000000001 ZCN ", expected: 1},
+		{input: "922337203.6854775807 ZCN", expected: math.MaxInt64},
+		{input: "42", expected: 42},
+		{input: "0", expected: 0},
+		{input: "1 XYZ", err: ErrUnknownUnit},
+		{input: "0.00000000001 ZCN", err: ErrTooManyDecimals},
+		{input: "0.00001 uZCN", err: ErrTooManyDecimals},
+		{input: "1.5", err: ErrTooManyDecimals},
+		{input: "-1 ZCN", err: ErrNegativeValue},
+		{input: "922337203.6854775808 ZCN", err: ErrTooLarge},
+		{input: "1000000000 ZCN", err: ErrTooLarge},
+		{input: "abc ZCN", err: ErrInvalidCoin},
+		{input: "ZCN", err: ErrInvalidCoin},
+		{input: "", err: ErrInvalidCoin},
+	} {
+		test := test
+		t.Run("ParseCoin should parse ["+test.input+"]", func(t *testing.T) {
+			t.Parallel()
+
+			coin, err := ParseCoin(test.input)
+			if test.err != nil {
+				require.True(t, errors.Is(err, test.err), "expected %v, got %v", test.err, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, test.expected, coin)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Format should print the value in the unit without losing precision", func(t *testing.T) {
+		t.Parallel()
+
+		coin := Coin(15000000001)
+		for unit, expected := range map[Unit]string{
+			ZCN:  "1.5000000001 ZCN",
+			MZCN: "1500.0000001 mZCN",
+			UZCN: "1500000.0001 uZCN",
+			SAS:  "15000000001 SAS",
+		} {
+			formatted, err := coin.Format(unit)
+			require.Nil(t, err)
+			require.Equal(t, expected, formatted)
+		}
+
+		amount, err := Coin(15000000000).FormatAmount(ZCN)
+		require.Nil(t, err)
+		require.Equal(t, "1.5", amount)
+	})
+
+	t.Run("Format should reject unknown units", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Coin(1).Format("XYZ")
+		require.True(t, errors.Is(err, ErrUnknownUnit), err)
+	})
+
+	t.Run("String should use the largest unit the value is worth at least one of", func(t *testing.T) {
+		t.Parallel()
+
+		for coin, expected := range map[Coin]string{
+			0:             "0 SAS",
+			9999:          "9999 SAS",
+			10000:         "1 uZCN",
+			5000000:       "500 uZCN",
+			10000000:      "1 mZCN",
+			9999999999:    "999.9999999 mZCN",
+			15000000000:   "1.5 ZCN",
+			math.MaxInt64: "922337203.6854775807 ZCN",
+		} {
+			require.Equal(t, expected, coin.String())
+
+			parsed, err := ParseCoin(coin.String())
+			require.Nil(t, err)
+			require.Equal(t, coin, parsed)
+		}
+	})
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		input    string
+		expected Coin
+		err      error
+	}{
+		{input: `42`, expected: 42},
+		{input: `"1.5 ZCN"`, expected: 15000000000},
+		{input: `"250 mZCN"`, expected: 2500000000},
+		{input: `"42"`, expected: 42},
+		{input: `null`, expected: 7},
+		{input: `1.5`, err: ErrTooManyDecimals},
+		{input: `-1`, err: ErrNegativeValue},
+		{input: `18446744073709551615`, err: ErrTooLarge},
+		{input: `"1 XYZ"`, err: ErrUnknownUnit},
+		{input: `true`, err: ErrInvalidCoin},
+	} {
+		test := test
+		t.Run("UnmarshalJSON should decode "+test.input, func(t *testing.T) {
+			t.Parallel()
+
+			// null leaves the value alone, like it does for the other types
+			value := struct {
+				Fee Coin `json:"fee"`
+			}{Fee: 7}
+			err := json.Unmarshal([]byte(`{"fee":`+test.input+`}`), &value)
+			if test.err != nil {
+				require.True(t, errors.Is(err, test.err), "expected %v, got %v", test.err, err)
+				require.Equal(t, Coin(7), value.Fee)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, test.expected, value.Fee)
+		})
+	}
+}
@@ -1,9 +1,9 @@
 package api_tests
 
 import (
-	"encoding/json"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract/storagesc"
 	"github.com/0chain/system_test/internal/api/util/endpoint"
 	"github.com/stretchr/testify/require"
 	"math/rand"
@@ -174,24 +174,7 @@ func getAllocationUpdate(allocationID string, newBlobberID, oldBlobberID string)
 }
 
 func updateAllocation(t *testing.T, wallet *model.Wallet, keyPair *model.KeyPair, allocationUpdate *model.AllocationUpdate) (*model.TransactionResponse, *model.Confirmation) {
-	txnDataString, err := json.Marshal(model.SmartContractTxnData{Name: "update_allocation_request", InputArgs: allocationUpdate})
-	require.Nil(t, err)
-
-	updateAllocationRequest := model.Transaction{
-		PublicKey:        wallet.ClientKey,
-		TxnOutputHash:    "",
-		TransactionValue: 1000000000,
-		TransactionType:  1000,
-		TransactionFee:   0,
-		TransactionData:  string(txnDataString),
-		ToClientId:       endpoint.StorageSmartContractAddress,
-		CreationDate:     time.Now().Unix(),
-		ClientId:         wallet.ClientID,
-		Version:          "1.0",
-		TransactionNonce: wallet.Nonce + 1,
-	}
-
-	addBlobberTransaction := executeTransaction(t, &updateAllocationRequest, keyPair)
+	addBlobberTransaction := executeTransaction(t, storagesc.UpdateAllocation(allocationUpdate, 1000000000), keyPair)
 	confirmation, _ := confirmTransaction(t, wallet, addBlobberTransaction.Entity, 5*time.Minute)
 
 	return addBlobberTransaction, confirmation
@@ -0,0 +1,105 @@
+package api_tests
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/blobber"
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobberFileOperations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Uploaded file should be listed and downloaded unchanged after commit", func(t *testing.T) {
+		t.Parallel()
+
+		registeredWallet, keyPair, allocation := createAllocationWithFunds(t)
+		storageBlobber := blobber.Blobber{ID: allocation.Blobbers[0].ID, URL: allocation.Blobbers[0].Baseurl}
+		client := blobber.NewClient(zeroChain.Zerochain, registeredWallet.ClientID, keyPair)
+		ctx := endpoint.Context(t)
+
+		content := make([]byte, 3*blobber.BlockSize+1024)
+		_, err := rand.Read(content)
+		require.Nil(t, err)
+
+		connection := client.NewConnection(storageBlobber, allocation.ID)
+		uploadResponse, err := connection.Upload(ctx, "/dir/file.bin", bytes.NewReader(content))
+		require.Nil(t, err)
+		require.Equal(t, blobber.ContentHash(content), uploadResponse.Hash)
+
+		commitResponse, err := connection.Commit(ctx)
+		require.Nil(t, err)
+		require.True(t, commitResponse.Success, commitResponse.ErrorMessage)
+
+		listResponse, err := client.List(ctx, storageBlobber, allocation.ID, "/dir")
+		require.Nil(t, err)
+		require.Equal(t, commitResponse.AllocationRoot, listResponse.AllocationRoot)
+		require.Len(t, listResponse.List, 1)
+		require.Equal(t, "file.bin", listResponse.List[0].Name)
+		require.Equal(t, int64(len(content)), listResponse.List[0].Size)
+		require.Equal(t, int64(4), listResponse.List[0].NumBlocks)
+
+		downloaded, err := client.DownloadFile(ctx, storageBlobber, allocation.ID, registeredWallet.ClientID, "/dir/file.bin")
+		require.Nil(t, err)
+		require.Equal(t, content, downloaded)
+	})
+
+	t.Run("Second commit should chain onto the allocation root of the first", func(t *testing.T) {
+		t.Parallel()
+
+		registeredWallet, keyPair, allocation := createAllocationWithFunds(t)
+		storageBlobber := blobber.Blobber{ID: allocation.Blobbers[0].ID, URL: allocation.Blobbers[0].Baseurl}
+		client := blobber.NewClient(zeroChain.Zerochain, registeredWallet.ClientID, keyPair)
+		ctx := endpoint.Context(t)
+
+		connection := client.NewConnection(storageBlobber, allocation.ID)
+		_, err := connection.Upload(ctx, "/first.txt", bytes.NewReader([]byte("first")))
+		require.Nil(t, err)
+		firstCommit, err := connection.Commit(ctx)
+		require.Nil(t, err)
+
+		connection = client.NewConnection(storageBlobber, allocation.ID)
+		_, err = connection.Upload(ctx, "/second.txt", bytes.NewReader([]byte("second")))
+		require.Nil(t, err)
+		secondCommit, err := connection.Commit(ctx)
+		require.Nil(t, err)
+		require.Equal(t, firstCommit.AllocationRoot, secondCommit.WriteMarker.PreviousAllocationRoot)
+		require.Nil(t, crypto.VerifyWriteMarker(&secondCommit.WriteMarker, keyPair.PublicKey, keyPair.Scheme))
+		require.Nil(t, crypto.VerifyWriteMarkerOrder(&firstCommit.WriteMarker, &secondCommit.WriteMarker))
+		require.ErrorIs(t, crypto.VerifyWriteMarkerOrder(&secondCommit.WriteMarker, &firstCommit.WriteMarker), crypto.ErrInvalidMarker)
+
+		tampered := secondCommit.WriteMarker
+		tampered.Size++
+		require.ErrorIs(t, crypto.VerifyWriteMarker(&tampered, keyPair.PublicKey, keyPair.Scheme), crypto.ErrInvalidMarker)
+
+		referencePath, err := client.ReferencePath(ctx, storageBlobber, allocation.ID, "/")
+		require.Nil(t, err)
+		require.NotNil(t, referencePath.LatestWM)
+		require.Equal(t, secondCommit.AllocationRoot, referencePath.LatestWM.AllocationRoot)
+		require.Len(t, referencePath.List, 2)
+	})
+}
+
+func createAllocationWithFunds(t *testing.T) (*model.Wallet, *model.KeyPair, *model.Allocation) {
+	registeredWallet, keyPair := registerWallet(t)
+	faucetResponse, confirmation := executeFaucet(t, registeredWallet, keyPair)
+	require.NotNil(t, faucetResponse)
+	require.Equal(t, endpoint.TxSuccessfulStatus, confirmation.Status, confirmation.Transaction.TransactionOutput)
+
+	blobbers, blobberRequirements := getBlobbersMatchingRequirements(t, registeredWallet, keyPair, 10000, 1, 1, time.Minute*20)
+	blobberRequirements.Blobbers = blobbers
+	allocationResponse, confirmation := createAllocation(t, registeredWallet, keyPair, blobberRequirements)
+	require.Equal(t, endpoint.TxSuccessfulStatus, confirmation.Status, confirmation.Transaction.TransactionOutput)
+
+	allocation := getAllocation(t, allocationResponse.Entity.Hash)
+	require.NotNil(t, allocation)
+	require.NotEmpty(t, allocation.Blobbers)
+
+	return registeredWallet, keyPair, allocation
+}
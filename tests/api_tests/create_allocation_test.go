@@ -1,7 +1,6 @@
 package api_tests
 
 import (
-	"encoding/json"
 	"github.com/0chain/system_test/internal/api/util/endpoint"
 	"github.com/0chain/system_test/internal/api/util/wait"
 	"testing"
@@ -10,6 +9,7 @@ import (
 	"github.com/go-resty/resty/v2" //nolint
 
 	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract/storagesc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,24 +36,7 @@ func TestCreateAllocation(t *testing.T) {
 
 func createAllocation(t *testing.T, wallet *model.Wallet, keyPair *model.KeyPair, blobberRequirements model.BlobberRequirements) (*model.TransactionResponse, *model.Confirmation) {
 	t.Logf("Creating allocation...")
-	txnDataString, err := json.Marshal(model.SmartContractTxnData{Name: "new_allocation_request", InputArgs: blobberRequirements})
-	require.Nil(t, err)
-
-	allocationRequest := model.Transaction{
-		PublicKey:        keyPair.PublicKey.SerializeToHexStr(),
-		TxnOutputHash:    "",
-		TransactionValue: 1000000000,
-		TransactionType:  1000,
-		TransactionFee:   0,
-		TransactionData:  string(txnDataString),
-		ToClientId:       endpoint.StorageSmartContractAddress,
-		CreationDate:     time.Now().Unix(),
-		ClientId:         wallet.ClientID,
-		Version:          "1.0",
-		TransactionNonce: wallet.Nonce + 1,
-	}
-
-	allocationTransaction := executeTransaction(t, &allocationRequest, keyPair)
+	allocationTransaction := executeTransaction(t, storagesc.NewAllocation(&blobberRequirements, 1000000000), keyPair)
 
 	confirmation, _ := confirmTransaction(t, wallet, allocationTransaction.Entity, 2*time.Minute)
 	return allocationTransaction, confirmation
@@ -61,19 +44,20 @@ func createAllocation(t *testing.T, wallet *model.Wallet, keyPair *model.KeyPair
 
 func getAllocation(t *testing.T, allocationId string) *model.Allocation {
 	var (
-		allocation   *model.Allocation
 		httpResponse *resty.Response //nolint
-		err          error
+		requestErr   error
 	)
 
-	wait.PoolImmediately(5*time.Minute, func() bool {
-		allocation, httpResponse, err = getAllocationWithoutAssertion(t, allocationId)
+	allocation, err := wait.Until(endpoint.Context(t), func() (*model.Allocation, bool, error) {
+		var allocation *model.Allocation
+		allocation, httpResponse, requestErr = getAllocationWithoutAssertion(t, allocationId)
 
-		return httpResponse.Status() == endpoint.HttpOkStatus
-	})
+		return allocation, httpResponse != nil && httpResponse.Status() == endpoint.HttpOkStatus, nil
+	}, wait.Options{Timeout: 5 * time.Minute, Jitter: 0.2})
 
 	require.NotNil(t, allocation, "Allocation was unexpectedly nil! with http response [%s]", httpResponse)
-	require.Nil(t, err, "Unexpected error [%s] occurred getting balance with http response [%s]", err, httpResponse)
+	require.Nil(t, requestErr, "Unexpected error [%s] occurred getting allocation with http response [%s]", requestErr, httpResponse)
+	require.Nil(t, err, "Allocation [%s] was not found in time with http response [%s]", allocationId, httpResponse)
 	require.Equal(t, endpoint.HttpOkStatus, httpResponse.Status())
 
 	return allocation
@@ -38,6 +38,17 @@ func v1TransactionGetConfirmation(t *testing.T, hash string, consensusCategorise
 	return confirmation, httpResponse, httpError
 }
 
+func v1BlockGetHeader(t *testing.T, hash string, consensusCategoriser endpoint.ConsensusMetFunction) (*model.BlockSummary, *resty.Response, error) { //nolint
+	var block *model.BlockHeader
+
+	httpResponse, httpError := zeroChain.GetFromSharders(t, "/v1/block/get?content=header&block="+hash, consensusCategoriser, &block)
+	if block == nil {
+		return nil, httpResponse, httpError
+	}
+
+	return block.Header, httpResponse, httpError
+}
+
 func v1ClientGetBalance(t *testing.T, clientId string, consensusCategoriser endpoint.ConsensusMetFunction) (*model.Balance, *resty.Response, error) { //nolint
 	var balance *model.Balance
 
@@ -46,6 +57,14 @@ func v1ClientGetBalance(t *testing.T, clientId string, consensusCategoriser endp
 	return balance, httpResponse, httpError
 }
 
+func v1ClientGetBalanceWithQuorum(t *testing.T, clientId string, quorum endpoint.Quorum) (*model.Balance, *resty.Response, error) { //nolint
+	var balance *model.Balance
+
+	httpResponse, httpError := zeroChain.GetFromShardersWithQuorum(t, filepath.Join("/v1/client/get/balance?client_id=")+clientId, quorum, &balance)
+
+	return balance, httpResponse, httpError
+}
+
 func v1ScrestAllocation(t *testing.T, clientId string, consensusCategoriser endpoint.ConsensusMetFunction) (*model.Allocation, *resty.Response, error) { //nolint
 	var allocation *model.Allocation
 
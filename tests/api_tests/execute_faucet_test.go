@@ -2,18 +2,24 @@ package api_tests
 
 import (
 	"github.com/0chain/system_test/internal/api/util/endpoint"
-	"github.com/0chain/system_test/internal/api/util/tokenomics"
 	"github.com/0chain/system_test/internal/api/util/wait"
 	resty "github.com/go-resty/resty/v2" //nolint
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract/faucetsc"
+	"github.com/0chain/system_test/internal/api/transaction"
 	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/currency"
 	"github.com/stretchr/testify/require"
 )
 
+// faucetPourAmount is what executeFaucet pours, 1 ZCN
+const faucetPourAmount currency.Coin = 10000000000
+
 func TestExecuteFaucet(t *testing.T) {
 	t.Parallel()
 
@@ -27,7 +33,7 @@ func TestExecuteFaucet(t *testing.T) {
 		require.Equal(t, endpoint.TxSuccessfulStatus, confirmation.Status, confirmation.Transaction.TransactionOutput)
 
 		balance := getBalance(t, registeredWallet.ClientID)
-		require.Equal(t, tokenomics.IntToZCN(1), balance.Balance)
+		require.Equal(t, faucetPourAmount, balance.Balance)
 	})
 }
 
@@ -61,7 +67,12 @@ func confirmTransaction(t *testing.T, wallet *model.Wallet, sentTransaction mode
 
 	assertTransactionEquals(t, &sentTransaction, confirmation.Transaction)
 
-	wallet.Nonce++
+	block, blockResponse, err := v1BlockGetHeader(t, confirmation.BlockHash, endpoint.ConsensusByHttpStatus(endpoint.HttpOkStatus))
+	require.Nil(t, err, "Unexpected error [%s] occurred getting block [%s] with http response [%s]", err, confirmation.BlockHash, blockResponse)
+	require.NotNil(t, block, "Block [%s] was unexpectedly nil! with http response [%s]", confirmation.BlockHash, blockResponse)
+	require.Nil(t, crypto.VerifyConfirmation(confirmation, block), "Confirmation of [%s] is not authentic", confirmation.Hash)
+
+	wallet.Nonce = sentTransaction.TransactionNonce
 
 	return confirmation, httpResponse
 }
@@ -69,16 +80,19 @@ func confirmTransaction(t *testing.T, wallet *model.Wallet, sentTransaction mode
 func confirmTransactionWithoutAssertion(t *testing.T, hash string, maxPollDuration time.Duration, consensusCategoriser endpoint.ConsensusMetFunction) (*model.Confirmation, *resty.Response, error) { //nolint
 	t.Logf("Confirming transaction...")
 	var (
-		confirmation *model.Confirmation
 		httpResponse *resty.Response //nolint
-		err          error
+		requestErr   error
 	)
 
-	wait.PoolImmediately(maxPollDuration, func() bool {
-		confirmation, httpResponse, err = v1TransactionGetConfirmation(t, hash, consensusCategoriser)
+	confirmation, err := wait.Until(endpoint.Context(t), func() (*model.Confirmation, bool, error) {
+		var confirmation *model.Confirmation
+		confirmation, httpResponse, requestErr = v1TransactionGetConfirmation(t, hash, consensusCategoriser)
 
-		return httpResponse.StatusCode() == http.StatusOK
-	})
+		return confirmation, httpResponse != nil && httpResponse.StatusCode() == http.StatusOK, nil
+	}, wait.Options{Timeout: maxPollDuration, Jitter: 0.2})
+	if requestErr != nil {
+		err = requestErr
+	}
 
 	return confirmation, httpResponse, err
 }
@@ -95,27 +109,14 @@ func getBalance(t *testing.T, clientId string) *model.Balance {
 
 func getBalanceWithoutAssertion(t *testing.T, clientId string) (*model.Balance, *resty.Response, error) { //nolint
 	t.Logf("Getting balance...")
-	balance, httpResponse, err := v1ClientGetBalance(t, clientId, nil)
+	balance, httpResponse, err := v1ClientGetBalanceWithQuorum(t, clientId, endpoint.QuorumIgnoring("round"))
 	return balance, httpResponse, err
 }
 
 func executeFaucet(t *testing.T, wallet *model.Wallet, keyPair *model.KeyPair) (*model.TransactionResponse, *model.Confirmation) {
 	t.Logf("Executing faucet...")
 
-	faucetRequest := model.Transaction{
-		PublicKey:        keyPair.PublicKey.SerializeToHexStr(),
-		TxnOutputHash:    "",
-		TransactionValue: 10000000000,
-		TransactionType:  1000,
-		TransactionFee:   0,
-		TransactionData:  "{\"name\":\"pour\",\"input\":{},\"name\":null}",
-		ToClientId:       endpoint.FaucetSmartContractAddress,
-		CreationDate:     time.Now().Unix(),
-		ClientId:         wallet.ClientID,
-		Version:          "1.0",
-		TransactionNonce: wallet.Nonce + 1,
-	}
-	faucetTransaction := executeTransaction(t, &faucetRequest, keyPair)
+	faucetTransaction := executeTransaction(t, faucetsc.Pour(faucetPourAmount), keyPair)
 	confirmation, _ := confirmTransaction(t, wallet, faucetTransaction.Entity, 5*time.Minute)
 
 	return faucetTransaction, confirmation
@@ -152,10 +153,15 @@ func assertTransactionEquals(t *testing.T, txnRequest *model.Transaction, transa
 }
 
 func executeTransactionWithoutAssertion(t *testing.T, txnRequest *model.Transaction, keyPair *model.KeyPair) (*model.TransactionResponse, *resty.Response, error) { //nolint
-	crypto.HashTransaction(txnRequest)
-	crypto.SignTransaction(txnRequest, keyPair)
+	t.Logf("Submitting transaction...")
+	return transactionBuilder(keyPair).Submit(endpoint.Context(t), txnRequest)
+}
 
-	transactionResponse, httpResponse, err := v1TransactionPut(t, txnRequest, nil)
+// transactionBuilders holds a *transaction.Builder per client ID, so the nonces of a wallet are tracked across tests
+var transactionBuilders sync.Map
 
-	return transactionResponse, httpResponse, err
+func transactionBuilder(keyPair *model.KeyPair) *transaction.Builder {
+	clientID := crypto.ClientID(keyPair.PublicKey)
+	builder, _ := transactionBuilders.LoadOrStore(clientID, transaction.NewBuilder(zeroChain.Zerochain, clientID, keyPair))
+	return builder.(*transaction.Builder)
 }
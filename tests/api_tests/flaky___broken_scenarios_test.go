@@ -24,12 +24,12 @@ func Test___BrokenScenariosRegisterWallet(t *testing.T) {
 		t.Parallel()
 
 		mnemonic := crypto.GenerateMnemonic(t)
-		expectedKeyPair := crypto.GenerateKeys(t, mnemonic)
-		publicKeyBytes, _ := hex.DecodeString(expectedKeyPair.PublicKey.SerializeToHexStr())
+		expectedKeyPair := generateKeys(t, mnemonic)
+		publicKeyBytes, _ := hex.DecodeString(expectedKeyPair.PublicKey)
 		expectedClientId := encryption.Hash(publicKeyBytes)
 		invalidCreationDate := -1
 
-		walletRequest := model.ClientPutWalletRequest{Id: expectedClientId, PublicKey: expectedKeyPair.PublicKey.SerializeToHexStr(), CreationDate: &invalidCreationDate}
+		walletRequest := model.ClientPutWalletRequest{Id: expectedClientId, PublicKey: expectedKeyPair.PublicKey, CreationDate: &invalidCreationDate}
 
 		registeredWallet, httpResponse, err := v1ClientPut(t, walletRequest, endpoint.ConsensusByHttpStatus(endpoint.HttpOkStatus))
 
@@ -37,7 +37,7 @@ func Test___BrokenScenariosRegisterWallet(t *testing.T) {
 		require.NotNil(t, registeredWallet, "Registered wallet was unexpectedly nil! with http response [%s]", httpResponse)
 		require.Equal(t, endpoint.HttpOkStatus, httpResponse.Status())
 		require.Equal(t, registeredWallet.Id, expectedClientId)
-		require.Equal(t, registeredWallet.PublicKey, expectedKeyPair.PublicKey.SerializeToHexStr())
+		require.Equal(t, registeredWallet.PublicKey, expectedKeyPair.PublicKey)
 		require.Greater(t, *registeredWallet.CreationDate, 0, "Creation date is an invalid value!")
 		require.NotNil(t, registeredWallet.Version)
 	})
@@ -46,13 +46,13 @@ func Test___BrokenScenariosRegisterWallet(t *testing.T) {
 		t.Parallel()
 
 		mnemonic := crypto.GenerateMnemonic(t)
-		expectedKeyPair := crypto.GenerateKeys(t, mnemonic)
-		walletRequest := model.ClientPutWalletRequest{Id: "invalid", PublicKey: expectedKeyPair.PublicKey.SerializeToHexStr()}
+		expectedKeyPair := generateKeys(t, mnemonic)
+		walletRequest := model.ClientPutWalletRequest{Id: "invalid", PublicKey: expectedKeyPair.PublicKey}
 
 		walletResponse, httpResponse, err := v1ClientPut(t, walletRequest, endpoint.ConsensusByHttpStatus("400 Bad Request"))
 
 		require.Nil(t, walletResponse, "Expected returned wallet to be nil but was [%s] with http response [%s]", walletResponse, httpResponse)
-		require.NotNil(t, err, "Expected error when registering wallet but was nil.")
+		require.Nil(t, err, "Unexpected error [%s] when registering wallet with an expected HTTP error status", err)
 		require.Equal(t, "400 Bad Request", httpResponse.Status())
 	})
 
@@ -60,15 +60,15 @@ func Test___BrokenScenariosRegisterWallet(t *testing.T) {
 		t.Parallel()
 
 		mnemonic := crypto.GenerateMnemonic(t)
-		expectedKeyPair := crypto.GenerateKeys(t, mnemonic)
-		publicKeyBytes, _ := hex.DecodeString(expectedKeyPair.PublicKey.SerializeToHexStr())
+		expectedKeyPair := generateKeys(t, mnemonic)
+		publicKeyBytes, _ := hex.DecodeString(expectedKeyPair.PublicKey)
 		clientId := encryption.Hash(publicKeyBytes)
 		walletRequest := model.ClientPutWalletRequest{Id: clientId, PublicKey: "invalid"}
 
 		walletResponse, httpResponse, err := v1ClientPut(t, walletRequest, endpoint.ConsensusByHttpStatus("400 Bad Request"))
 
 		require.Nil(t, walletResponse, "Expected returned wallet to be nil but was [%s] with http response [%s]", walletResponse, httpResponse)
-		require.NotNil(t, err, "Expected error when registering wallet but was nil.")
+		require.Nil(t, err, "Unexpected error [%s] when registering wallet with an expected HTTP error status", err)
 		require.Equal(t, "400 Bad Request", httpResponse.Status())
 	})
 
@@ -79,7 +79,7 @@ func Test___BrokenScenariosRegisterWallet(t *testing.T) {
 		walletResponse, httpResponse, err := v1ClientPut(t, walletRequest, endpoint.ConsensusByHttpStatus("400 Bad Request"))
 
 		require.Nil(t, walletResponse, "Expected returned wallet to be nil but was [%s] with http response [%s]", walletResponse, httpResponse)
-		require.NotNil(t, err, "Expected error when registering wallet but was nil.")
+		require.Nil(t, err, "Unexpected error [%s] when registering wallet with an expected HTTP error status", err)
 		require.Equal(t, "400 Bad Request", httpResponse.Status())
 	})
 }
@@ -1,15 +1,23 @@
 package api_tests
 
 import (
+	"github.com/0chain/system_test/internal/api/util/cassette"
 	"github.com/0chain/system_test/internal/api/util/config"
+	"github.com/0chain/system_test/internal/api/util/crypto"
 	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/api/util/fakenet"
 
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 )
 
-var zeroChain endpoint.Zerochain
+var zeroChain = endpoint.NewTestZerochain()
+
+// signer generates the keys of wallets and signs with them, for the signature_scheme of the zbox config
+var signer crypto.Signer
 
 func TestMain(m *testing.M) {
 	configPath, ok := os.LookupEnv(config.ConfigPathEnv)
@@ -20,7 +28,96 @@ func TestMain(m *testing.M) {
 
 	parsedConfig := config.Parse(configPath)
 
-	zeroChain.Init(parsedConfig.NetworkEntrypoint)
+	httpMode, ok := os.LookupEnv(config.HttpModeEnv)
+	if !ok {
+		httpMode = parsedConfig.HttpMode
+	}
+	mode, err := cassette.ParseMode(httpMode)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	recorder := cassette.NewRecorder(mode, parsedConfig.CassetteDir, parsedConfig.CassetteIgnoreFields...)
+	zeroChain.Transport = recorder
+	crypto.UseDeterministicMnemonics(mode != cassette.ModePassthrough)
+	if mode == cassette.ModeReplay {
+		log.Printf("Replaying HTTP calls from cassettes in [%v]", parsedConfig.CassetteDir)
+	}
+
+	zeroChain.NodeTimeout = parsedConfig.NodeTimeout
+	zeroChain.ConsensusTimeout = parsedConfig.ConsensusTimeout
+	zeroChain.HealthCheckInterval = parsedConfig.HealthCheckInterval
+	zeroChain.DiscoveryInterval = parsedConfig.DiscoveryInterval
+	zeroChain.UnhealthyAfter = parsedConfig.UnhealthyAfter
+	zeroChain.HealthyAfter = parsedConfig.HealthyAfter
+	signatureScheme := crypto.BLS0Chain
+	if parsedConfig.ZboxConfigPath != "" {
+		zboxConfig := config.ParseZbox(parsedConfig.ZboxConfigPath)
+		zeroChain.MinConfirmation = zboxConfig.MinConfirmation
+		signatureScheme = zboxConfig.SignatureScheme
+	}
+	signer, err = crypto.NewSigner(signatureScheme)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	networkEntrypoint := parsedConfig.NetworkEntrypoint
+	fakeNetwork := parsedConfig.FakeNetwork
+	if value, ok := os.LookupEnv(config.FakeNetworkEnv); ok {
+		fakeNetwork, _ = strconv.ParseBool(value)
+	}
+	var network *fakenet.Network
+	if fakeNetwork {
+		network = fakenet.New(fakenet.Options{Signer: signer})
+		networkEntrypoint = network.NetworkEntrypoint()
+		log.Printf("Running against a fake network at [%v]", networkEntrypoint)
+	}
+	zeroChain.Init(networkEntrypoint)
+
+	exitCode := m.Run()
+
+	zeroChain.StopHealthTracking()
+	if err := recorder.Save(); err != nil {
+		log.Printf("failed to save cassettes into [%v] due to error: %v", parsedConfig.CassetteDir, err)
+	}
+	for _, node := range zeroChain.Nodes() {
+		log.Printf("%s [%s] healthy [%t] with [%d] consecutive failures, last error [%s]", node.Type, node.Node, node.Healthy, node.ConsecutiveFailures, node.LastError)
+	}
+	metrics := zeroChain.Metrics()
+	for _, node := range metrics.Nodes {
+		log.Printf("%s [%s] answered [%d] calls with error rate [%.2f%%], latency p50 [%.3fs] p95 [%.3fs] p99 [%.3fs]", node.NodeType, node.Node, node.Calls, node.ErrorRate*100, node.Latency.P50, node.Latency.P95, node.Latency.P99)
+	}
+	if parsedConfig.MetricsDir != "" {
+		if err := writeMetrics(parsedConfig.MetricsDir, metrics); err != nil {
+			log.Printf("failed to write metrics into [%v] due to error: %v", parsedConfig.MetricsDir, err)
+		}
+	}
+
+	if network != nil {
+		network.Close()
+	}
+
+	os.Exit(exitCode)
+}
+
+func writeMetrics(dir string, metrics endpoint.Metrics) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	jsonFile, err := os.Create(filepath.Join(dir, "metrics.json"))
+	if err != nil {
+		return err
+	}
+	defer jsonFile.Close()
+	if err := metrics.WriteJSON(jsonFile); err != nil {
+		return err
+	}
+
+	prometheusFile, err := os.Create(filepath.Join(dir, "metrics.prom"))
+	if err != nil {
+		return err
+	}
+	defer prometheusFile.Close()
 
-	os.Exit(m.Run())
+	return metrics.WritePrometheus(prometheusFile)
 }
@@ -0,0 +1,66 @@
+package api_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/smartcontract/multisigsc"
+	"github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/util/endpoint"
+	"github.com/0chain/system_test/internal/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiSigWallet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Transfer of a group wallet should execute only once the threshold of its signers voted for it", func(t *testing.T) {
+		t.Parallel()
+
+		if signer.Scheme() != crypto.BLS0Chain {
+			t.Skipf("group wallets need %s keys, the network uses %s", crypto.BLS0Chain, signer.Scheme())
+		}
+
+		const threshold, signers = 2, 3
+		transferAmount := currency.Coin(1000000000)
+
+		groupWallet, groupKeys := registerWallet(t)
+		_, confirmation := executeFaucet(t, groupWallet, groupKeys)
+		require.Equal(t, endpoint.TxSuccessfulStatus, confirmation.Status, confirmation.Transaction.TransactionOutput)
+
+		shares, err := crypto.GenerateThresholdKeys(groupKeys, threshold, signers)
+		require.Nil(t, err)
+
+		t.Logf("Registering group wallet...")
+		registerTransaction := executeTransaction(t, multisigsc.Register(multisigsc.NewWallet(groupKeys, shares, threshold)), groupKeys)
+		confirmation, _ = confirmTransaction(t, groupWallet, registerTransaction.Entity, 2*time.Minute)
+		require.Equal(t, endpoint.TxSuccessfulStatus, confirmation.Status, confirmation.Transaction.TransactionOutput)
+
+		receiverWallet, receiverKeys := registerWallet(t)
+		_, confirmation = executeFaucet(t, receiverWallet, receiverKeys)
+		require.Equal(t, endpoint.TxSuccessfulStatus, confirmation.Status, confirmation.Transaction.TransactionOutput)
+
+		transfer := multisigsc.Transfer{From: groupWallet.ClientID, To: receiverWallet.ClientID, Amount: transferAmount}
+		proposalID := crypto.NewConnectionID()
+		for i, share := range shares[:threshold] {
+			signerKeys := share.KeyPair
+			signerWallet, _ := registerWalletForKeys(t, &signerKeys, "")
+
+			vote, err := multisigsc.NewVote(proposalID, transfer, share)
+			require.Nil(t, err)
+
+			t.Logf("Voting as signer %d of %d...", i+1, threshold)
+			voteTransaction := executeTransaction(t, multisigsc.CastVote(vote), &signerKeys)
+			confirmation, _ = confirmTransaction(t, signerWallet, voteTransaction.Entity, 2*time.Minute)
+			require.Equal(t, endpoint.TxSuccessfulStatus, confirmation.Status, confirmation.Transaction.TransactionOutput)
+
+			expected := faucetPourAmount
+			if i+1 == threshold {
+				expected += transferAmount
+			}
+			require.Equal(t, expected, getBalance(t, receiverWallet.ClientID).Balance, "balance of the receiver after %d of %d votes", i+1, threshold)
+		}
+
+		require.Equal(t, faucetPourAmount-transferAmount, getBalance(t, groupWallet.ClientID).Balance)
+	})
+}
@@ -24,13 +24,13 @@ func TestRegisterWallet(t *testing.T) {
 
 		registeredWallet, keyPair, rawHttpResponse, err := registerWalletForMnemonicWithoutAssertion(t, mnemonic)
 
-		publicKeyBytes, _ := hex.DecodeString(keyPair.PublicKey.SerializeToHexStr())
+		publicKeyBytes, _ := hex.DecodeString(keyPair.PublicKey)
 		expectedClientId := encryption.Hash(publicKeyBytes)
 		require.Nil(t, err, "Unexpected error [%s] occurred registering wallet with http response [%s]", err, rawHttpResponse)
 		require.NotNil(t, registeredWallet, "Registered wallet was unexpectedly nil! with http response [%s]", rawHttpResponse)
 		require.Equal(t, endpoint.HttpOkStatus, rawHttpResponse.Status())
 		require.Equal(t, registeredWallet.ClientID, expectedClientId)
-		require.Equal(t, registeredWallet.ClientKey, keyPair.PublicKey.SerializeToHexStr())
+		require.Equal(t, registeredWallet.ClientKey, keyPair.PublicKey)
 		require.Greater(t, registeredWallet.MustConvertDateCreatedToInt(), 0, "Creation date is an invalid value!")
 		require.NotNil(t, registeredWallet.Version)
 	})
@@ -43,28 +43,44 @@ func registerWallet(t *testing.T) (*model.Wallet, *model.KeyPair) {
 }
 
 func registerWalletForMnemonic(t *testing.T, mnemonic string) (*model.Wallet, *model.KeyPair) {
-	registeredWallet, keyPair, httpResponse, err := registerWalletForMnemonicWithoutAssertion(t, mnemonic)
+	return registerWalletForKeys(t, generateKeys(t, mnemonic), mnemonic)
+}
+
+// registerWalletForKeys registers a wallet of keys which were not generated from a mnemonic, as the shares of a group wallet
+func registerWalletForKeys(t *testing.T, keyPair *model.KeyPair, mnemonic string) (*model.Wallet, *model.KeyPair) {
+	registeredWallet, keyPair, httpResponse, err := registerWalletForKeysWithoutAssertion(t, keyPair, mnemonic)
 
-	publicKeyBytes, _ := hex.DecodeString(keyPair.PublicKey.SerializeToHexStr())
+	publicKeyBytes, _ := hex.DecodeString(keyPair.PublicKey)
 	clientId := encryption.Hash(publicKeyBytes)
 
 	require.Nil(t, err, "Unexpected error [%s] occurred registering wallet with http response [%s]", err, httpResponse)
 	require.NotNil(t, registeredWallet, "Registered wallet was unexpectedly nil! with http response [%s]", httpResponse)
 	require.Equal(t, endpoint.HttpOkStatus, httpResponse.Status())
 	require.Equal(t, registeredWallet.ClientID, clientId)
-	require.Equal(t, registeredWallet.ClientKey, keyPair.PublicKey.SerializeToHexStr())
+	require.Equal(t, registeredWallet.ClientKey, keyPair.PublicKey)
 	require.Greater(t, registeredWallet.MustConvertDateCreatedToInt(), 0, "Creation date is an invalid value!")
 	require.NotNil(t, registeredWallet.Version)
 
 	return registeredWallet, keyPair
 }
 
+func generateKeys(t *testing.T, mnemonic string) *model.KeyPair {
+	keyPair, err := signer.GenerateKeys(mnemonic)
+	require.Nil(t, err, "Unexpected error [%s] occurred generating %s keys", err, signer.Scheme())
+	t.Logf("Generated %s public key [%s] and secret key [%s]", signer.Scheme(), keyPair.PublicKey, keyPair.PrivateKey)
+
+	return keyPair
+}
+
 func registerWalletForMnemonicWithoutAssertion(t *testing.T, mnemonic string) (*model.Wallet, *model.KeyPair, *resty.Response, error) { //nolint
+	return registerWalletForKeysWithoutAssertion(t, generateKeys(t, mnemonic), mnemonic)
+}
+
+func registerWalletForKeysWithoutAssertion(t *testing.T, keyPair *model.KeyPair, mnemonic string) (*model.Wallet, *model.KeyPair, *resty.Response, error) { //nolint
 	t.Logf("Registering wallet...")
-	keyPair := crypto.GenerateKeys(t, mnemonic)
-	publicKeyBytes, _ := hex.DecodeString(keyPair.PublicKey.SerializeToHexStr())
+	publicKeyBytes, _ := hex.DecodeString(keyPair.PublicKey)
 	clientId := encryption.Hash(publicKeyBytes)
-	walletRequest := model.ClientPutWalletRequest{Id: clientId, PublicKey: keyPair.PublicKey.SerializeToHexStr()}
+	walletRequest := model.ClientPutWalletRequest{Id: clientId, PublicKey: keyPair.PublicKey}
 
 	registeredWallet, httpResponse, err := v1ClientPut(t, walletRequest, endpoint.ConsensusByHttpStatus(endpoint.HttpOkStatus))
 
@@ -72,8 +88,8 @@ func registerWalletForMnemonicWithoutAssertion(t *testing.T, mnemonic string) (*
 		ClientID:  registeredWallet.Id,
 		ClientKey: registeredWallet.PublicKey,
 		Keys: []*sys.KeyPair{{
-			PrivateKey: keyPair.PrivateKey.SerializeToHexStr(),
-			PublicKey:  keyPair.PublicKey.SerializeToHexStr(),
+			PrivateKey: keyPair.PrivateKey,
+			PublicKey:  keyPair.PublicKey,
 		}},
 		DateCreated: strconv.Itoa(*registeredWallet.CreationDate),
 		Mnemonics:   mnemonic,
@@ -1,11 +1,11 @@
 package api_tests
 
 import (
-	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract/storagesc"
 	"github.com/0chain/system_test/internal/api/util/endpoint"
 	"github.com/stretchr/testify/require"
 )
@@ -47,24 +47,7 @@ func TestUpdateBlobber(t *testing.T) {
 }
 
 func updateBlobber(t *testing.T, wallet *model.Wallet, keyPair *model.KeyPair, blobberUpdate *model.GetBlobberResponse) (*model.TransactionResponse, *model.Confirmation) {
-	txnDataString, err := json.Marshal(model.SmartContractTxnData{Name: "update_blobber_settings", InputArgs: blobberUpdate})
-	require.Nil(t, err)
-
-	updateAllocationRequest := model.Transaction{
-		PublicKey:        keyPair.PublicKey.SerializeToHexStr(),
-		TxnOutputHash:    "",
-		TransactionValue: 1000000000,
-		TransactionType:  1000,
-		TransactionFee:   0,
-		TransactionData:  string(txnDataString),
-		ToClientId:       endpoint.StorageSmartContractAddress,
-		CreationDate:     time.Now().Unix(),
-		ClientId:         wallet.ClientID,
-		Version:          "1.0",
-		TransactionNonce: wallet.Nonce + 1,
-	}
-
-	updateBlobberTransaction := executeTransaction(t, &updateAllocationRequest, keyPair)
+	updateBlobberTransaction := executeTransaction(t, storagesc.UpdateBlobberSettings(blobberUpdate), keyPair)
 	confirmation, restyResponse := confirmTransaction(t, wallet, updateBlobberTransaction.Entity, time.Minute)
 	require.NotNil(t, restyResponse)
 
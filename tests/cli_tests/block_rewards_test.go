@@ -1,10 +1,10 @@
 package cli_tests
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
 	"reflect"
 	"sort"
@@ -15,6 +15,8 @@ import (
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutil "github.com/0chain/system_test/internal/cli/util"
+	"github.com/0chain/system_test/internal/currency"
+	"github.com/0chain/system_test/internal/currency/rewards"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,10 +26,12 @@ func TestBlockRewards(t *testing.T) { // nolint:gocyclo // team preference is to
 		_ = initialiseTest(t)
 
 		sharderUrl := getSharderUrl(t)
+		waitForRoomInEpoch(t, 2*blockRewardRounds)
 		beforeMiners := getSortedMiners(t, sharderUrl)
 		require.True(t, len(beforeMiners.Nodes) > 0, "no miners found")
 
-		time.Sleep(time.Second * 10)
+		err := waitForRoundsGT(t, blockRewardRounds)
+		require.NoError(t, err, "waiting for %d rounds", blockRewardRounds)
 
 		afterMiners := getSortedMiners(t, sharderUrl)
 		require.EqualValues(t, len(afterMiners.Nodes), len(beforeMiners.Nodes), "miner count changed during test")
@@ -44,20 +48,33 @@ func TestBlockRewards(t *testing.T) { // nolint:gocyclo // team preference is to
 		minerScConfig := getMinerScMap(t)
 		history := cliutil.NewHistory(startRound, endRound)
 		history.ReadBlocks(t, sharderUrl)
+		if verifyBlocks {
+			history.VerifyBlocks(t, sharderUrl, notarizationThreshold)
+		}
 
 		require.EqualValues(t, startRound/int64(minerScConfig["epoch"]), endRound/int64(minerScConfig["epoch"]),
 			"epoch changed during test, start %v finish %v",
 			startRound/int64(minerScConfig["epoch"]), endRound/int64(minerScConfig["epoch"]))
 
-		minerBlockRewardPerRound, _ := blockRewards(t, startRound, minerScConfig)
+		rewardConfig := getBlockRewardConfig(t, minerScConfig)
 		for i, beforeMiner := range beforeMiners.Nodes {
 			id := beforeMiner.ID
-			timesWon := history.TimesWonBestMiner(id)
-			expectedBlockRewards := timesWon * minerBlockRewardPerRound
-			recordedFees := history.TotalMinerFees(id)
-			expectedRewards := expectedBlockRewards + recordedFees
-			actualReward := afterMiners.Nodes[i].Reward - beforeMiner.Reward
-			require.EqualValues(t, expectedRewards, actualReward, "actual rewards don't match expected rewards")
+			delegates := nodeDelegates(t, &beforeMiners.Nodes[i])
+			// the block reward and the fees of every block are distributed to the stake pool of its generator separately
+			var expected rewards.Split
+			for _, block := range history.BlocksWonBy(id) {
+				blockReward, _, err := rewardConfig.Rewards(block.Round)
+				require.NoError(t, err, "block reward of round %d", block.Round)
+				blockFees, err := history.TotalBlockFees(block)
+				require.NoError(t, err, "summing fees of block %s", block.Hash)
+				for _, payment := range []currency.Coin{blockReward, blockFees} {
+					split, err := rewards.SplitReward(payment, beforeMiner.Settings.ServiceCharge, delegates)
+					require.NoError(t, err, "splitting %v between miner %s and its delegates", payment, id)
+					require.NoError(t, expected.Add(split), "adding up rewards of miner %s", id)
+				}
+			}
+			actualReward := subtractCoins(t, afterMiners.Nodes[i].Reward, beforeMiner.Reward)
+			require.EqualValues(t, expected.Provider, actualReward, "actual rewards don't match expected rewards")
 		}
 	})
 
@@ -66,10 +83,12 @@ func TestBlockRewards(t *testing.T) { // nolint:gocyclo // team preference is to
 		_ = initialiseTest(t)
 
 		sharderUrl := getSharderUrl(t)
+		waitForRoomInEpoch(t, 2*blockRewardRounds)
 		beforeSharders := getSortedSharders(t, sharderUrl)
 		require.True(t, len(beforeSharders.Nodes) > 0, "no miners found")
 
-		time.Sleep(time.Second * 10)
+		err := waitForRoundsGT(t, blockRewardRounds)
+		require.NoError(t, err, "waiting for %d rounds", blockRewardRounds)
 
 		afterSharders := getSortedSharders(t, sharderUrl)
 		require.EqualValues(t, len(afterSharders.Nodes), len(beforeSharders.Nodes), "miner count changed during test")
@@ -87,13 +106,27 @@ func TestBlockRewards(t *testing.T) { // nolint:gocyclo // team preference is to
 			"epoch changed during test, start %v finish %v",
 			startRound/int64(minerScConfig["epoch"]), endRound/int64(minerScConfig["epoch"]))
 
-		_, sharderBlockRewards := blockRewards(t, startRound, minerScConfig)
-		numberOfRounds := endRound - startRound
-		totalBlockRewardsPerSharder := numberOfRounds * sharderBlockRewards / int64(len(beforeSharders.Nodes))
+		rewardConfig := getBlockRewardConfig(t, minerScConfig)
+		expected := make([]rewards.Split, len(beforeSharders.Nodes))
+		delegates := make([][]rewards.Delegate, len(beforeSharders.Nodes))
+		for i := range beforeSharders.Nodes {
+			delegates[i] = nodeDelegates(t, &beforeSharders.Nodes[i])
+		}
+		for round := startRound; round < endRound; round++ {
+			_, sharderBlockReward, err := rewardConfig.Rewards(round)
+			require.NoError(t, err, "block reward of round %d", round)
+			share, _, err := rewards.ShareEvenly(sharderBlockReward, len(beforeSharders.Nodes))
+			require.NoError(t, err, "sharing block reward among %d sharders", len(beforeSharders.Nodes))
+			for i := range beforeSharders.Nodes {
+				sharder := &beforeSharders.Nodes[i]
+				split, err := rewards.SplitReward(share, sharder.Settings.ServiceCharge, delegates[i])
+				require.NoError(t, err, "splitting %v between sharder %s and its delegates", share, sharder.ID)
+				require.NoError(t, expected[i].Add(split), "adding up rewards of sharder %s", sharder.ID)
+			}
+		}
 		for i, beforeSharder := range beforeSharders.Nodes {
-			expectedBlockRewards := totalBlockRewardsPerSharder
-			actualReward := afterSharders.Nodes[i].Reward - beforeSharder.Reward
-			require.EqualValues(t, expectedBlockRewards, actualReward)
+			actualReward := subtractCoins(t, afterSharders.Nodes[i].Reward, beforeSharder.Reward)
+			require.EqualValues(t, expected[i].Provider, actualReward)
 		}
 	})
 }
@@ -137,6 +170,30 @@ func keyValuePairStringToMap(t *testing.T, input []string) (stringMap map[string
 	return
 }
 
+// blockRewardRounds is how many rounds the block reward tests let pass between reading the rewards of the nodes
+const blockRewardRounds = 10
+
+// waitForRoomInEpoch waits for the next epoch to begin if the next n rounds would not all be in the current one,
+// block rewards decline from one epoch to the next
+func waitForRoomInEpoch(t *testing.T, n int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), roundWaitTimeout)
+	defer cancel()
+
+	watcher := getRoundWatcher(t)
+	latest, err := watcher.Latest(ctx)
+	require.NoError(t, err, "getting latest finalized block")
+	epoch, err := watcher.Epoch(ctx, latest.Round)
+	require.NoError(t, err, "getting epoch of round %d", latest.Round)
+	endEpoch, err := watcher.Epoch(ctx, latest.Round+n)
+	require.NoError(t, err, "getting epoch of round %d", latest.Round+n)
+	if endEpoch == epoch {
+		return
+	}
+
+	_, err = watcher.WaitForEpochBoundary(ctx, endEpoch)
+	require.NoError(t, err, "waiting for epoch %d", endEpoch)
+}
+
 func getMinerScMap(t *testing.T) map[string]float64 {
 	output, err := getMinerSCConfig(t, configPath, true)
 	require.NoError(t, err, "get miners sc config failed", strings.Join(output, "\n"))
@@ -145,14 +202,31 @@ func getMinerScMap(t *testing.T) map[string]float64 {
 	return configAsFloat
 }
 
-func blockRewards(t *testing.T, round int64, minerScConfig map[string]float64) (int64, int64) {
-	epoch := round / int64(minerScConfig["epoch"])
-	epochDecline := 1.0 - minerScConfig["reward_decline_rate"]
-	declineRate := math.Pow(epochDecline, float64(epoch))
-	blockReward := (minerScConfig["block_reward"] * float64(TOKEN_UNIT)) * declineRate
-	minerReward := int64(blockReward * minerScConfig["share_ratio"])
-	sharderReward := int64(blockReward) - minerReward
-	return minerReward, sharderReward
+func getBlockRewardConfig(t *testing.T, minerScConfig map[string]float64) *rewards.BlockRewardConfig {
+	return &rewards.BlockRewardConfig{
+		BlockReward: tokensToCoin(t, minerScConfig["block_reward"]),
+		ShareRatio:  minerScConfig["share_ratio"],
+		DeclineRate: minerScConfig["reward_decline_rate"],
+		Epoch:       int64(minerScConfig["epoch"]),
+	}
+}
+
+// nodeDelegates returns the active stake pools of node, the ones its rewards are distributed to.
+// The pools of a staked node listed without them are read with mn-info, the test fails if there are none either.
+func nodeDelegates(t *testing.T, node *climodel.Node) []rewards.Delegate {
+	pools := node.Pools
+	if len(pools) == 0 && node.TotalStake > 0 {
+		pools = getMinersDetail(t, node.ID).Pools
+	}
+	require.False(t, len(pools) == 0 && node.TotalStake > 0, "node %s has %v staked but no stake pools", node.ID, node.TotalStake)
+
+	delegates := make([]rewards.Delegate, 0, len(pools))
+	for id, pool := range pools {
+		if pool.Status == int(climodel.Active) {
+			delegates = append(delegates, rewards.Delegate{ID: id, Stake: pool.Balance})
+		}
+	}
+	return delegates
 }
 
 func getSharderUrl(t *testing.T) string {
@@ -7,12 +7,12 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"testing"
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/0chain/system_test/internal/currency"
 	"github.com/stretchr/testify/require"
 )
 
@@ -358,12 +358,7 @@ func Test___FlakyBrokenScenarios(t *testing.T) {
 		}), true)
 		require.Nil(t, err, "Could not get download cost", strings.Join(output, "\n"))
 
-		expectedDownloadCostInZCN, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedDownloadCostInZCN = unitToZCN(expectedDownloadCostInZCN, unit)
-		expectedDownloadCost := ConvertToValue(expectedDownloadCostInZCN)
+		expectedDownloadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
 
 		output, err = addCollaborator(t, createParams(map[string]interface{}{
 			"allocation": allocationID,
@@ -389,7 +384,7 @@ func Test___FlakyBrokenScenarios(t *testing.T) {
 		require.Equal(t, "locked", output[0])
 
 		readPool := getReadPoolInfo(t)
-		require.Equal(t, ConvertToValue(0.4), readPool.Balance, "Read Pool balance must be equal to locked amount")
+		require.Equal(t, tokensToCoin(t, 0.4), readPool.Balance, "Read Pool balance must be equal to locked amount")
 
 		output, err = downloadFileForWallet(t, collaboratorWalletName, configPath, createParams(map[string]interface{}{
 			"allocation": allocationID,
@@ -403,7 +398,7 @@ func Test___FlakyBrokenScenarios(t *testing.T) {
 		require.Equal(t, expectedOutput, output[1], "Unexpected output", strings.Join(output, "\n"))
 
 		// expected download cost times to the number of blobbers
-		expectedPoolBalance := ConvertToValue(0.4) - expectedDownloadCost
+		expectedPoolBalance := subtractCoins(t, tokensToCoin(t, 0.4), expectedDownloadCost)
 
 		updatedReadPool, err := getReadPoolUpdate(t, readPool, 5)
 		require.NoError(t, err)
@@ -436,7 +431,7 @@ func Test___FlakyBrokenScenarios(t *testing.T) {
 
 		// Write pool balance should increment to 1
 		initialAllocation := getAllocation(t, allocationID)
-		require.Equal(t, 0.8, intToZCN(initialAllocation.WritePool))
+		require.Equal(t, tokensToCoin(t, 0.8), initialAllocation.WritePool)
 
 		filename := generateRandomTestFileName(t)
 		err = createFileWithSize(filename, 1024*5)
@@ -445,16 +440,12 @@ func Test___FlakyBrokenScenarios(t *testing.T) {
 		// Get expected upload cost
 		output, _ = getUploadCostInUnit(t, configPath, allocationID, filename)
 
-		expectedUploadCostInZCN, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedUploadCostInZCN = unitToZCN(expectedUploadCostInZCN, unit)
+		expectedUploadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
 
 		// Expected cost is given in "per 720 hours", we need 1 hour
 		// Expected cost takes into account data+parity, so we divide by that
-		actualExpectedUploadCostInZCN := expectedUploadCostInZCN / ((2 + 2) * 720)
-		fmt.Print(actualExpectedUploadCostInZCN)
+		actualExpectedUploadCost, _, err := currency.DistributeCoin(expectedUploadCost, (2+2)*720)
+		require.Nil(t, err)
 		// upload a dummy 5 MB file
 		uploadWithParam(t, configPath, map[string]interface{}{
 			"allocation": allocationID,
@@ -463,7 +454,7 @@ func Test___FlakyBrokenScenarios(t *testing.T) {
 		})
 
 		finalAllocation := getAllocation(t, allocationID)
-		require.Equal(t, 0.8, intToZCN(finalAllocation.WritePool))
+		require.Equal(t, tokensToCoin(t, 0.8), finalAllocation.WritePool)
 
 		// Get Challenge-Pool info after upload
 		output, err = challengePoolInfo(t, configPath, allocationID)
@@ -476,15 +467,15 @@ func Test___FlakyBrokenScenarios(t *testing.T) {
 		require.Regexp(t, regexp.MustCompile(fmt.Sprintf("([a-f0-9]{64}):challengepool:%s", allocationID)), challengePool.Id)
 		require.IsType(t, int64(1), challengePool.StartTime)
 		require.IsType(t, int64(1), challengePool.Expiration)
-		require.IsType(t, int64(1), challengePool.Balance)
+		require.IsType(t, currency.Coin(1), challengePool.Balance)
 		require.False(t, challengePool.Finalized)
 
 		// FIXME: Blobber details are empty
 		// Blobber pool balance should reduce by (write price*filesize) for each blobber
-		totalChangeInWritePool := intToZCN(initialAllocation.WritePool - finalAllocation.WritePool)
+		totalChangeInWritePool := subtractCoins(t, initialAllocation.WritePool, finalAllocation.WritePool)
 
-		require.Equal(t, actualExpectedUploadCostInZCN, totalChangeInWritePool, "expected write pool balance to decrease by [%v] but has actually decreased by [%v]", actualExpectedUploadCostInZCN, totalChangeInWritePool)
-		require.Equal(t, totalChangeInWritePool, intToZCN(challengePool.Balance), "expected challenge pool balance to match deducted amount from write pool [%v] but balance was actually [%v]", totalChangeInWritePool, intToZCN(challengePool.Balance))
+		require.Equal(t, actualExpectedUploadCost, totalChangeInWritePool, "expected write pool balance to decrease by [%v] but has actually decreased by [%v]", actualExpectedUploadCost, totalChangeInWritePool)
+		require.Equal(t, totalChangeInWritePool, challengePool.Balance, "expected challenge pool balance to match deducted amount from write pool [%v] but balance was actually [%v]", totalChangeInWritePool, challengePool.Balance)
 	})
 
 	// FIXME: Commented out because these cases hang the broken test suite till timeout
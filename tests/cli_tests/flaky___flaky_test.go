@@ -5,12 +5,12 @@ import (
 	"math"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/0chain/system_test/internal/currency"
 
 	"github.com/stretchr/testify/require"
 )
@@ -51,20 +51,18 @@ func Test___FlakyScenariosCommonUserFunctions(t *testing.T) {
 		// Get expected upload cost for 0.5 MB
 		localpath := uploadRandomlyGeneratedFile(t, allocationID, "/", fileSize)
 		output, _ = getUploadCostInUnit(t, configPath, allocationID, localpath)
-		expectedUploadCostInZCN, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-		unit := strings.Fields(output[0])[1]
-		expectedUploadCostInZCN = unitToZCN(expectedUploadCostInZCN, unit)
+		expectedUploadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
 
 		// Expected cost takes into account data+parity, so we divide by that
-		actualExpectedUploadCostInZCN := (expectedUploadCostInZCN / (2 + 2))
+		actualExpectedUploadCost, _, err := currency.DistributeCoin(expectedUploadCost, 2+2)
+		require.Nil(t, err)
 
 		// Wait for write pool balance to be deduced for initial 0.5 MB
 		cliutils.Wait(t, time.Minute)
 
 		initialAllocation := getAllocation(t, allocationID)
 
-		require.Equal(t, 0.5-actualExpectedUploadCostInZCN, intToZCN(initialAllocation.WritePool))
+		require.Equal(t, subtractCoins(t, tokensToCoin(t, 0.5), actualExpectedUploadCost), initialAllocation.WritePool)
 
 		remotepath := "/" + filepath.Base(localpath)
 		updateFileWithRandomlyGeneratedData(t, allocationID, remotepath, int64(1*MB))
@@ -73,11 +71,11 @@ func Test___FlakyScenariosCommonUserFunctions(t *testing.T) {
 		cliutils.Wait(t, time.Minute)
 
 		finalAllocation := getAllocation(t, allocationID)
-		require.Equal(t, (0.5 - 2*actualExpectedUploadCostInZCN), intToZCN(finalAllocation.WritePool))
+		require.Equal(t, subtractCoins(t, tokensToCoin(t, 0.5), multiplyCoin(t, actualExpectedUploadCost, 2)), finalAllocation.WritePool)
 
 		// Blobber pool balance should reduce by expected cost of 0.5 MB
-		totalChangeInWritePool := intToZCN(initialAllocation.WritePool - finalAllocation.WritePool)
-		require.Equal(t, actualExpectedUploadCostInZCN, totalChangeInWritePool)
+		totalChangeInWritePool := subtractCoins(t, initialAllocation.WritePool, finalAllocation.WritePool)
+		require.Equal(t, actualExpectedUploadCost, totalChangeInWritePool)
 		createAllocationTestTeardown(t, allocationID)
 	})
 }
@@ -155,21 +153,18 @@ func Test___FlakyTransferAllocation(t *testing.T) { // nolint:gocyclo // team pr
 		// Get expected upload cost
 		output, _ = getUploadCostInUnit(t, configPath, allocationID, file)
 
-		expectedUploadCostInZCN, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedUploadCostInZCN = unitToZCN(expectedUploadCostInZCN, unit)
+		expectedUploadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
 
 		// Expected cost is given in "per 720 hours", we need 1 hour
 		// Expected cost takes into account data+parity, so we divide by that
-		actualExpectedUploadCostInZCN := expectedUploadCostInZCN / ((2 + 2) * 720)
+		actualExpectedUploadCost, _, err := currency.DistributeCoin(expectedUploadCost, (2+2)*720)
+		require.Nil(t, err)
 
 		finalAllocation := getAllocation(t, allocationID)
-		actualCost := initialAllocation.WritePool - finalAllocation.WritePool
+		actualCost := subtractCoins(t, initialAllocation.WritePool, finalAllocation.WritePool)
 
 		// If a challenge has passed for upload, writepool balance should reduce, else, remain same
-		require.True(t, actualCost == 0 || intToZCN(actualCost) == actualExpectedUploadCostInZCN)
+		require.True(t, actualCost == 0 || actualCost == actualExpectedUploadCost)
 	})
 }
 
@@ -211,20 +206,17 @@ func Test___FlakyFileRename(t *testing.T) { // nolint:gocyclo // team preference
 		// Get expected upload cost
 		output, _ = getUploadCostInUnit(t, configPath, allocationID, localpath)
 
-		expectedUploadCostInZCN, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedUploadCostInZCN = unitToZCN(expectedUploadCostInZCN, unit)
+		expectedUploadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
 
 		// Expected cost is given in "per 720 hours", we need 1 hour
 		// Expected cost takes into account data+parity, so we divide by that
-		actualExpectedUploadCostInZCN := expectedUploadCostInZCN / ((2 + 2) * 720)
+		actualExpectedUploadCost, _, err := currency.DistributeCoin(expectedUploadCost, (2+2)*720)
+		require.Nil(t, err)
 
 		finalAllocation := getAllocation(t, allocationID)
 
-		actualCost := initialAllocation.WritePool - finalAllocation.WritePool
-		require.True(t, actualCost == 0 || intToZCN(actualCost) == actualExpectedUploadCostInZCN)
+		actualCost := subtractCoins(t, initialAllocation.WritePool, finalAllocation.WritePool)
+		require.True(t, actualCost == 0 || actualCost == actualExpectedUploadCost)
 		createAllocationTestTeardown(t, allocationID)
 	})
 }
@@ -278,20 +270,17 @@ func Test___FlakyFileCopy(t *testing.T) { // nolint:gocyclo // team preference i
 		// Get expected upload cost
 		output, _ = getUploadCostInUnit(t, configPath, allocationID, localpath)
 
-		expectedUploadCostInZCN, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedUploadCostInZCN = unitToZCN(expectedUploadCostInZCN, unit)
+		expectedUploadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
 
 		// Expected cost is given in "per 720 hours", we need 1 hour
 		// Expected cost takes into account data+parity, so we divide by that
-		actualExpectedUploadCostInZCN := expectedUploadCostInZCN / ((2 + 2) * 720)
+		actualExpectedUploadCost, _, err := currency.DistributeCoin(expectedUploadCost, (2+2)*720)
+		require.Nil(t, err)
 
 		finalAllocation := getAllocation(t, allocationID)
 
-		actualCost := initialAllocation.WritePool - finalAllocation.WritePool
-		require.True(t, actualCost == 0 || intToZCN(actualCost) == actualExpectedUploadCostInZCN)
+		actualCost := subtractCoins(t, initialAllocation.WritePool, finalAllocation.WritePool)
+		require.True(t, actualCost == 0 || actualCost == actualExpectedUploadCost)
 
 		createAllocationTestTeardown(t, allocationID)
 	})
@@ -342,20 +331,17 @@ func Test___FlakyFileMove(t *testing.T) { // nolint:gocyclo // team preference i
 		// Get expected upload cost
 		output, _ = getUploadCostInUnit(t, configPath, allocationID, localpath)
 
-		expectedUploadCostInZCN, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedUploadCostInZCN = unitToZCN(expectedUploadCostInZCN, unit)
+		expectedUploadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
 
 		// Expected cost is given in "per 720 hours", we need 1 hour
 		// Expected cost takes into account data+parity, so we divide by that
-		actualExpectedUploadCostInZCN := expectedUploadCostInZCN / ((2 + 2) * 720)
+		actualExpectedUploadCost, _, err := currency.DistributeCoin(expectedUploadCost, (2+2)*720)
+		require.Nil(t, err)
 
 		finalAllocation := getAllocation(t, allocationID)
 
-		actualCost := initialAllocation.WritePool - finalAllocation.WritePool
-		require.True(t, actualCost == 0 || intToZCN(actualCost) == actualExpectedUploadCostInZCN)
+		actualCost := subtractCoins(t, initialAllocation.WritePool, finalAllocation.WritePool)
+		require.True(t, actualCost == 0 || actualCost == actualExpectedUploadCost)
 
 		createAllocationTestTeardown(t, allocationID)
 	})
@@ -1058,10 +1058,7 @@ func Test___FlakyVestingPoolStop(t *testing.T) {
 		require.Len(t, output, 11, "expected output of length 11 atleast")
 		require.Equal(t, "destinations:", output[9])
 		require.Equal(t, "client_id:    "+wallet.ClientID, output[10])
-		canUnlockAmount, err := strconv.ParseFloat(regexp.MustCompile(`\d+\.?\d*`).FindString(output[2]), 64)
-		require.Nil(t, err, "error parsing float from vp-info")
-		canUnlockUnit := regexp.MustCompile("[um]?ZCN").FindString(output[2])
-		canUnlockAmount = unitToZCN(canUnlockAmount, canUnlockUnit)
+		canUnlockAmount := parseCoin(t, regexp.MustCompile(`\d+\.?\d* [um]?ZCN`).FindString(output[2]))
 
 		// token-accounting for this case: balance tokens should be unlockable
 		output, err = vestingPoolUnlock(t, configPath, createParams(map[string]interface{}{
@@ -1076,12 +1073,11 @@ func Test___FlakyVestingPoolStop(t *testing.T) {
 		require.Nil(t, err, "error fetching balance for target wallet")
 		require.Len(t, output, 1)
 		require.Regexp(t, regexp.MustCompile(`Balance: \d+\.?\d* [um]?ZCN \(\d+\.?\d* USD\)`), output[0])
-		newBalance := regexp.MustCompile(`\d+\.?\d* [um]?ZCN`).FindString(output[0])
-		newBalanceValue, err := strconv.ParseFloat(strings.Fields(newBalance)[0], 64)
-		require.Nil(t, err, "error parsing float from balance")
-		newBalanceInZCN := unitToZCN(newBalanceValue, strings.Fields(newBalance)[1])
+		newBalance, err := cliutils.ParseBalance(output[0])
+		require.Nil(t, err, "error parsing balance")
 		// Post-mainnet: turn to equal
-		require.InEpsilonf(t, 0.9+canUnlockAmount, newBalanceInZCN, 0.00000000001, "expected balance to be [%v] but was [%v]", 0.9+canUnlockAmount, newBalanceInZCN)
+		expectedBalance := addCoins(t, tokensToCoin(t, 0.9), canUnlockAmount)
+		require.Equalf(t, expectedBalance, newBalance, "expected balance to be [%v] but was [%v]", expectedBalance, newBalance)
 	})
 
 	// FIXME: this only stops last destination flag.
@@ -1145,10 +1141,7 @@ func Test___FlakyVestingPoolStop(t *testing.T) {
 		}), true)
 		require.Nil(t, err, "error fetching pool-info")
 		require.Len(t, output, 23, "expected output of length 23")
-		canUnlockAmount, err := strconv.ParseFloat(regexp.MustCompile(`\d+\.?\d*`).FindString(output[2]), 64)
-		require.Nil(t, err, "error parsing float from vp-info")
-		canUnlockUnit := regexp.MustCompile("[um]?ZCN").FindString(output[2])
-		canUnlockAmount = unitToZCN(canUnlockAmount, canUnlockUnit)
+		canUnlockAmount := parseCoin(t, regexp.MustCompile(`\d+\.?\d* [um]?ZCN`).FindString(output[2]))
 
 		output, err = vestingPoolUnlock(t, configPath, createParams(map[string]interface{}{
 			"pool_id": poolId,
@@ -1162,12 +1155,11 @@ func Test___FlakyVestingPoolStop(t *testing.T) {
 		require.Nil(t, err, "error fetching balance for target wallet")
 		require.Len(t, output, 1)
 		require.Regexp(t, regexp.MustCompile(`Balance: \d+\.?\d* [um]?ZCN \(\d+\.?\d* USD\)`), output[0])
-		newBalance := regexp.MustCompile(`\d+\.?\d* [um]?ZCN`).FindString(output[0])
-		newBalanceValue, err := strconv.ParseFloat(strings.Fields(newBalance)[0], 64)
-		require.Nil(t, err, "error parsing float from balance")
-		newBalanceInZCN := unitToZCN(newBalanceValue, strings.Fields(newBalance)[1])
+		newBalance, err := cliutils.ParseBalance(output[0])
+		require.Nil(t, err, "error parsing balance")
 		// Post-mainnet: turn to equal
-		require.InEpsilonf(t, 0.4+canUnlockAmount, newBalanceInZCN, 0.00000000001, "expected balance to be [%v] but was [%v]", 0.9+canUnlockAmount, newBalanceInZCN)
+		expectedBalance := addCoins(t, tokensToCoin(t, 0.4), canUnlockAmount)
+		require.Equalf(t, expectedBalance, newBalance, "expected balance to be [%v] but was [%v]", expectedBalance, newBalance)
 	})
 
 	t.Run("Vesting pool stop for someone else's pool must fail", func(t *testing.T) {
@@ -1295,13 +1287,9 @@ func Test___FlakyVestingPoolTokenAccounting(t *testing.T) {
 		require.Nil(t, err, "error fetching pool info")
 		require.Len(t, output, 18, "expected output of length 18")
 		ratio := math.Min((float64(currTime)-float64(startTime))/120, 1) // 120 is duration
-		expectedVestedAmount := 2 * ratio
 		// Round to 6 decimal places
-		expectedVestedAmount = math.Round(expectedVestedAmount*1e6) / 1e6
-		actualVestedAmount, err := strconv.ParseFloat(regexp.MustCompile(`\d+\.?\d*`).FindString(output[15]), 64)
-		require.Nil(t, err, "error parsing float from vp-info")
-		unit := regexp.MustCompile("[um]?ZCN").FindString(output[15])
-		actualVestedAmount = unitToZCN(actualVestedAmount, unit)
+		expectedVestedAmount := tokensToCoin(t, math.Round(2*ratio*1e6)/1e6)
+		actualVestedAmount := parseCoin(t, regexp.MustCompile(`\d+\.?\d* [um]?ZCN`).FindString(output[15]))
 		require.GreaterOrEqualf(t, actualVestedAmount, expectedVestedAmount,
 			"transferred amount [%v] should have been greater than or equal to expected transferred amount [%v]", actualVestedAmount, expectedVestedAmount)
 
@@ -1320,11 +1308,9 @@ func Test___FlakyVestingPoolTokenAccounting(t *testing.T) {
 		require.Nil(t, err, "error fetching balance for target wallet")
 		require.Len(t, output, 1)
 		require.Regexp(t, regexp.MustCompile(`Balance: \d+\.?\d* [um]?ZCN \(\d+\.?\d* USD\)`), output[0])
-		newBalance := regexp.MustCompile(`\d+\.?\d* [um]?ZCN`).FindString(output[0])
-		newBalanceValue, err := strconv.ParseFloat(strings.Fields(newBalance)[0], 64)
-		require.Nil(t, err, "error parsing float from balance")
-		newBalanceInZCN := unitToZCN(newBalanceValue, strings.Fields(newBalance)[1])
-		require.GreaterOrEqualf(t, newBalanceInZCN, actualVestedAmount,
+		newBalance, err := cliutils.ParseBalance(output[0])
+		require.Nil(t, err, "error parsing balance")
+		require.GreaterOrEqualf(t, newBalance, actualVestedAmount,
 			"amount in wallet after unlock should be greater or equal to transferred amount")
 	})
 
@@ -1378,12 +1364,9 @@ func Test___FlakyVestingPoolTokenAccounting(t *testing.T) {
 		require.Nil(t, err, "error fetching pool info")
 		require.GreaterOrEqual(t, len(output), 23, "expected output of length 23 or more")
 		ratio := math.Min((float64(currTime)-float64(startTime))/120, 1) // 120 is duration
-		expectedVestedAmount1 := 1 * ratio
-		expectedVestedAmount1 = math.Round(expectedVestedAmount1*1e6) / 1e6
-		actualVestedAmount1, err := strconv.ParseFloat(regexp.MustCompile(`\d+\.?\d*`).FindString(output[15]), 64)
-		require.Nil(t, err, "error parsing float from vp-info")
-		unit := regexp.MustCompile("[um]?ZCN").FindString(output[15])
-		actualVestedAmount1 = unitToZCN(actualVestedAmount1, unit)
+		// Round to 6 decimal places
+		expectedVestedAmount1 := tokensToCoin(t, math.Round(1*ratio*1e6)/1e6)
+		actualVestedAmount1 := parseCoin(t, regexp.MustCompile(`\d+\.?\d* [um]?ZCN`).FindString(output[15]))
 		require.GreaterOrEqualf(t, actualVestedAmount1, expectedVestedAmount1,
 			"transferred amount [%v] should have been greater than or equal to expected transferred amount [%v]", actualVestedAmount1, expectedVestedAmount1)
 
@@ -1402,11 +1385,9 @@ func Test___FlakyVestingPoolTokenAccounting(t *testing.T) {
 		require.Nil(t, err, "error fetching balance for target wallet")
 		require.Len(t, output, 1)
 		require.Regexp(t, regexp.MustCompile(`Balance: \d+\.?\d* [um]?ZCN \(\d+\.?\d* USD\)`), output[0])
-		newBalance := regexp.MustCompile(`\d+\.?\d* [um]?ZCN`).FindString(output[0])
-		newBalanceValue, err := strconv.ParseFloat(strings.Fields(newBalance)[0], 64)
-		require.Nil(t, err, "error parsing float from balance")
-		newBalanceInZCN := unitToZCN(newBalanceValue, strings.Fields(newBalance)[1])
-		require.GreaterOrEqualf(t, newBalanceInZCN, actualVestedAmount1,
+		newBalance, err := cliutils.ParseBalance(output[0])
+		require.Nil(t, err, "error parsing balance")
+		require.GreaterOrEqualf(t, newBalance, actualVestedAmount1,
 			"amount in wallet after unlock should be greater or equal to transferred amount")
 
 		output, err = vestingPoolInfo(t, configPath, createParams(map[string]interface{}{
@@ -1415,12 +1396,9 @@ func Test___FlakyVestingPoolTokenAccounting(t *testing.T) {
 		currTime = time.Now().Unix()
 		require.Nil(t, err, "error fetching pool info")
 		ratio = math.Min((float64(currTime)-float64(startTime))/120, 1) // 120 is duration
-		expectedVestedAmount2 := 2 * ratio
-		expectedVestedAmount2 = math.Round(expectedVestedAmount2*1e6) / 1e6
-		actualVestedAmount2, err := strconv.ParseFloat(regexp.MustCompile(`\d+\.?\d*`).FindString(output[22]), 64)
-		require.Nil(t, err, "error parsing float from vp-info")
-		unit = regexp.MustCompile("[um]?ZCN").FindString(output[22])
-		actualVestedAmount2 = unitToZCN(actualVestedAmount2, unit)
+		// Round to 6 decimal places
+		expectedVestedAmount2 := tokensToCoin(t, math.Round(2*ratio*1e6)/1e6)
+		actualVestedAmount2 := parseCoin(t, regexp.MustCompile(`\d+\.?\d* [um]?ZCN`).FindString(output[22]))
 		require.GreaterOrEqualf(t, actualVestedAmount2, expectedVestedAmount2,
 			"transferred amount [%v] should have been greater than or equal to expected transferred amount [%v]", actualVestedAmount2, expectedVestedAmount2)
 
@@ -1439,11 +1417,9 @@ func Test___FlakyVestingPoolTokenAccounting(t *testing.T) {
 		require.Nil(t, err, "error fetching balance for target wallet")
 		require.Len(t, output, 1)
 		require.Regexp(t, regexp.MustCompile(`Balance: \d+\.?\d* [um]?ZCN \(\d+\.?\d* USD\)`), output[0])
-		newBalance = regexp.MustCompile(`\d+\.?\d* [um]?ZCN`).FindString(output[0])
-		newBalanceValue, err = strconv.ParseFloat(strings.Fields(newBalance)[0], 64)
-		require.Nil(t, err, "error parsing float from balance")
-		newBalanceInZCN = unitToZCN(newBalanceValue, strings.Fields(newBalance)[1])
-		require.GreaterOrEqualf(t, newBalanceInZCN, actualVestedAmount2,
+		newBalance, err = cliutils.ParseBalance(output[0])
+		require.Nil(t, err, "error parsing balance")
+		require.GreaterOrEqualf(t, newBalance, actualVestedAmount2,
 			"amount in wallet after unlock should be greater or equal to transferred amount")
 	})
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/0chain/system_test/internal/api/util/crypto"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
 	"github.com/spf13/viper"
 )
@@ -17,6 +18,8 @@ func setupDefaultConfig() {
 	viper.SetDefault("nodes.miner03ID", "c6f4b8ce5da386b278ba8c4e6cf98b24b32d15bc675b4d12c95e082079c91937")
 	viper.SetDefault("nodes.sharder01ID", "ea26431f8adb7061766f1d6bbcc3b292d70dd59960d857f04b8a75e6a5bbe04f")
 	viper.SetDefault("nodes.sharder02ID", "30001a01a888584772b7fee13934021ab8557e0ed471c0a3a454e9164180aef1")
+	viper.SetDefault("verify_blocks", false)
+	viper.SetDefault("notarization_threshold", crypto.DefaultNotarizationThreshold)
 }
 
 // SetupConfig setups the main configuration system.
@@ -36,6 +39,8 @@ func setupConfig() {
 	miner03ID = viper.GetString("nodes.miner03ID")
 	sharder01ID = viper.GetString("nodes.sharder01ID")
 	sharder02ID = viper.GetString("nodes.sharder02ID")
+	verifyBlocks = viper.GetBool("verify_blocks")
+	notarizationThreshold = viper.GetInt("notarization_threshold")
 }
 
 const (
@@ -55,6 +60,10 @@ var (
 	miner03ID   string
 	sharder01ID string
 	sharder02ID string
+	// verifyBlocks checks the signatures and verification tickets of the blocks read by the block reward tests
+	verifyBlocks bool
+	// notarizationThreshold is the threshold_by_count percentage of the 0chain.yaml of the network
+	notarizationThreshold int
 )
 
 var (
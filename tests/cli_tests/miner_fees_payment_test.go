@@ -1,6 +1,7 @@
 package cli_tests
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,10 +13,14 @@ import (
 
 	apimodel "github.com/0chain/system_test/internal/api/model"
 	climodel "github.com/0chain/system_test/internal/cli/model"
-	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/0chain/system_test/internal/currency"
+	"github.com/0chain/system_test/internal/currency/rewards"
 	"github.com/stretchr/testify/require"
 )
 
+// txnFinalizationRounds is how many rounds a transaction the CLI confirmed may take to be in a finalized block of every sharder
+const txnFinalizationRounds = 5
+
 func TestMinerFeesPayment(t *testing.T) {
 	t.Skip("Skipped till re-done")
 	mnconfig := getMinerSCConfiguration(t)
@@ -48,8 +53,7 @@ func TestMinerFeesPayment(t *testing.T) {
 		output, err = sendTokens(t, configPath, targetWallet.ClientID, 0.5, escapedTestName(t), fee)
 		require.Nil(t, err, "error sending tokens", strings.Join(output, "\n"))
 
-		cliutils.Wait(t, 30*time.Second)
-		endBlock := getLatestFinalizedBlock(t)
+		endBlock := waitForTransactionRounds(t)
 
 		block := getBlockContainingTransaction(t, startBlock, endBlock, wallet, escapedTestName(t))
 		blockMinerId := block.Block.MinerId
@@ -89,8 +93,7 @@ func TestMinerFeesPayment(t *testing.T) {
 		}), true)
 		require.Nil(t, err, "error adding vesting pool", strings.Join(output, "\n"))
 
-		cliutils.Wait(t, 30*time.Second)
-		endBlock := getLatestFinalizedBlock(t)
+		endBlock := waitForTransactionRounds(t)
 
 		block := getBlockContainingTransaction(t, startBlock, endBlock, wallet, "vestingpool")
 		blockMinerId := block.Block.MinerId
@@ -122,9 +125,7 @@ func TestMinerFeesPayment(t *testing.T) {
 		require.Nil(t, err, "error locking read pool tokens", strings.Join(output, "\n"))
 
 		lockTimer := time.NewTimer(time.Minute)
-		cliutils.Wait(t, 30*time.Second)
-
-		endBlock := getLatestFinalizedBlock(t)
+		endBlock := waitForTransactionRounds(t)
 
 		block := getBlockContainingTransaction(t, startBlock, endBlock, wallet, "read_pool_lock")
 		blockMinerId := block.Block.MinerId
@@ -148,9 +149,7 @@ func TestMinerFeesPayment(t *testing.T) {
 		output, err = readPoolUnlock(t, configPath, createParams(map[string]interface{}{}), true)
 		require.Nil(t, err, "error unlocking read pool", strings.Join(output, "\n"))
 
-		cliutils.Wait(t, 30*time.Second)
-
-		endBlock = getLatestFinalizedBlock(t)
+		endBlock = waitForTransactionRounds(t)
 
 		block = getBlockContainingTransaction(t, startBlock, endBlock, wallet, "read_pool_unlock")
 		blockMinerId = block.Block.MinerId
@@ -185,9 +184,7 @@ func TestMinerFeesPayment(t *testing.T) {
 		require.Nil(t, err, "Failed to lock write tokens", strings.Join(output, "\n"))
 
 		lockTimer := time.NewTimer(time.Minute * 2)
-		cliutils.Wait(t, 30*time.Second)
-
-		endBlock := getLatestFinalizedBlock(t)
+		endBlock := waitForTransactionRounds(t)
 
 		block := getBlockContainingTransaction(t, startBlock, endBlock, wallet, "write_pool_lock")
 		blockMinerId := block.Block.MinerId
@@ -207,8 +204,7 @@ func TestMinerFeesPayment(t *testing.T) {
 		}), true)
 		require.Nil(t, err, "Unable to unlock tokens", strings.Join(output, "\n"))
 
-		cliutils.Wait(t, 30*time.Second)
-		endBlock = getLatestFinalizedBlock(t)
+		endBlock = waitForTransactionRounds(t)
 
 		block = getBlockContainingTransaction(t, startBlock, endBlock, wallet, "write_pool_unlock")
 		blockMinerId = block.Block.MinerId
@@ -254,8 +250,7 @@ func TestMinerFeesPayment(t *testing.T) {
 		require.Nil(t, err, "Error staking tokens", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
-		cliutils.Wait(t, 30*time.Second)
-		endBlock := getLatestFinalizedBlock(t)
+		endBlock := waitForTransactionRounds(t)
 
 		block := getBlockContainingTransaction(t, startBlock, endBlock, wallet, "stake_pool_lock")
 		blockMinerId := block.Block.MinerId
@@ -274,8 +269,7 @@ func TestMinerFeesPayment(t *testing.T) {
 		}))
 		require.Nil(t, err, "Error unstaking tokens from stake pool", strings.Join(output, "\n"))
 
-		cliutils.Wait(t, 30*time.Second)
-		endBlock = getLatestFinalizedBlock(t)
+		endBlock = waitForTransactionRounds(t)
 
 		block = getBlockContainingTransaction(t, startBlock, endBlock, wallet, "stake_pool_unlock")
 		blockMinerId = block.Block.MinerId
@@ -311,6 +305,17 @@ func apiGetLatestFinalized(sharderBaseURL string) (*http.Response, error) {
 	return http.Get(sharderBaseURL + "/v1/block/get/latest_finalized")
 }
 
+// waitForTransactionRounds waits until the transactions sent before are finalized and returns the latest finalized block from then
+func waitForTransactionRounds(t *testing.T) *apimodel.LatestFinalizedBlock {
+	ctx, cancel := context.WithTimeout(context.Background(), roundWaitTimeout)
+	defer cancel()
+
+	block, err := getRoundWatcher(t).WaitForRounds(ctx, txnFinalizationRounds)
+	require.NoError(t, err)
+
+	return block
+}
+
 func getLatestFinalizedBlock(t *testing.T) *apimodel.LatestFinalizedBlock {
 	sharders := getShardersList(t)
 	sharder := sharders[reflect.ValueOf(sharders).MapKeys()[0].String()]
@@ -331,28 +336,18 @@ func getLatestFinalizedBlock(t *testing.T) *apimodel.LatestFinalizedBlock {
 	return &block
 }
 
-func getExpectedMinerFees(t *testing.T, fee, minerShare float64, blockMiner *climodel.Node) (expectedMinerFee int64) {
-	// Expected miner fee is calculating using this formula:
-	// Fee * minerShare * miner.ServiceCharge
-	// Stakeholders' reward is:
-	// Fee * minerShare * (1 - miner.ServiceCharge)
-	// In case of no stakeholders, miner gets:
-	// Fee * minerShare
-	minerFee := ConvertToValue(fee * minerShare)
-	minerServiceCharge := int64(float64(minerFee) * blockMiner.Settings.ServiceCharge)
-	expectedMinerFee = minerServiceCharge
-	minerFeeRemaining := minerFee - minerServiceCharge
-
-	// If there is no stake, the miner gets entire fee.
-	// Else "Remaining" portion would be distributed to stake holders
-	// And hence not go the miner
-	if blockMiner.TotalStake == 0 {
-		expectedMinerFee += minerFeeRemaining
-	}
-	return expectedMinerFee
+func getExpectedMinerFees(t *testing.T, fee, minerShare float64, blockMiner *climodel.Node) currency.Coin {
+	// The miner's share of the fee is distributed to its stake pool:
+	// the miner gets its service charge and stakeholders the rest,
+	// in case of no stakeholders the miner gets all of it.
+	minerFee, _, err := rewards.ShareRatio(tokensToCoin(t, fee), minerShare)
+	require.Nil(t, err, "error calculating miner share of fee")
+	split, err := rewards.SplitReward(minerFee, blockMiner.Settings.ServiceCharge, nodeDelegates(t, blockMiner))
+	require.Nil(t, err, "error splitting fee between miner and stakeholders")
+	return split.Provider
 }
 
-func verifyMinerFeesPayment(t *testing.T, block *apimodel.Block, expectedMinerFee int64) bool {
+func verifyMinerFeesPayment(t *testing.T, block *apimodel.Block, expectedMinerFee currency.Coin) bool {
 	for _, txn := range block.Block.Transactions {
 		if strings.Contains(txn.TransactionData, "payFees") && strings.Contains(txn.TransactionData, fmt.Sprintf("%d", block.Block.Round)) {
 			var transfers []apimodel.Transfer
@@ -45,14 +45,10 @@ func TestBlobberConfigUpdate(t *testing.T) {
 		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "max_offer_duration": intialBlobberInfo.Terms.Max_offer_duration}))
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		max_stake, err := intialBlobberInfo.StakePoolSettings.MaxStake.Int64()
-		require.Nil(t, err)
-		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "max_stake": intToZCN(max_stake)}))
+		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "max_stake": intialBlobberInfo.StakePoolSettings.MaxStake}))
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		min_stake, err := intialBlobberInfo.StakePoolSettings.MinStake.Int64()
-		require.Nil(t, err)
-		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "min_stake": intToZCN(min_stake)}))
+		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "min_stake": intialBlobberInfo.StakePoolSettings.MinStake}))
 		require.Nil(t, err, strings.Join(output, "\n"))
 
 		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "min_lock_demand": intialBlobberInfo.Terms.Min_lock_demand}))
@@ -64,10 +60,10 @@ func TestBlobberConfigUpdate(t *testing.T) {
 		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "service_charge": intialBlobberInfo.StakePoolSettings.ServiceCharge}))
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "read_price": intToZCN(intialBlobberInfo.Terms.Read_price)}))
+		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "read_price": intialBlobberInfo.Terms.Read_price}))
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "write_price": intToZCN(intialBlobberInfo.Terms.Write_price)}))
+		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "write_price": intialBlobberInfo.Terms.Write_price}))
 		require.Nil(t, err, strings.Join(output, "\n"))
 	})
 
@@ -118,9 +114,7 @@ func TestBlobberConfigUpdate(t *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-		oldMaxStake, err := intialBlobberInfo.StakePoolSettings.MaxStake.Int64()
-		require.Nil(t, err)
-		newMaxStake := intToZCN(oldMaxStake) - 1
+		newMaxStake := subtractCoins(t, intialBlobberInfo.StakePoolSettings.MaxStake, tokensToCoin(t, 1))
 
 		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "max_stake": newMaxStake}))
 		require.Nil(t, err, strings.Join(output, "\n"))
@@ -134,18 +128,14 @@ func TestBlobberConfigUpdate(t *testing.T) {
 		err = json.Unmarshal([]byte(output[0]), &finalBlobberInfo)
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		max_stake, err := finalBlobberInfo.StakePoolSettings.MaxStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, float64(newMaxStake), intToZCN(max_stake))
+		require.Equal(t, newMaxStake, finalBlobberInfo.StakePoolSettings.MaxStake)
 	})
 
 	t.Run("update blobber min stake should work", func(t *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-		oldMinStake, err := intialBlobberInfo.StakePoolSettings.MinStake.Int64()
-		require.Nil(t, err)
-		newMinStake := intToZCN(oldMinStake) + 1
+		newMinStake := addCoins(t, intialBlobberInfo.StakePoolSettings.MinStake, tokensToCoin(t, 1))
 
 		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "min_stake": newMinStake}))
 		require.Nil(t, err, strings.Join(output, "\n"))
@@ -159,9 +149,7 @@ func TestBlobberConfigUpdate(t *testing.T) {
 		err = json.Unmarshal([]byte(output[0]), &finalBlobberInfo)
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		min_stake, err := finalBlobberInfo.StakePoolSettings.MinStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, float64(newMinStake), intToZCN(min_stake))
+		require.Equal(t, newMinStake, finalBlobberInfo.StakePoolSettings.MinStake)
 	})
 
 	t.Run("update blobber min lock demand should work", func(t *testing.T) {
@@ -275,8 +263,7 @@ func TestBlobberConfigUpdate(t *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-		oldReadPrice := intialBlobberInfo.Terms.Read_price
-		newReadPrice := intToZCN(oldReadPrice) + 1
+		newReadPrice := addCoins(t, intialBlobberInfo.Terms.Read_price, tokensToCoin(t, 1))
 
 		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "read_price": newReadPrice}))
 		require.Nil(t, err, strings.Join(output, "\n"))
@@ -291,15 +278,14 @@ func TestBlobberConfigUpdate(t *testing.T) {
 		err = json.Unmarshal([]byte(output[0]), &finalBlobberInfo)
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		require.Equal(t, newReadPrice, intToZCN(finalBlobberInfo.Terms.Read_price))
+		require.Equal(t, newReadPrice, finalBlobberInfo.Terms.Read_price)
 	})
 
 	t.Run("update blobber write price should work", func(t *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-		oldWritePrice := intialBlobberInfo.Terms.Write_price
-		newWritePrice := intToZCN(oldWritePrice) + 1
+		newWritePrice := addCoins(t, intialBlobberInfo.Terms.Write_price, tokensToCoin(t, 1))
 
 		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "write_price": newWritePrice}))
 		require.Nil(t, err, strings.Join(output, "\n"))
@@ -314,26 +300,22 @@ func TestBlobberConfigUpdate(t *testing.T) {
 		err = json.Unmarshal([]byte(output[0]), &finalBlobberInfo)
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		require.Equal(t, newWritePrice, intToZCN(finalBlobberInfo.Terms.Write_price))
+		require.Equal(t, newWritePrice, finalBlobberInfo.Terms.Write_price)
 	})
 
 	t.Run("update all params at once should work", func(t *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-		newWritePrice := intToZCN(intialBlobberInfo.Terms.Write_price) + 1
+		newWritePrice := addCoins(t, intialBlobberInfo.Terms.Write_price, tokensToCoin(t, 1))
 		newServiceCharge := intialBlobberInfo.StakePoolSettings.ServiceCharge + 0.1
-		newReadPrice := intToZCN(intialBlobberInfo.Terms.Read_price) + 1
+		newReadPrice := addCoins(t, intialBlobberInfo.Terms.Read_price, tokensToCoin(t, 1))
 		newNumberOfDelegates := intialBlobberInfo.StakePoolSettings.MaxNumDelegates + 1
 		newMaxOfferDuration := intialBlobberInfo.Terms.Max_offer_duration + 1*time.Second
 		newCapacity := intialBlobberInfo.Capacity + 1
 		newMinLockDemand := intialBlobberInfo.Terms.Min_lock_demand + 0.01
-		min_stake, err := intialBlobberInfo.StakePoolSettings.MinStake.Int64()
-		require.Nil(t, err)
-		newMinStake := intToZCN(min_stake) + 1
-		max_stake, err := intialBlobberInfo.StakePoolSettings.MaxStake.Int64()
-		require.Nil(t, err)
-		newMaxStake := intToZCN(max_stake) - 1
+		newMinStake := addCoins(t, intialBlobberInfo.StakePoolSettings.MinStake, tokensToCoin(t, 1))
+		newMaxStake := subtractCoins(t, intialBlobberInfo.StakePoolSettings.MaxStake, tokensToCoin(t, 1))
 
 		output, err = updateBlobberInfo(t, configPath, createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID, "write_price": newWritePrice, "service_charge": newServiceCharge, "read_price": newReadPrice, "num_delegates": newNumberOfDelegates, "max_offer_duration": newMaxOfferDuration, "capacity": newCapacity, "min_lock_demand": newMinLockDemand, "min_stake": newMinStake, "max_stake": newMaxStake}))
 		require.Nil(t, err, strings.Join(output, "\n"))
@@ -348,19 +330,15 @@ func TestBlobberConfigUpdate(t *testing.T) {
 		err = json.Unmarshal([]byte(output[0]), &finalBlobberInfo)
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		require.Equal(t, newWritePrice, intToZCN(finalBlobberInfo.Terms.Write_price))
+		require.Equal(t, newWritePrice, finalBlobberInfo.Terms.Write_price)
 		require.Equal(t, newServiceCharge, finalBlobberInfo.StakePoolSettings.ServiceCharge)
-		require.Equal(t, newReadPrice, intToZCN(finalBlobberInfo.Terms.Read_price))
+		require.Equal(t, newReadPrice, finalBlobberInfo.Terms.Read_price)
 		require.Equal(t, newNumberOfDelegates, finalBlobberInfo.StakePoolSettings.MaxNumDelegates)
 		require.Equal(t, newMaxOfferDuration, finalBlobberInfo.Terms.Max_offer_duration)
 		require.Equal(t, newCapacity, finalBlobberInfo.Capacity)
 		require.Equal(t, newMinLockDemand, finalBlobberInfo.Terms.Min_lock_demand)
-		min_stake, err = finalBlobberInfo.StakePoolSettings.MinStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, newMinStake, intToZCN(min_stake))
-		max_stake, err = finalBlobberInfo.StakePoolSettings.MaxStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, newMaxStake, intToZCN(max_stake))
+		require.Equal(t, newMinStake, finalBlobberInfo.StakePoolSettings.MinStake)
+		require.Equal(t, newMaxStake, finalBlobberInfo.StakePoolSettings.MaxStake)
 	})
 }
 
@@ -137,7 +137,7 @@ func TestCollaborator(t *testing.T) {
 		require.Equal(t, "locked", output[0])
 
 		readPool := getReadPoolInfo(t)
-		require.Equal(t, ConvertToValue(0.4), readPool.Balance, "Read Pool balance must be equal to locked amount")
+		require.Equal(t, tokensToCoin(t, 0.4), readPool.Balance, "Read Pool balance must be equal to locked amount")
 
 		output, err = downloadFileForWallet(t, collaboratorWalletName, configPath, createParams(map[string]interface{}{
 			"allocation": allocationID,
@@ -720,7 +720,7 @@ func TestCollaborator(t *testing.T) {
 		require.Equal(t, "locked", output[0])
 
 		readPool := getReadPoolInfo(t)
-		require.Equal(t, ConvertToValue(lockedTokens), readPool.Balance, "Read Pool balance must be equal to locked amount")
+		require.Equal(t, tokensToCoin(t, lockedTokens), readPool.Balance, "Read Pool balance must be equal to locked amount")
 
 		output, err = downloadFileForWallet(t, collaboratorWalletName, configPath, createParams(map[string]interface{}{
 			"allocation": allocationID,
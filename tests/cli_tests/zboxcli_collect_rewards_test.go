@@ -1,20 +1,32 @@
 package cli_tests
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/0chain/system_test/internal/api/util/wait"
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/0chain/system_test/internal/currency"
+	"github.com/0chain/system_test/internal/currency/rewards"
 	"github.com/stretchr/testify/require"
 )
 
+// rewardSplitTolerance is the fraction of the rewards of a stake pool the share of a delegate may be off by,
+// every payment rounds the share of every delegate down to the SAS
+const rewardSplitTolerance = 0.01
+
+// readRedeemRounds is how many rounds blobbers take at most to redeem the read markers of a download
+const readRedeemRounds = 30
+
 func TestCollectRewards(t *testing.T) {
 	t.Parallel()
 
@@ -50,6 +62,7 @@ func TestCollectRewards(t *testing.T) {
 		require.Len(t, output, 1)
 		require.Regexp(t, regexp.MustCompile("tokens locked, txn hash: ([a-f0-9]{64})"), output[0])
 
+		stakePoolBefore := getStakePool(t, "blobber_id", blobber.Id)
 		balanceBefore := getBalanceFromSharders(t, wallet.ClientID)
 
 		// Upload and download a file so blobber can accumulate rewards
@@ -78,27 +91,16 @@ func TestCollectRewards(t *testing.T) {
 		}), true)
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		cliutils.Wait(t, 30*time.Second)
+		// the blobber redeems the read marker of the download in a transaction of its own
+		ctx, cancel := context.WithTimeout(context.Background(), roundWaitTimeout)
+		defer cancel()
+		_, err = getRoundWatcher(t).WaitForRounds(ctx, readRedeemRounds)
+		require.Nil(t, err)
 
-		output, err = stakePoolInfo(t, configPath, createParams(map[string]interface{}{
-			"blobber_id": blobber.Id,
-			"json":       "",
-		}))
-		require.Nil(t, err, "error getting stake pool info")
-		require.Len(t, output, 1)
-		stakePoolAfter := climodel.StakePoolInfo{}
-		err = json.Unmarshal([]byte(output[0]), &stakePoolAfter)
-		require.Nil(t, err, "Error unmarshalling stake pool info", strings.Join(output, "\n"))
-		require.NotEmpty(t, stakePoolAfter)
-
-		rewards := int64(0)
-		for _, poolDelegateInfo := range stakePoolAfter.Delegate {
-			if poolDelegateInfo.DelegateID == wallet.ClientID {
-				rewards = poolDelegateInfo.TotalReward
-				break
-			}
-		}
-		require.Greater(t, rewards, int64(0))
+		stakePoolAfter := getStakePool(t, "blobber_id", blobber.Id)
+		reward := delegateReward(stakePoolAfter, wallet.ClientID)
+		require.Greater(t, reward, currency.Coin(0))
+		requireRewardsSplitByStake(t, stakePoolBefore, stakePoolAfter)
 
 		output, err = collectRewards(t, configPath, createParams(map[string]interface{}{
 			"provider_type": "blobber",
@@ -109,7 +111,71 @@ func TestCollectRewards(t *testing.T) {
 		require.Equal(t, "transferred reward tokens", output[0])
 
 		balanceAfter := getBalanceFromSharders(t, wallet.ClientID)
-		require.GreaterOrEqual(t, balanceAfter, balanceBefore+rewards) // greater or equal since more rewards can accumulate after we check stakepool
+		require.GreaterOrEqual(t, balanceAfter, addCoins(t, balanceBefore, reward)) // greater or equal since more rewards can accumulate after we check stakepool
+	})
+
+	t.Run("Test collect reward with valid pool and validator id should pass", func(t *testing.T) {
+		t.Parallel()
+
+		output, err := registerWallet(t, configPath)
+		require.Nil(t, err, "registering wallet failed", strings.Join(output, "\n"))
+
+		wallet, err := getWallet(t, configPath)
+		require.Nil(t, err, "error getting wallet")
+
+		output, err = executeFaucetWithTokens(t, configPath, 1.0)
+		require.Nil(t, err, "faucet execution failed", strings.Join(output, "\n"))
+
+		validators := []climodel.Validator{}
+		output, err = listValidators(t, configPath, createParams(map[string]interface{}{"json": ""}))
+		require.Nil(t, err, "Error listing validators", strings.Join(output, "\n"))
+		require.Len(t, output, 1)
+		err = json.Unmarshal([]byte(output[0]), &validators)
+		require.Nil(t, err, "Error unmarshalling validator list", strings.Join(output, "\n"))
+		require.True(t, len(validators) > 0, "No validators found in validator list")
+
+		// Pick a random validator
+		validator := validators[time.Now().Unix()%int64(len(validators))]
+
+		// Stake tokens against this validator
+		output, err = stakeTokens(t, configPath, createParams(map[string]interface{}{
+			"validator_id": validator.ID,
+			"tokens":       0.5,
+		}), true)
+		require.Nil(t, err, "Error staking tokens", strings.Join(output, "\n"))
+		require.Len(t, output, 1)
+		require.Regexp(t, regexp.MustCompile("tokens locked, txn hash: ([a-f0-9]{64})"), output[0])
+
+		stakePoolBefore := getStakePool(t, "validator_id", validator.ID)
+		balanceBefore := getBalanceFromSharders(t, wallet.ClientID)
+
+		// Validators are rewarded for validating challenges, which uploading a file greater than 1 MB generates
+		allocationID := setupAllocationAndReadLock(t, configPath, map[string]interface{}{
+			"size":   10 * MB,
+			"tokens": 1,
+		})
+		filename := generateFileAndUpload(t, allocationID, "/", 2*MB)
+		err = os.Remove(filename)
+		require.Nil(t, err)
+
+		stakePoolAfter, err := wait.Until(context.Background(), func() (*climodel.StakePoolInfo, bool, error) {
+			stakePool := getStakePool(t, "validator_id", validator.ID)
+			return stakePool, delegateReward(stakePool, wallet.ClientID) > 0, nil
+		}, wait.Options{Timeout: 5 * time.Minute, Interval: 10 * time.Second})
+		require.Nil(t, err, "validator %s paid no rewards to its delegate %s", validator.ID, wallet.ClientID)
+		reward := delegateReward(stakePoolAfter, wallet.ClientID)
+		requireRewardsSplitByStake(t, stakePoolBefore, stakePoolAfter)
+
+		output, err = collectRewards(t, configPath, createParams(map[string]interface{}{
+			"provider_type": "validator",
+			"provider_id":   validator.ID,
+		}), true)
+		require.Nil(t, err, "Error collecting rewards", strings.Join(output, "\n"))
+		require.Len(t, output, 1)
+		require.Equal(t, "transferred reward tokens", output[0])
+
+		balanceAfter := getBalanceFromSharders(t, wallet.ClientID)
+		require.GreaterOrEqual(t, balanceAfter, addCoins(t, balanceBefore, reward)) // greater or equal since more rewards can accumulate after we check stakepool
 	})
 
 	t.Run("Test collect reward with invalid blobber id should fail", func(t *testing.T) {
@@ -210,3 +276,78 @@ func collectRewards(t *testing.T, cliConfigFilename, params string, retry bool)
 		return cliutils.RunCommandWithoutRetry(cmd)
 	}
 }
+
+// getStakePool returns the stake pool of the blobber or validator providerID, providerFlag being blobber_id or validator_id
+func getStakePool(t *testing.T, providerFlag, providerID string) *climodel.StakePoolInfo {
+	output, err := stakePoolInfo(t, configPath, createParams(map[string]interface{}{
+		providerFlag: providerID,
+		"json":       "",
+	}))
+	require.Nil(t, err, "error getting stake pool info", strings.Join(output, "\n"))
+	require.Len(t, output, 1)
+
+	stakePool := &climodel.StakePoolInfo{}
+	err = json.Unmarshal([]byte(output[0]), stakePool)
+	require.Nil(t, err, "Error unmarshalling stake pool info", strings.Join(output, "\n"))
+	require.NotEmpty(t, stakePool)
+
+	return stakePool
+}
+
+// delegateReward returns the total reward of the pool delegateID has in stakePool
+func delegateReward(stakePool *climodel.StakePoolInfo, delegateID string) currency.Coin {
+	for _, pool := range stakePool.Delegate {
+		if pool.DelegateID == delegateID {
+			return pool.TotalReward
+		}
+	}
+	return 0
+}
+
+// activeDelegates returns the active pools of stakePool by ID, the ones its rewards are distributed to
+func activeDelegates(stakePool *climodel.StakePoolInfo) map[string]climodel.StakePoolDelegatePoolInfo {
+	delegates := make(map[string]climodel.StakePoolDelegatePoolInfo, len(stakePool.Delegate))
+	for _, pool := range stakePool.Delegate {
+		if strings.EqualFold(pool.Status, "active") {
+			delegates[pool.ID] = pool
+		}
+	}
+	return delegates
+}
+
+// requireRewardsSplitByStake checks that the rewards the delegates of a stake pool earned between the before and after
+// snapshots were shared between them in proportion of their stakes, as rewards.SplitReward computes it.
+// The service charge is taken out of every payment before the delegates share it, so it does not matter here.
+// There is nothing to check if the active pools or their stakes changed in between, other tests may stake on the provider.
+func requireRewardsSplitByStake(t *testing.T, before, after *climodel.StakePoolInfo) {
+	poolsBefore, poolsAfter := activeDelegates(before), activeDelegates(after)
+	stakes := func(pools map[string]climodel.StakePoolDelegatePoolInfo) map[string]currency.Coin {
+		result := make(map[string]currency.Coin, len(pools))
+		for id, pool := range pools {
+			result[id] = pool.Balance
+		}
+		return result
+	}
+	if !reflect.DeepEqual(stakes(poolsBefore), stakes(poolsAfter)) {
+		t.Logf("delegates of stake pool %s changed during the test, not checking how its rewards were split", after.ID)
+		return
+	}
+
+	var (
+		delegates []rewards.Delegate
+		total     currency.Coin
+	)
+	earned := make(map[string]currency.Coin, len(poolsAfter))
+	for id, pool := range poolsAfter {
+		delegates = append(delegates, rewards.Delegate{ID: id, Stake: pool.Balance})
+		earned[id] = subtractCoins(t, pool.TotalReward, poolsBefore[id].TotalReward)
+		total = addCoins(t, total, earned[id])
+	}
+
+	split, err := rewards.SplitReward(total, 0, delegates)
+	require.Nil(t, err, "splitting %v between the delegates of stake pool %s", total, after.ID)
+	for id, expected := range split.Delegates {
+		require.InDelta(t, float64(expected), float64(earned[id]), float64(total)*rewardSplitTolerance+1,
+			"reward of delegate %s of stake pool %s", id, after.ID)
+	}
+}
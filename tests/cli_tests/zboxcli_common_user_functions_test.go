@@ -11,14 +11,14 @@ import (
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/0chain/system_test/internal/currency"
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	KB               = 1024      // kilobyte
-	MB               = 1024 * KB // megabyte
-	GB               = 1024 * MB // gigabyte
-	TOKEN_UNIT int64 = 1e10
+	KB = 1024      // kilobyte
+	MB = 1024 * KB // megabyte
+	GB = 1024 * MB // gigabyte
 )
 
 func TestCommonUserFunctions(t *testing.T) {
@@ -221,12 +221,45 @@ func getAllocationWithRetry(t *testing.T, cliConfigFilename, allocationID string
 	return output, err
 }
 
-// ConvertToToken converts the value to ZCN tokens
-func ConvertToToken(value int64) float64 {
-	return float64(value) / float64(TOKEN_UNIT)
+// tokensToCoin converts tokens given to the CLIs in ZCN into the exact amount, it is the only place tests go through floats
+func tokensToCoin(t *testing.T, tokens float64) currency.Coin {
+	coin, err := currency.ParseZCN(tokens)
+	require.NoError(t, err, "converting %v ZCN", tokens)
+	return coin
 }
 
-// ConvertToValue converts ZCN tokens to value
-func ConvertToValue(token float64) int64 {
-	return int64(token * float64(TOKEN_UNIT))
+// parseCoin parses an amount the CLIs print along with its unit, such as "1.5 mZCN"
+func parseCoin(t *testing.T, amount string) currency.Coin {
+	coin, err := currency.ParseCoin(amount)
+	require.NoError(t, err, "parsing amount [%s]", amount)
+	return coin
+}
+
+// addCoins sums coins, failing the test if they overflow
+func addCoins(t *testing.T, coins ...currency.Coin) currency.Coin {
+	var (
+		sum currency.Coin
+		err error
+	)
+	for _, coin := range coins {
+		sum, err = currency.AddCoin(sum, coin)
+		require.NoError(t, err, "adding %d to %d", coin, sum)
+	}
+	return sum
+}
+
+// multiplyCoin multiplies c by n, failing the test if they overflow
+func multiplyCoin(t *testing.T, c currency.Coin, n int64) currency.Coin {
+	times, err := currency.Int64ToCoin(n)
+	require.NoError(t, err, "multiplying %d by %d", c, n)
+	product, err := currency.MultCoin(c, times)
+	require.NoError(t, err, "multiplying %d by %d", c, n)
+	return product
+}
+
+// subtractCoins subtracts b from a, failing the test if b is greater
+func subtractCoins(t *testing.T, a, b currency.Coin) currency.Coin {
+	difference, err := currency.MinusCoin(a, b)
+	require.NoError(t, err, "subtracting %d from %d", b, a)
+	return difference
 }
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/hex"
 	"encoding/json"
-	"io"
 	"net/http"
 	"os"
 	"regexp"
@@ -19,8 +18,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	apimodel "github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/smartcontract/storagesc"
 	crypto "github.com/0chain/system_test/internal/api/util/crypto"
+	"github.com/0chain/system_test/internal/api/wallet"
 	climodel "github.com/0chain/system_test/internal/cli/model"
+	"github.com/0chain/system_test/internal/currency"
 )
 
 const (
@@ -132,14 +134,16 @@ func TestCreateAllocationFreeStorage(t *testing.T) {
 		readPoolFraction, err := strconv.ParseFloat(cfg[configKeyReadPoolFraction], 64)
 		require.Nil(t, err, "Read pool fraction config is not float: %s", cfg[configKeyReadPoolFraction])
 
-		wantReadPoolFraction := marker.FreeTokens * readPoolFraction
-		wantWritePoolToken := marker.FreeTokens - wantReadPoolFraction
+		freeTokens := tokensToCoin(t, marker.FreeTokens)
+		wantReadPoolFraction, err := currency.MultFloat64(freeTokens, readPoolFraction)
+		require.Nil(t, err, "error calculating read pool fraction of free tokens")
+		wantWritePoolToken := subtractCoins(t, freeTokens, wantReadPoolFraction)
 
 		allocation := getAllocation(t, allocationID)
-		require.Equal(t, ConvertToValue(wantWritePoolToken), allocation.WritePool, "Expected write pool amount not met", strings.Join(output, "\n"))
+		require.Equal(t, wantWritePoolToken, allocation.WritePool, "Expected write pool amount not met", strings.Join(output, "\n"))
 
 		readPool := getReadPoolInfo(t)
-		require.Equal(t, ConvertToValue(wantReadPoolFraction), readPool.Balance, "Read Pool balance must be equal to locked amount")
+		require.Equal(t, wantReadPoolFraction, readPool.Balance, "Read Pool balance must be equal to locked amount")
 	})
 
 	t.Run("Create free storage with malformed marker should fail", func(t *testing.T) {
@@ -294,18 +298,10 @@ func init() {
 }
 
 func readWalletFile(t *testing.T, file string) *climodel.WalletFile {
-	wallet := &climodel.WalletFile{}
-
-	f, err := os.Open(file)
-	require.Nil(t, err, "wallet file %s not found", file)
-
-	ownerWalletBytes, err := io.ReadAll(f)
+	walletFile, err := wallet.Load(file)
 	require.Nil(t, err, "error reading wallet file %s", file)
 
-	err = json.Unmarshal(ownerWalletBytes, wallet)
-	require.Nil(t, err, "error marshaling wallet content")
-
-	return wallet
+	return walletFile
 }
 
 func sendTxn(miners climodel.NodeList, txn *apimodel.Transaction) error {
@@ -339,15 +335,17 @@ func apiPutTransaction(minerBaseURL string, txn *apimodel.Transaction) (*http.Re
 }
 
 func freeAllocationAssignerTxn(t *testing.T, from, assigner *climodel.WalletFile) *apimodel.Transaction {
-	txn := &apimodel.Transaction{}
+	txn := storagesc.AddFreeStorageAssigner(&storagesc.FreeStorageAssignerRequest{
+		Name:            assigner.ClientID,
+		PublicKey:       assigner.ClientKey,
+		IndividualLimit: freeTokensIndividualLimit,
+		TotalLimit:      freeTokensTotalLimit,
+	})
 	txn.Version = "1.0"
 	txn.ClientId = from.ClientID
 	txn.CreationDate = time.Now().Unix()
 	txn.ChainId = chainID
 	txn.PublicKey = from.ClientKey
-	txn.TransactionType = txnTypeSmartContract
-	txn.ToClientId = storageSmartContractAddress
-	txn.TransactionValue = 0
 	ret, err := getNonceForWallet(t, configPath, scOwnerWallet, true)
 	require.Nil(t, err, "error fetching minerNodeDelegate nonce")
 	nonceStr := strings.Split(ret[0], ":")[1]
@@ -355,21 +353,11 @@ func freeAllocationAssignerTxn(t *testing.T, from, assigner *climodel.WalletFile
 	require.Nil(t, err, "error converting nonce to in")
 	txn.TransactionNonce = int(nonce) + 1
 
-	input := map[string]interface{}{
-		"name":             assigner.ClientID,
-		"public_key":       assigner.ClientKey,
-		"individual_limit": freeTokensIndividualLimit,
-		"total_limit":      freeTokensTotalLimit,
-	}
-
-	sn := apimodel.SmartContractTxnData{Name: "add_free_storage_assigner", InputArgs: input}
-	snBytes, err := json.Marshal(sn)
-	require.Nil(t, err, "error marshaling smart contract data")
-
-	txn.TransactionData = string(snBytes)
 	crypto.HashTransaction(txn)
-	keypair := crypto.GenerateKeys(t, from.Mnemonic)
-	crypto.SignTransaction(txn, keypair)
+	keypair, err := wallet.DeriveKeys(crypto.BLS0Chain, from.Mnemonic)
+	require.Nil(t, err, "error deriving keys from mnemonic")
+	err = crypto.SignTransaction(txn, keypair)
+	require.Nil(t, err, "error signing transaction")
 
 	return txn
 }
@@ -5,7 +5,6 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -69,7 +68,7 @@ func TestFileDownloadTokenMovement(t *testing.T) {
 
 		// Read pool before download
 		initialReadPool := getReadPoolInfo(t)
-		require.Equal(t, ConvertToValue(lockedTokens), initialReadPool.Balance)
+		require.Equal(t, tokensToCoin(t, lockedTokens), initialReadPool.Balance)
 
 		output, err = getDownloadCost(t, configPath, createParams(map[string]interface{}{
 			"allocation": allocationID,
@@ -78,11 +77,7 @@ func TestFileDownloadTokenMovement(t *testing.T) {
 		require.Nil(t, err, "Could not get download cost", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
-		expectedDownloadCost, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedDownloadCostInZCN := unitToZCN(expectedDownloadCost, unit)
+		expectedDownloadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
 
 		// Download the file
 		output, err = downloadFile(t, configPath, createParams(map[string]interface{}{
@@ -102,7 +97,7 @@ func TestFileDownloadTokenMovement(t *testing.T) {
 		time.Sleep(time.Second * 20)
 
 		// Read pool after download
-		expectedPoolBalance := ConvertToValue(lockedTokens) - ConvertToValue(expectedDownloadCostInZCN)
+		expectedPoolBalance := subtractCoins(t, tokensToCoin(t, lockedTokens), expectedDownloadCost)
 		updatedReadPool, err := getReadPoolUpdate(t, initialReadPool, 5)
 		require.NoError(t, err)
 		require.Equal(t, expectedPoolBalance, updatedReadPool.Balance, "Read Pool balance must be equal to (initial balance-download cost)")
@@ -146,18 +141,3 @@ func getDownloadCostWithWallet(t *testing.T, wallet, cliConfigFilename, params s
 		return cliutils.RunCommandWithoutRetry(cmd)
 	}
 }
-
-func unitToZCN(unitCost float64, unit string) float64 {
-	switch unit {
-	case "SAS", "sas":
-		unitCost /= 1e10
-		return unitCost
-	case "uZCN", "uzcn":
-		unitCost /= 1e6
-		return unitCost
-	case "mZCN", "mzcn":
-		unitCost /= 1e3
-		return unitCost
-	}
-	return unitCost
-}
@@ -15,6 +15,7 @@ import (
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/0chain/system_test/internal/currency"
 	"github.com/stretchr/testify/require"
 )
 
@@ -99,20 +100,17 @@ func TestFileUpdate(t *testing.T) {
 		// Get expected upload cost
 		output, _ = getUploadCostInUnit(t, configPath, allocationID, localpath)
 
-		expectedUploadCostInZCN, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedUploadCostInZCN = unitToZCN(expectedUploadCostInZCN, unit)
+		expectedUploadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
 
 		// Expected cost is given in "per 720 hours", we need 1 hour
 		// Expected cost takes into account data+parity, so we divide by that
-		actualExpectedUploadCostInZCN := expectedUploadCostInZCN / ((2 + 2) * 720)
+		actualExpectedUploadCost, _, err := currency.DistributeCoin(expectedUploadCost, (2+2)*720)
+		require.Nil(t, err)
 
 		finalAllocation := getAllocation(t, allocationID)
 
-		actualCost := initialAllocation.WritePool - finalAllocation.WritePool
-		require.True(t, actualCost == 0 || intToZCN(actualCost) == actualExpectedUploadCostInZCN)
+		actualCost := subtractCoins(t, initialAllocation.WritePool, finalAllocation.WritePool)
+		require.True(t, actualCost == 0 || actualCost == actualExpectedUploadCost)
 
 		createAllocationTestTeardown(t, allocationID)
 	})
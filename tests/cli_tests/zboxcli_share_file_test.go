@@ -7,7 +7,6 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -1142,7 +1141,7 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Error unmarshalling read pool", strings.Join(output, "\n"))
 		require.NotEmpty(t, initialReadPool)
 
-		require.Equal(t, 0.4, initialReadPool.Balance)
+		require.Equal(t, tokensToCoin(t, 0.4), initialReadPool.Balance)
 
 		output, err = getDownloadCost(t, configPath, createParams(map[string]interface{}{
 			"allocation": allocationID,
@@ -1151,12 +1150,8 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Could not get download cost", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
-		expectedDownloadCost, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedDownloadCostInSas := unitToZCN(expectedDownloadCost, unit) * 1e10
-		t.Logf("Download cost: %v sas", expectedDownloadCostInSas)
+		expectedDownloadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
+		t.Logf("Download cost: %v", expectedDownloadCost)
 
 		// Download the file (delete local copy first)
 		os.Remove(file)
@@ -1181,10 +1176,8 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Error unmarshalling read pool", strings.Join(output, "\n"))
 		require.NotEmpty(t, finalReadPool)
 
-		expectedRPBalance := 0.4*1e10 - expectedDownloadCostInSas
-
-		// todo: finalReadPool.OwnerBalance might be in ZCN format
-		require.Equal(t, expectedRPBalance, float64(finalReadPool.Balance))
+		expectedRPBalance := subtractCoins(t, tokensToCoin(t, 0.4), expectedDownloadCost)
+		require.Equal(t, expectedRPBalance, finalReadPool.Balance)
 	})
 
 	// FIXME download cost is not affecting read pool if downloading through auth ticket
@@ -1239,7 +1232,7 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Error unmarshalling read pool", strings.Join(output, "\n"))
 		require.NotEmpty(t, initialReadPool)
 
-		require.Equal(t, 0.4, initialReadPool.Balance)
+		require.Equal(t, tokensToCoin(t, 0.4), initialReadPool.Balance)
 
 		output, err = getDownloadCost(t, configPath, createParams(map[string]interface{}{
 			"allocation": allocationID,
@@ -1248,12 +1241,8 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Could not get download cost", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
-		expectedDownloadCost, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedDownloadCostInSas := unitToZCN(expectedDownloadCost, unit) * 1e10
-		t.Logf("Download cost: %v sas", expectedDownloadCostInSas)
+		expectedDownloadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
+		t.Logf("Download cost: %v", expectedDownloadCost)
 
 		// Download the file (delete local copy first)
 		os.Remove(file)
@@ -1281,10 +1270,8 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Error unmarshalling read pool", strings.Join(output, "\n"))
 		require.NotEmpty(t, finalReadPool)
 
-		expectedRPBalance := 0.4*1e10 - expectedDownloadCostInSas
-
-		// todo: finalReadPool.OwnerBalance might be in ZCN format
-		require.Equal(t, expectedRPBalance, float64(finalReadPool.Balance))
+		expectedRPBalance := subtractCoins(t, tokensToCoin(t, 0.4), expectedDownloadCost)
+		require.Equal(t, expectedRPBalance, finalReadPool.Balance)
 	})
 
 	t.Run("Share encrypted file using auth ticket - download accounting test where 3rd party pays - proxy re-encryption ", func(t *testing.T) {
@@ -1361,7 +1348,7 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Error unmarshalling read pool", strings.Join(output, "\n"))
 		require.NotEmpty(t, initialReadPool)
 
-		require.Equal(t, 0.4, initialReadPool.Balance)
+		require.Equal(t, tokensToCoin(t, 0.4), initialReadPool.Balance)
 
 		output, err = getDownloadCost(t, configPath, createParams(map[string]interface{}{
 			"allocation": allocationID,
@@ -1370,12 +1357,8 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Could not get download cost", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
-		expectedDownloadCostInZCN, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedDownloadCostInZCN = unitToZCN(expectedDownloadCostInZCN, unit) * 1e10
-		t.Logf("Download cost: %v", expectedDownloadCostInZCN)
+		expectedDownloadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
+		t.Logf("Download cost: %v", expectedDownloadCost)
 
 		// Download the file (delete local copy first)
 		os.Remove(file)
@@ -1404,8 +1387,7 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Error unmarshalling read pool", strings.Join(output, "\n"))
 		require.NotEmpty(t, finalReadPool)
 
-		// todo: finalReadPool.Balance might be in ZCN format
-		require.EqualValues(t, 0.4*1e10, float64(finalReadPool.Balance))
+		require.Equal(t, tokensToCoin(t, 0.4), finalReadPool.Balance)
 	})
 
 	t.Run("Share unencrypted file using auth ticket - download accounting test where 3rd party pays ", func(t *testing.T) {
@@ -1473,7 +1455,7 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Error unmarshalling read pool", strings.Join(output, "\n"))
 		require.NotEmpty(t, initialReadPool)
 
-		require.Equal(t, 0.4*1e10, initialReadPool.Balance, "read pool balance did not match expected")
+		require.Equal(t, tokensToCoin(t, 0.4), initialReadPool.Balance, "read pool balance did not match expected")
 
 		output, err = getDownloadCost(t, configPath, createParams(map[string]interface{}{
 			"allocation": allocationID,
@@ -1482,12 +1464,8 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Could not get download cost", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
-		expectedDownloadCostInZCN, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
-		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
-
-		unit := strings.Fields(output[0])[1]
-		expectedDownloadCostInZCN = unitToZCN(expectedDownloadCostInZCN, unit) * 1e10
-		t.Logf("Download cost: %v", expectedDownloadCostInZCN)
+		expectedDownloadCost := parseCoin(t, strings.Join(strings.Fields(output[0])[:2], " "))
+		t.Logf("Download cost: %v", expectedDownloadCost)
 
 		// Download the file (delete local copy first)
 		os.Remove(filename)
@@ -1513,8 +1491,7 @@ func TestShareFile(t *testing.T) {
 		require.Nil(t, err, "Error unmarshalling read pool", strings.Join(output, "\n"))
 		require.NotEmpty(t, finalReadPool)
 
-		// todo: finalReadPool.Balance might be in ZCN format
-		require.EqualValues(t, 0.4*1e10, float64(finalReadPool.Balance))
+		require.Equal(t, tokensToCoin(t, 0.4), finalReadPool.Balance)
 	})
 }
 
@@ -15,6 +15,7 @@ import (
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/0chain/system_test/internal/currency"
 )
 
 var (
@@ -533,6 +534,10 @@ func createParams(params map[string]interface{}) string {
 	var builder strings.Builder
 
 	for k, v := range params {
+		if coin, ok := v.(currency.Coin); ok {
+			// the CLIs take amounts in ZCN
+			v, _ = coin.FormatAmount(currency.ZCN)
+		}
 		if v == nil {
 			_, _ = builder.WriteString(fmt.Sprintf("--%s ", k))
 		} else if reflect.TypeOf(v).String() == "bool" {
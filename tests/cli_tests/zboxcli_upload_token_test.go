@@ -13,8 +13,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-const tokenUnit float64 = 1e+10
-
 func TestFileUploadTokenMovement(t *testing.T) {
 	t.Parallel()
 
@@ -78,7 +76,7 @@ func TestFileUploadTokenMovement(t *testing.T) {
 		allocationID := strings.Fields(output[0])[2]
 
 		allocation := getAllocation(t, allocationID)
-		require.Equal(t, 0.8, intToZCN(allocation.WritePool))
+		require.Equal(t, tokensToCoin(t, 0.8), allocation.WritePool)
 	})
 }
 
@@ -94,7 +92,3 @@ func challengePoolInfo(t *testing.T, cliConfigFilename, allocationID string) ([]
 	t.Logf("Getting challenge pool info...")
 	return cliutils.RunCommand(t, "./zbox cp-info --allocation "+allocationID+" --json --silent --wallet "+escapedTestName(t)+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
 }
-
-func intToZCN(balance int64) float64 {
-	return float64(balance) / tokenUnit
-}
@@ -37,10 +37,10 @@ func TestValidatorConfigUpdate(t *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-		output, err = updateValidatorInfo(t, configPath, createParams(map[string]interface{}{"validator_id": intialValidatorInfo.ID, "max_stake": intToZCN(intialValidatorInfo.MaxStake)}))
+		output, err = updateValidatorInfo(t, configPath, createParams(map[string]interface{}{"validator_id": intialValidatorInfo.ID, "max_stake": intialValidatorInfo.MaxStake}))
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		output, err = updateValidatorInfo(t, configPath, createParams(map[string]interface{}{"validator_id": intialValidatorInfo.ID, "min_stake": intToZCN(intialValidatorInfo.MinStake)}))
+		output, err = updateValidatorInfo(t, configPath, createParams(map[string]interface{}{"validator_id": intialValidatorInfo.ID, "min_stake": intialValidatorInfo.MinStake}))
 		require.Nil(t, err, strings.Join(output, "\n"))
 
 		output, err = updateValidatorInfo(t, configPath, createParams(map[string]interface{}{"validator_id": intialValidatorInfo.ID, "num_delegates": intialValidatorInfo.NumDelegates}))
@@ -54,8 +54,7 @@ func TestValidatorConfigUpdate(t *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-		oldMaxStake := intialValidatorInfo.MaxStake
-		newMaxStake := intToZCN(oldMaxStake) - 1
+		newMaxStake := subtractCoins(t, intialValidatorInfo.MaxStake, tokensToCoin(t, 1))
 
 		output, err = updateValidatorInfo(t, configPath, createParams(map[string]interface{}{"validator_id": intialValidatorInfo.ID, "max_stake": newMaxStake}))
 		require.Nil(t, err, strings.Join(output, "\n"))
@@ -69,15 +68,14 @@ func TestValidatorConfigUpdate(t *testing.T) {
 		err = json.Unmarshal([]byte(output[0]), &finalValidatorInfo)
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		require.Equal(t, float64(newMaxStake), intToZCN(finalValidatorInfo.MaxStake))
+		require.Equal(t, newMaxStake, finalValidatorInfo.MaxStake)
 	})
 
 	t.Run("update blobber min stake should work", func(t *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-		oldMinStake := intialValidatorInfo.MinStake
-		newMinStake := intToZCN(oldMinStake) + 1
+		newMinStake := addCoins(t, intialValidatorInfo.MinStake, tokensToCoin(t, 1))
 
 		output, err = updateValidatorInfo(t, configPath, createParams(map[string]interface{}{"validator_id": intialValidatorInfo.ID, "min_stake": newMinStake}))
 		require.Nil(t, err, strings.Join(output, "\n"))
@@ -91,7 +89,7 @@ func TestValidatorConfigUpdate(t *testing.T) {
 		err = json.Unmarshal([]byte(output[0]), &finalValidatorInfo)
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		require.Equal(t, float64(newMinStake), intToZCN(finalValidatorInfo.MinStake))
+		require.Equal(t, newMinStake, finalValidatorInfo.MinStake)
 	})
 
 	t.Run("update validator number of delegates should work", func(t *testing.T) {
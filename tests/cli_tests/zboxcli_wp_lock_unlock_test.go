@@ -60,7 +60,7 @@ func TestWritePoolLockUnlock(t *testing.T) {
 
 		// Write pool balance should increment to 1
 		allocation := getAllocation(t, allocationID)
-		require.Equal(t, 1.5, intToZCN(allocation.WritePool))
+		require.Equal(t, tokensToCoin(t, 1.5), allocation.WritePool)
 
 		output, err = cancelAllocation(t, configPath, allocationID, true)
 		require.Nil(t, err)
@@ -128,7 +128,7 @@ func TestWritePoolLockUnlock(t *testing.T) {
 
 		// Write pool balance should increment to 1
 		allocation := getAllocation(t, allocationID)
-		require.Equal(t, 1.5, intToZCN(allocation.WritePool))
+		require.Equal(t, tokensToCoin(t, 1.5), allocation.WritePool)
 
 		// Wait for allocation to expire
 		cliutils.Wait(t, time.Minute*5)
@@ -356,7 +356,7 @@ func TestWritePoolLockUnlock(t *testing.T) {
 		cliutils.Wait(t, 5*time.Second)
 
 		allocation := getAllocation(t, allocationID)
-		require.Equal(t, 1.5, intToZCN(allocation.WritePool))
+		require.Equal(t, tokensToCoin(t, 1.5), allocation.WritePool)
 
 		params = createParams(map[string]interface{}{
 			"allocation": allocationID,
@@ -15,6 +15,7 @@ import (
 	apimodel "github.com/0chain/system_test/internal/api/model"
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/0chain/system_test/internal/currency"
 	"github.com/stretchr/testify/require"
 )
 
@@ -63,7 +64,7 @@ func TestMinerStake(t *testing.T) {
 
 		poolsInfo, err := pollForPoolInfo(t, miner.ID)
 		require.Nil(t, err)
-		require.Equal(t, float64(1), intToZCN(poolsInfo.Balance))
+		require.Equal(t, tokensToCoin(t, 1), poolsInfo.Balance)
 
 		// Unlock should work
 		output, err = minerOrSharderUnlock(t, configPath, createParams(map[string]interface{}{
@@ -129,7 +130,7 @@ func TestMinerStake(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, poolsInfo.Pools[miner.ID], 1)
 
-		require.Equal(t, float64(2), intToZCN(poolsInfo.Pools[miner.ID][0].Balance))
+		require.Equal(t, tokensToCoin(t, 2), poolsInfo.Pools[miner.ID][0].Balance)
 	})
 
 	t.Run("Staking tokens with insufficient balance should fail", func(t *testing.T) {
@@ -275,26 +276,24 @@ func TestMinerStake(t *testing.T) {
 		wallet, err := getWallet(t, configPath)
 		require.Nil(t, err, "error getting wallet")
 
-		max_stake, err := miner.Settings.MaxStake.Int64()
-		require.Nil(t, err)
-
-		maxStake := intToZCN(max_stake)
+		maxStake := miner.Settings.MaxStake
 
-		for i := 0; i < (int(maxStake)/9)+1; i++ {
+		// faucet pours 9 ZCN at most
+		for i := 0; i < int(maxStake/tokensToCoin(t, 9))+1; i++ {
 			_, err = executeFaucetWithTokens(t, configPath, 9.0)
 			require.Nil(t, err, "error executing faucet")
 		}
 
 		balance := getBalanceFromSharders(t, wallet.ClientID)
-		require.Greater(t, balance, max_stake)
+		require.Greater(t, balance, maxStake)
 
 		output, err = minerOrSharderLock(t, configPath, createParams(map[string]interface{}{
 			"id":     miner.ID,
-			"tokens": intToZCN(max_stake) + 1,
+			"tokens": addCoins(t, maxStake, tokensToCoin(t, 1)),
 		}), true)
 		require.NotNil(t, err, "expected error when staking more tokens than max_stake but got output: ", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
-		require.Equal(t, fmt.Sprintf("delegate_pool_add: stake is greater than max allowed: %d \\u003e %d", max_stake+1e10, max_stake), output[0])
+		require.Equal(t, fmt.Sprintf("delegate_pool_add: stake is greater than max allowed: %d \\u003e %d", addCoins(t, maxStake, tokensToCoin(t, 1)), maxStake), output[0])
 	})
 
 	t.Run("Staking tokens less than min_stake of miner node should fail", func(t *testing.T) {
@@ -332,35 +331,36 @@ func TestMinerStake(t *testing.T) {
 		wallet, err := getWallet(t, configPath)
 		require.Nil(t, err, "error getting wallet")
 
-		max_stake, err := miner.Settings.MaxStake.Int64()
-		require.Nil(t, err)
-
-		maxStake := intToZCN(max_stake)
+		maxStake := miner.Settings.MaxStake
 
-		for i := 0; i < (int(maxStake)/9)+1; i++ {
+		// faucet pours 9 ZCN at most
+		for i := 0; i < int(maxStake/tokensToCoin(t, 9))+1; i++ {
 			_, err = executeFaucetWithTokens(t, configPath, 9.0)
 			require.Nil(t, err, "error executing faucet")
 		}
 
 		balance := getBalanceFromSharders(t, wallet.ClientID)
-		require.Greater(t, balance, max_stake)
+		require.Greater(t, balance, maxStake)
+
+		halfMaxStake, _, err := currency.DistributeCoin(maxStake, 2)
+		require.Nil(t, err)
 
 		_, err = minerOrSharderLock(t, configPath, createParams(map[string]interface{}{
 			"id":     miner.ID,
-			"tokens": intToZCN(max_stake) / 2,
+			"tokens": halfMaxStake,
 		}), true)
 		require.Nil(t, err, "error staking tokens against a node")
 
 		waitForStakePoolActive(t)
 		output, err = minerOrSharderLock(t, configPath, createParams(map[string]interface{}{
 			"id":     miner.ID,
-			"tokens": intToZCN(max_stake)/2 + 1,
+			"tokens": addCoins(t, halfMaxStake, tokensToCoin(t, 1)),
 		}), true)
 
 		// FIXME: Change to NotNil and Equal post-fix
 		require.Nil(t, err, "expected error when staking more tokens than max_stake through multiple stakes but got output: ", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
-		require.NotEqual(t, fmt.Sprintf("delegate_pool_add: stake is greater than max allowed: %d \\u003e %d", max_stake+1e10, max_stake), output[0])
+		require.NotEqual(t, fmt.Sprintf("delegate_pool_add: stake is greater than max allowed: %d \\u003e %d", addCoins(t, maxStake, tokensToCoin(t, 1)), maxStake), output[0])
 	})
 
 	// this case covers both invalid miner and sharder id, so is not repeated in zwalletcli_sharder_stake_test.go
@@ -439,7 +439,7 @@ func minerSharderPoolInfoForWallet(t *testing.T, cliConfigFilename, params, wall
 	}
 }
 
-func getBalanceFromSharders(t *testing.T, clientId string) int64 {
+func getBalanceFromSharders(t *testing.T, clientId string) currency.Coin {
 	output, err := getSharders(t, configPath)
 	require.Nil(t, err, "get sharders failed", strings.Join(output, "\n"))
 	require.Greater(t, len(output), 1)
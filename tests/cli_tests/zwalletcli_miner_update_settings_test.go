@@ -47,8 +47,8 @@ func TestMinerUpdateSettings(t *testing.T) {
 		output, err := minerUpdateSettings(t, configPath, createParams(map[string]interface{}{
 			"id":            miner.ID,
 			"num_delegates": miner.Settings.MaxNumDelegates,
-			"min_stake":     miner.Settings.MinStake / 1e10,
-			"max_stake":     miner.Settings.MaxStake / 1e10,
+			"min_stake":     miner.Settings.MinStake,
+			"max_stake":     miner.Settings.MaxStake,
 		}), true)
 		require.Nil(t, err, "error reverting miner settings after test")
 		require.Len(t, output, 2)
@@ -94,9 +94,7 @@ func TestMinerUpdateSettings(t *testing.T) {
 		var minerInfo climodel.Node
 		err = json.Unmarshal([]byte(output[0]), &minerInfo)
 		require.Nil(t, err, "error unmarshalling miner info")
-		min_stake, err := minerInfo.Settings.MinStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, 1, int(intToZCN(min_stake)))
+		require.Equal(t, tokensToCoin(t, 1), minerInfo.Settings.MinStake)
 	})
 
 	t.Run("Miner update num_delegates by delegate wallet should work", func(t *testing.T) {
@@ -169,9 +167,7 @@ func TestMinerUpdateSettings(t *testing.T) {
 		var minerInfo climodel.Node
 		err = json.Unmarshal([]byte(output[0]), &minerInfo)
 		require.Nil(t, err, "error unmarshalling miner info")
-		max_stake, err := minerInfo.Settings.MaxStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, 99, int(intToZCN(max_stake)))
+		require.Equal(t, tokensToCoin(t, 99), minerInfo.Settings.MaxStake)
 	})
 
 	t.Run("Miner update multiple settings with delegate wallet should work", func(t *testing.T) {
@@ -211,12 +207,8 @@ func TestMinerUpdateSettings(t *testing.T) {
 		err = json.Unmarshal([]byte(output[0]), &minerInfo)
 		require.Nil(t, err, "error unmarshalling miner info")
 		require.Equal(t, 5, minerInfo.Settings.MaxNumDelegates)
-		max_stake, err := minerInfo.Settings.MaxStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, 99, int(intToZCN(max_stake)))
-		min_stake, err := minerInfo.Settings.MinStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, 1, int(intToZCN(min_stake)))
+		require.Equal(t, tokensToCoin(t, 99), minerInfo.Settings.MaxStake)
+		require.Equal(t, tokensToCoin(t, 1), minerInfo.Settings.MinStake)
 	})
 
 	t.Run("Miner update min_stake with less than global min stake should fail", func(t *testing.T) {
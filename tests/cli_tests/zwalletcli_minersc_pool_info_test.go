@@ -50,7 +50,7 @@ func TestMinerSCUserPoolInfo(t *testing.T) {
 		require.Nil(t, err, "error unmarshalling Miner SC User Pool")
 		require.Len(t, poolsInfo.Pools[miner01ID], 1)
 		require.Equal(t, w.ClientID, poolsInfo.Pools[miner01ID][0].ID)
-		require.Equal(t, float64(1), intToZCN(poolsInfo.Pools[miner01ID][0].Balance))
+		require.Equal(t, tokensToCoin(t, 1), poolsInfo.Pools[miner01ID][0].Balance)
 
 		// teardown
 		_, err = minerOrSharderUnlock(t, configPath, createParams(map[string]interface{}{
@@ -97,7 +97,7 @@ func TestMinerSCUserPoolInfo(t *testing.T) {
 		require.Nil(t, err, "error unmarshalling Miner SC User Pool")
 		require.Len(t, poolsInfo.Pools[sharder01ID], 1)
 		require.Equal(t, w.ClientID, poolsInfo.Pools[sharder01ID][0].ID)
-		require.Equal(t, float64(1), intToZCN(poolsInfo.Pools[sharder01ID][0].Balance))
+		require.Equal(t, tokensToCoin(t, 1), poolsInfo.Pools[sharder01ID][0].Balance)
 
 		// teardown
 		_, err = minerOrSharderUnlock(t, configPath, createParams(map[string]interface{}{
@@ -154,11 +154,11 @@ func TestMinerSCUserPoolInfo(t *testing.T) {
 
 		require.Len(t, poolsInfo.Pools[miner01ID], 1)
 		require.Equal(t, w.ClientID, poolsInfo.Pools[miner01ID][0].ID)
-		require.Equal(t, float64(1), intToZCN(poolsInfo.Pools[miner01ID][0].Balance))
+		require.Equal(t, tokensToCoin(t, 1), poolsInfo.Pools[miner01ID][0].Balance)
 
 		require.Len(t, poolsInfo.Pools[sharder01ID], 1)
 		require.Equal(t, w.ClientID, poolsInfo.Pools[sharder01ID][0].ID)
-		require.Equal(t, float64(1), intToZCN(poolsInfo.Pools[sharder01ID][0].Balance))
+		require.Equal(t, tokensToCoin(t, 1), poolsInfo.Pools[sharder01ID][0].Balance)
 
 		// teardown
 		_, err = minerOrSharderUnlock(t, configPath, createParams(map[string]interface{}{
@@ -1,8 +1,8 @@
 package cli_tests
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"os"
 	"regexp"
 	"strings"
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
+	cliutil "github.com/0chain/system_test/internal/cli/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,7 +52,7 @@ func TestSharderStake(t *testing.T) {
 
 		poolsInfo, err := pollForPoolInfo(t, sharder.ID)
 		require.Nil(t, err)
-		require.Equal(t, float64(1), intToZCN(poolsInfo.Balance))
+		require.Equal(t, tokensToCoin(t, 1), poolsInfo.Balance)
 
 		// unlock should work
 		output, err = minerOrSharderUnlock(t, configPath, createParams(map[string]interface{}{
@@ -195,32 +196,34 @@ func TestSharderStake(t *testing.T) {
 	})
 }
 
+// roundWaitTimeout bounds how long tests wait for rounds to be finalized
+const roundWaitTimeout = 3 * time.Minute
+
+// getRoundWatcher returns the round watcher all tests share, polling the sharders of the magic block
+func getRoundWatcher(t *testing.T) *cliutil.RoundWatcher {
+	return cliutil.Rounds(func() []string {
+		var urls []string
+		for _, sharder := range getShardersList(t) {
+			urls = append(urls, getNodeBaseURL(sharder.Host, sharder.Port))
+		}
+		return urls
+	})
+}
+
 // waitForRoundsGT waits for at least r rounds passed
 func waitForRoundsGT(t *testing.T, r int) error {
-	var (
-		lfb      = getLatestFinalizedBlock(t)
-		endRound = lfb.Round + int64(r)
-		checkTk  = time.NewTicker(5 * time.Second)
-	)
+	ctx, cancel := context.WithTimeout(context.Background(), roundWaitTimeout)
+	defer cancel()
 
-	for {
-		select {
-		case <-time.After(3 * time.Minute):
-			return fmt.Errorf("wait timeout")
-		case <-checkTk.C:
-			lfb = getLatestFinalizedBlock(t)
-			if lfb.Round > endRound {
-				return nil
-			}
-		}
-	}
+	_, err := getRoundWatcher(t).WaitForRounds(ctx, int64(r)+1)
+	return err
 }
 
+// waitForStakePoolActive waits for the next reward round of minersc, which activates pending stake pools
 func waitForStakePoolActive(t *testing.T) {
-	vs := getMinerSCConfiguration(t)
-	round, ok := vs["reward_round_frequency"]
-	require.True(t, ok, "could not get reward_round_frequency from minersc config")
+	ctx, cancel := context.WithTimeout(context.Background(), roundWaitTimeout)
+	defer cancel()
 
-	err := waitForRoundsGT(t, int(round))
+	_, err := getRoundWatcher(t).WaitForRewardRound(ctx)
 	require.NoError(t, err)
 }
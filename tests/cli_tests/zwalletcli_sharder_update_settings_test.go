@@ -66,15 +66,11 @@ func TestSharderUpdateSettings(t *testing.T) {
 			}
 		}
 
-		old_max_stake, err := oldSharderInfo.Settings.MaxStake.Int64()
-		require.Nil(t, err)
-		old_min_stake, err := oldSharderInfo.Settings.MinStake.Int64()
-		require.Nil(t, err)
 		output, err := sharderUpdateSettings(t, configPath, createParams(map[string]interface{}{
 			"id":            sharder01ID,
 			"num_delegates": oldSharderInfo.Settings.MaxNumDelegates,
-			"max_stake":     intToZCN(old_max_stake),
-			"min_stake":     intToZCN(old_min_stake),
+			"max_stake":     oldSharderInfo.Settings.MaxStake,
+			"min_stake":     oldSharderInfo.Settings.MinStake,
 		}), true)
 		require.Nil(t, err, "error reverting sharder settings after test")
 		require.Len(t, output, 2)
@@ -113,9 +109,7 @@ func TestSharderUpdateSettings(t *testing.T) {
 		var sharderInfo climodel.Node
 		err = json.Unmarshal([]byte(output[0]), &sharderInfo)
 		require.Nil(t, err, "error unmarshalling sharder info")
-		min_stake, err := sharderInfo.Settings.MinStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, 1, int(intToZCN(min_stake)))
+		require.Equal(t, tokensToCoin(t, 1), sharderInfo.Settings.MinStake)
 	})
 
 	t.Run("Sharder update num_delegates by delegate wallet should work", func(t *testing.T) {
@@ -183,9 +177,7 @@ func TestSharderUpdateSettings(t *testing.T) {
 		var sharderInfo climodel.Node
 		err = json.Unmarshal([]byte(output[0]), &sharderInfo)
 		require.Nil(t, err, "error unmarshalling sharder info")
-		max_stake, err := sharderInfo.Settings.MaxStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, 99, int(intToZCN(max_stake)))
+		require.Equal(t, tokensToCoin(t, 99), sharderInfo.Settings.MaxStake)
 	})
 
 	t.Run("Sharder update multiple settings with delegate wallet should work", func(t *testing.T) {
@@ -222,12 +214,8 @@ func TestSharderUpdateSettings(t *testing.T) {
 		err = json.Unmarshal([]byte(output[0]), &sharderInfo)
 		require.Nil(t, err, "error unmarshalling sharder info")
 		require.Equal(t, 8, sharderInfo.Settings.MaxNumDelegates)
-		min_stake, err := sharderInfo.Settings.MinStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, 2, int(intToZCN(min_stake)))
-		max_stake, err := sharderInfo.Settings.MaxStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, 98, int(intToZCN(max_stake)))
+		require.Equal(t, tokensToCoin(t, 2), sharderInfo.Settings.MinStake)
+		require.Equal(t, tokensToCoin(t, 98), sharderInfo.Settings.MaxStake)
 	})
 
 	t.Run("Sharder update with min_stake less than global min should fail", func(t *testing.T) {